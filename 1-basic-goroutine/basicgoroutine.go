@@ -0,0 +1,334 @@
+// Package basicgoroutine is module 1 of the learning path: fundamental
+// goroutine concepts.
+//
+// Each numbered example is a Demo. Section headings and results are written
+// to an io.Writer, while the goroutines report what they do as lifecycle
+// events, so the examples can be run from tests and other programs as well
+// as from cmd/1-basic-goroutine.
+package basicgoroutine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/lifecycle"
+	"go-concurrency/queue"
+	"go-concurrency/supervisor"
+	"go-concurrency/taskgroup"
+)
+
+// Demo is one numbered example of the module. Headings and results are
+// written to w; what the goroutines do is recorded on rec. Every sleep and
+// timeout is measured on clk, so on a clock.Fake advanced by its Drive
+// method the demo finishes at once, printing the same output on every run.
+type Demo func(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock)
+
+// Demos lists the module's examples in order.
+var Demos = []Demo{
+	// 1. Basic Goroutine Creation
+	BasicGoroutineCreation,
+
+	// 2. Goroutine with Parameters
+	GoroutineWithParameters,
+
+	// 3. Multiple Goroutines
+	MultipleGoroutines,
+
+	// 4. Goroutine Communication
+	GoroutineCommunication,
+
+	// 5. Goroutine Error Handling
+	GoroutineErrorHandling,
+
+	// 6. Goroutine Best Practices
+	GoroutineBestPractices,
+}
+
+// Run runs every demo in order on clk, each with a fresh Recorder built
+// from opts. The tests check that no demo leaves a goroutine running
+// behind it.
+func Run(w io.Writer, clk clock.Clock, opts ...lifecycle.Option) {
+	fmt.Fprintln(w, "=== Basic Goroutines ===")
+	fmt.Fprintln(w, "Demonstrating fundamental goroutine concepts in Go")
+	fmt.Fprintln(w)
+
+	for _, demo := range Demos {
+		rec := lifecycle.New(append([]lifecycle.Option{lifecycle.WithMemory()}, opts...)...)
+		demo(w, rec, clk)
+	}
+}
+
+// 1. Basic Goroutine Creation
+// Demonstrates simple goroutine creation, lifecycle, and execution order
+func BasicGoroutineCreation(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 1. Basic Goroutine Creation ===")
+
+	// Simple goroutine creation
+	fmt.Fprintln(w, "Creating a basic goroutine...")
+	var group taskgroup.Group
+	start := clk.Now()
+	rec.Spawned("hello")
+	task := group.Go(func() {
+		rec.Run("hello", func() {
+			rec.Progressf("hello", "Hello from goroutine!")
+			clk.Sleep(50 * time.Millisecond)
+		})
+	})
+
+	// Wait for goroutine to complete
+	<-task.Done()
+	fmt.Fprintf(w, "Basic goroutine completed in %v!\n", clk.Since(start).Round(time.Millisecond))
+	fmt.Fprintln(w)
+}
+
+// 2. Goroutine with Parameters
+// Demonstrates passing parameters and avoiding closure variable capture issues
+func GoroutineWithParameters(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 2. Goroutine with Parameters ===")
+
+	var group taskgroup.Group
+
+	// Correct way: Pass parameters directly
+	fmt.Fprintln(w, "Passing parameters correctly:")
+	worker := func(id int) func() {
+		label := fmt.Sprintf("worker-%d", id)
+		rec.Spawned(label)
+		return func() {
+			rec.Run(label, func() {
+				rec.Progressf(label, "running with id = %d", id)
+				clk.Sleep(50 * time.Millisecond)
+			})
+		}
+	}
+	for i := 1; i <= 3; i++ {
+		group.Go(worker(i)) // Pass 'i' as parameter
+	}
+	group.Wait()
+
+	// Demonstrate closure variable capture issue
+	// Since Go 1.22 every iteration gets its own 'i', so capturing the loop
+	// variable is safe. Capturing a variable declared outside the loop is not:
+	// all goroutines share 'current' and see whatever it holds when they run.
+	// cmd/gocapture reports this closure.
+	fmt.Fprintln(w, "Demonstrating closure variable capture issue:")
+	start := make(chan struct{})
+	current := 0
+	for i := 1; i <= 3; i++ {
+		current = i
+		label := fmt.Sprintf("problematic-%d", i)
+		rec.Spawned(label)
+		group.Go(func() {
+			rec.Run(label, func() {
+				rec.Blocked(label, "start")
+				<-start // Run only after the loop has finished
+				rec.Progressf(label, "sees current = %d", current)
+			})
+		})
+	}
+	close(start) // Every goroutine now sees the final value (3)
+
+	// Wait for every goroutine instead of sleeping
+	group.Wait()
+	fmt.Fprintln(w, "Goroutines with parameters completed!")
+	fmt.Fprintln(w)
+}
+
+// 3. Multiple Goroutines
+// Demonstrates multiple concurrent goroutines with WaitGroup coordination
+func MultipleGoroutines(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 3. Multiple Goroutines ===")
+
+	var wg sync.WaitGroup
+
+	// Create multiple goroutines
+	for i := 1; i <= 5; i++ {
+		wg.Add(1) // Increment WaitGroup counter
+		rec.Go(fmt.Sprintf("worker-%d", i), func() {
+			defer wg.Done() // Decrement counter when done
+			clk.Sleep(time.Duration(i*50) * time.Millisecond)
+		})
+	}
+
+	fmt.Fprintln(w, "Waiting for all goroutines to complete...")
+	wg.Wait() // Wait for all goroutines to finish
+	fmt.Fprintln(w, "All goroutines completed!")
+	fmt.Fprintln(w)
+}
+
+// 4. Goroutine Communication
+// Demonstrates basic producer-consumer pattern using channels
+func GoroutineCommunication(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 4. Goroutine Communication ===")
+
+	// Create a queue for communication; it closes itself once every
+	// registered producer is done, so nobody has to decide who closes it
+	messages := queue.New[string](3) // Bounded like a buffered channel
+	producer, err := messages.AddProducer()
+	if err != nil {
+		fmt.Fprintln(w, "Cannot add producer:", err)
+		return
+	}
+	ctx := context.Background()
+	var wg sync.WaitGroup
+
+	// Producer goroutine
+	wg.Add(1)
+	rec.Go("producer", func() {
+		defer wg.Done()
+		// Record before finishing: the queue closes on Done, which happens
+		// before the consumer sees ErrClosed, so the event precedes its Finished
+		defer producer.Done()
+		defer rec.Progressf("producer", "closing messages")
+
+		for _, msg := range []string{"Hello", "World", "from", "Go", "channels"} {
+			rec.Progressf("producer", "sending: %s", msg)
+			if err := producer.Put(ctx, msg); err != nil {
+				return
+			}
+			clk.Sleep(100 * time.Millisecond)
+		}
+	})
+
+	// Consumer goroutine; Run records its Finished event before wg.Done,
+	// so the event is there once Wait returns
+	wg.Add(1)
+	rec.Spawned("consumer")
+	go func() {
+		defer wg.Done()
+		rec.Run("consumer", func() {
+			for {
+				msg, err := messages.Get(ctx)
+				if err != nil {
+					return // queue.ErrClosed: every producer is done
+				}
+				rec.Progressf("consumer", "received: %s", msg)
+				clk.Sleep(50 * time.Millisecond)
+			}
+		})
+	}()
+
+	// Wait for communication to complete
+	wg.Wait()
+	stats := messages.Stats()
+	fmt.Fprintf(w, "Queue stats: %d put, %d received, closed = %v\n", stats.Puts, stats.Gets, stats.Closed)
+
+	// The recorded events prove the ordering: the consumer only stops once
+	// the producer has closed the queue
+	closed := lifecycle.Match(lifecycle.Progress, "producer", "closing messages")
+	finished := lifecycle.Match(lifecycle.Finished, "consumer")
+	fmt.Fprintln(w, "Consumer finished after producer closed messages:", rec.Before(closed, finished))
+	fmt.Fprintln(w, "Goroutine communication completed!")
+	fmt.Fprintln(w)
+}
+
+// 5. Goroutine Error Handling
+// Demonstrates error handling and panic recovery in goroutines
+func GoroutineErrorHandling(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 5. Goroutine Error Handling ===")
+
+	// The supervisor recovers panics and reports every error as it happens
+	// Sinks are called from the failing goroutines, so writes to w are
+	// serialized
+	var mu sync.Mutex
+	sup := supervisor.New(supervisor.WithSink(supervisor.SinkFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "  Error received: %v\n", err)
+	})))
+	ctx := context.Background()
+
+	// Goroutine with potential panic
+	rec.Spawned("panicker")
+	sup.Go(ctx, func(ctx context.Context) error {
+		rec.Run("panicker", func() {
+			clk.Sleep(100 * time.Millisecond)
+
+			// Simulate a panic
+			panic("simulated panic in goroutine")
+		})
+		return nil
+	})
+
+	// Goroutine that returns an error
+	rec.Spawned("failer")
+	sup.Go(ctx, func(ctx context.Context) error {
+		var err error
+		rec.Run("failer", func() {
+			clk.Sleep(50 * time.Millisecond)
+			err = fmt.Errorf("simulated error in goroutine")
+		})
+		return err
+	})
+
+	// Wait for all goroutines and inspect the joined error
+	err := sup.Wait()
+	var panicErr *supervisor.PanicError
+	if errors.As(err, &panicErr) {
+		fmt.Fprintf(w, "  Recovered panic value: %v\n", panicErr.Value)
+	}
+	fmt.Fprintln(w, "Goroutine error handling completed!")
+	fmt.Fprintln(w)
+}
+
+// 6. Goroutine Best Practices
+// Demonstrates proper cleanup, resource management, and avoiding leaks
+func GoroutineBestPractices(w io.Writer, rec *lifecycle.Recorder, clk clock.Clock) {
+	fmt.Fprintln(w, "=== 6. Goroutine Best Practices ===")
+
+	// Using context for cancellation
+	ctx, cancel := clk.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel() // Always call cancel to free resources
+
+	// Goroutine with proper cleanup
+	var group taskgroup.Group
+	rec.Spawned("looper")
+	canceller := group.Go(func() {
+		rec.Run("looper", func() {
+			defer rec.Progressf("looper", "cleanup completed")
+
+			for {
+				select {
+				case <-ctx.Done():
+					rec.Progressf("looper", "cancelled via context")
+					return
+				default:
+					rec.Progressf("looper", "working...")
+					clk.Sleep(50 * time.Millisecond)
+				}
+			}
+		})
+	})
+
+	// Goroutine with resource management
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	rec.Go("resource", func() {
+		defer wg.Done()
+		defer rec.Progressf("resource", "cleanup completed")
+
+		// Simulate resource allocation
+		rec.Progressf("resource", "allocating resources...")
+		clk.Sleep(100 * time.Millisecond)
+
+		// Simulate work
+		rec.Progressf("resource", "working with resources...")
+		clk.Sleep(100 * time.Millisecond)
+
+		// Resources are automatically cleaned up via defer
+	})
+
+	// Wait for goroutine to complete
+	wg.Wait()
+
+	// Wait for the context-driven goroutine to observe cancellation and exit
+	<-canceller.Done()
+
+	fmt.Fprintln(w, "Goroutine best practices completed!")
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,82 @@
+package basicgoroutine
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/leakcheck"
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/lifecycle"
+)
+
+// Every demo must leave no goroutine running behind it.
+func TestDemosDoNotLeak(t *testing.T) {
+	demos := []struct {
+		name string
+		demo Demo
+	}{
+		{"BasicGoroutineCreation", BasicGoroutineCreation},
+		{"GoroutineWithParameters", GoroutineWithParameters},
+		{"MultipleGoroutines", MultipleGoroutines},
+		{"GoroutineCommunication", GoroutineCommunication},
+		{"GoroutineErrorHandling", GoroutineErrorHandling},
+		{"GoroutineBestPractices", GoroutineBestPractices},
+	}
+	if len(demos) != len(Demos) {
+		t.Fatalf("testing %d demos, the module has %d", len(demos), len(Demos))
+	}
+	for _, d := range demos {
+		t.Run(d.name, func(t *testing.T) {
+			rec := lifecycle.New(lifecycle.WithMemory())
+			leaktest.VerifyNone(t, func() { d.demo(io.Discard, rec, clock.Real()) })
+		})
+	}
+}
+
+// On a clock.Fake driven by Drive, every demo writes the same output,
+// each goroutine records the same events and the demo ends at the same
+// fake time on every run. How the events of different goroutines
+// interleave is still up to the scheduler.
+func TestDemosAreDeterministicOnFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	run := func(d Demo) (out string, events map[string][]string, took time.Duration) {
+		var b strings.Builder
+		rec := lifecycle.New(lifecycle.WithMemory())
+		clk := clock.NewFake(start)
+		// Waiting for the demo's goroutines to exit also waits for the
+		// finished events they record on the way out.
+		if report := leakcheck.Check(func() { clk.Drive(func() { d(&b, rec, clk) }) }); !report.OK() {
+			t.Fatal(report)
+		}
+
+		events = make(map[string][]string)
+		for _, e := range rec.Events() {
+			events[e.Label] = append(events[e.Label], fmt.Sprintf("%s %s", e.Kind, e.Detail))
+		}
+		return b.String(), events, clk.Since(start)
+	}
+
+	for i, d := range Demos {
+		t.Run(fmt.Sprint(i+1), func(t *testing.T) {
+			wantOut, wantEvents, wantTook := run(d)
+			for range 5 {
+				out, events, took := run(d)
+				if out != wantOut {
+					t.Fatalf("output changed between runs:\n%s\nthen:\n%s", wantOut, out)
+				}
+				if !maps.EqualFunc(events, wantEvents, slices.Equal) {
+					t.Fatalf("events changed between runs:\n%v\nthen:\n%v", wantEvents, events)
+				}
+				if took != wantTook {
+					t.Fatalf("took %v of fake time, then %v", wantTook, took)
+				}
+			}
+		})
+	}
+}
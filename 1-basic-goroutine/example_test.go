@@ -0,0 +1,87 @@
+package basicgoroutine_test
+
+import (
+	"os"
+	"time"
+
+	basicgoroutine "go-concurrency/1-basic-goroutine"
+	"go-concurrency/clock"
+	"go-concurrency/lifecycle"
+)
+
+// The demos sleep on a clock.Clock. On a clock.Fake advanced by Drive they
+// finish at once and print the same output every run.
+func ExampleBasicGoroutineCreation() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.BasicGoroutineCreation(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 1. Basic Goroutine Creation ===
+	// Creating a basic goroutine...
+	// Basic goroutine completed in 50ms!
+}
+
+func ExampleGoroutineWithParameters() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.GoroutineWithParameters(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 2. Goroutine with Parameters ===
+	// Passing parameters correctly:
+	// Demonstrating closure variable capture issue:
+	// Goroutines with parameters completed!
+}
+
+func ExampleMultipleGoroutines() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.MultipleGoroutines(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 3. Multiple Goroutines ===
+	// Waiting for all goroutines to complete...
+	// All goroutines completed!
+}
+
+func ExampleGoroutineCommunication() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.GoroutineCommunication(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 4. Goroutine Communication ===
+	// Queue stats: 5 put, 5 received, closed = true
+	// Consumer finished after producer closed messages: true
+	// Goroutine communication completed!
+}
+
+func ExampleGoroutineErrorHandling() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.GoroutineErrorHandling(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 5. Goroutine Error Handling ===
+	//   Error received: simulated error in goroutine
+	//   Error received: panic recovered: simulated panic in goroutine
+	//   Recovered panic value: simulated panic in goroutine
+	// Goroutine error handling completed!
+}
+
+func ExampleGoroutineBestPractices() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() {
+		basicgoroutine.GoroutineBestPractices(os.Stdout, lifecycle.New(lifecycle.WithMemory()), clk)
+	})
+
+	// Output:
+	// === 6. Goroutine Best Practices ===
+	// Goroutine best practices completed!
+}
@@ -0,0 +1,21 @@
+package performance_test
+
+import (
+	"os"
+
+	performance "go-concurrency/10-performance-optimization"
+)
+
+func ExampleRun() {
+	performance.Run(os.Stdout)
+
+	// Output:
+	// === Performance Optimization ===
+	// Run: go run ./cmd/10-performance-optimization
+	// Then implement each performance optimization!
+	//
+	// Useful commands:
+	// - go test -bench=. -benchmem
+	// - go tool pprof
+	// - go tool trace
+}
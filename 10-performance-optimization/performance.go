@@ -1,10 +1,15 @@
-package main
+// Package performance is module 10 of the learning path: performance optimization of concurrent code.
+//
+// Run prints the module's exercises; implement each one in this package.
+package performance
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Performance Optimization ===
 	// This module demonstrates performance optimization techniques for concurrent Go code.
 	// Complete the following exercises:
@@ -55,12 +60,12 @@ func main() {
 	// - Handle edge cases and error scenarios
 	// - Test with realistic workloads
 
-	fmt.Println("=== Performance Optimization ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each performance optimization!")
-	fmt.Println()
-	fmt.Println("Useful commands:")
-	fmt.Println("- go test -bench=. -benchmem")
-	fmt.Println("- go tool pprof")
-	fmt.Println("- go tool trace")
+	fmt.Fprintln(w, "=== Performance Optimization ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/10-performance-optimization")
+	fmt.Fprintln(w, "Then implement each performance optimization!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Useful commands:")
+	fmt.Fprintln(w, "- go test -bench=. -benchmem")
+	fmt.Fprintln(w, "- go tool pprof")
+	fmt.Fprintln(w, "- go tool trace")
 }
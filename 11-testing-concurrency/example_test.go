@@ -0,0 +1,22 @@
+package testingconcurrency_test
+
+import (
+	"os"
+
+	testingconcurrency "go-concurrency/11-testing-concurrency"
+)
+
+func ExampleRun() {
+	testingconcurrency.Run(os.Stdout)
+
+	// Output:
+	// === Testing Concurrent Code ===
+	// Run: go run ./cmd/11-testing-concurrency
+	// Then implement each testing pattern!
+	//
+	// Useful commands:
+	// - go test -race -v
+	// - go test -bench=. -count=10
+	// - go test -timeout=30s
+	// - go test -parallel=4
+}
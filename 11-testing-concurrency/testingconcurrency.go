@@ -1,10 +1,15 @@
-package main
+// Package testingconcurrency is module 11 of the learning path: testing strategies for concurrent code.
+//
+// Run prints the module's exercises; implement each one in this package.
+package testingconcurrency
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Testing Concurrent Code ===
 	// This module demonstrates testing strategies for concurrent Go code.
 	// Complete the following exercises:
@@ -55,13 +60,13 @@ func main() {
 	// - Handle test cleanup and resource management
 	// - Test both success and failure scenarios
 
-	fmt.Println("=== Testing Concurrent Code ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each testing pattern!")
-	fmt.Println()
-	fmt.Println("Useful commands:")
-	fmt.Println("- go test -race -v")
-	fmt.Println("- go test -bench=. -count=10")
-	fmt.Println("- go test -timeout=30s")
-	fmt.Println("- go test -parallel=4")
+	fmt.Fprintln(w, "=== Testing Concurrent Code ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/11-testing-concurrency")
+	fmt.Fprintln(w, "Then implement each testing pattern!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Useful commands:")
+	fmt.Fprintln(w, "- go test -race -v")
+	fmt.Fprintln(w, "- go test -bench=. -count=10")
+	fmt.Fprintln(w, "- go test -timeout=30s")
+	fmt.Fprintln(w, "- go test -parallel=4")
 }
@@ -0,0 +1,69 @@
+package lockfree_test
+
+import (
+	"os"
+
+	lockfree "go-concurrency/12-lock-free-programming"
+)
+
+func ExampleRun() {
+	lockfree.Run(os.Stdout)
+
+	// Output:
+	// === Lock-Free Programming ===
+	// This module demonstrates lock-free programming techniques using atomic operations.
+	// Complete the following exercises:
+	//
+	// 1. ATOMIC OPERATIONS BASICS
+	//    - Use atomic.AddInt64 for counters
+	//    - Implement atomic.Load/Store operations
+	//    - Use atomic.CompareAndSwap
+	//    - Implement atomic pointer operations
+	//
+	// 2. LOCK-FREE COUNTER
+	//    - Implement a thread-safe counter
+	//    - Compare performance with mutex-based counter
+	//    - Handle overflow scenarios
+	//    - Implement atomic increment/decrement
+	//
+	// 3. LOCK-FREE STACK
+	//    - Implement a lock-free stack using atomic operations
+	//    - Handle ABA problem
+	//    - Implement memory ordering
+	//    - Test with concurrent push/pop operations
+	//
+	// 4. LOCK-FREE QUEUE
+	//    - Implement a lock-free queue
+	//    - Handle producer-consumer scenarios
+	//    - Implement memory barriers
+	//    - Test with multiple producers/consumers
+	//
+	// 5. MEMORY ORDERING
+	//    - Understand memory ordering guarantees
+	//    - Implement acquire/release semantics
+	//    - Use memory barriers correctly
+	//    - Test memory ordering scenarios
+	//
+	// 6. ADVANCED LOCK-FREE PATTERNS
+	//    - Implement lock-free hash table
+	//    - Create lock-free ring buffer
+	//    - Implement hazard pointers
+	//    - Handle memory reclamation
+	//
+	// Instructions:
+	// - Use sync/atomic package extensively
+	// - Understand memory model implications
+	// - Implement comprehensive testing
+	// - Compare performance with lock-based alternatives
+	// - Handle edge cases and race conditions
+	// - Document memory ordering choices
+	//
+	// Run: go run ./cmd/12-lock-free-programming
+	// Then implement each lock-free pattern!
+	//
+	// Important Notes:
+	// - Lock-free code is complex and error-prone
+	// - Always benchmark and test thoroughly
+	// - Consider using existing lock-free libraries
+	// - Understand the Go memory model
+}
@@ -0,0 +1,36 @@
+package lockfree
+
+// Counter is a counter built on atomic operations.
+type Counter interface {
+	Add(delta int64)
+	Load() int64
+}
+
+// Stack is a LIFO stack that is safe for concurrent use without locks.
+type Stack interface {
+	Push(v int)
+	Pop() (int, bool)
+}
+
+// Queue is a FIFO queue that is safe for concurrent use without locks.
+type Queue interface {
+	Enqueue(v int)
+	Dequeue() (int, bool)
+}
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 12" to check them.
+type Solution struct {
+	// 2. Lock-Free Counter
+	NewCounter func() Counter
+
+	// 3. Lock-Free Stack
+	NewStack func() Stack
+
+	// 4. Lock-Free Queue
+	NewQueue func() Queue
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -0,0 +1,86 @@
+// Package lockfree is module 12 of the learning path: lock-free programming with atomic operations.
+//
+// Run prints the module's exercises; implement each one in this package.
+package lockfree
+
+import (
+	"fmt"
+	"io"
+	// "sync/atomic"
+	// "time"
+)
+
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
+	fmt.Fprintln(w, "=== Lock-Free Programming ===")
+	fmt.Fprintln(w, "This module demonstrates lock-free programming techniques using atomic operations.")
+	fmt.Fprintln(w, "Complete the following exercises:")
+	fmt.Fprintln(w)
+
+	// TODO: Implement the following lock-free patterns:
+
+	// 1. Atomic Operations Basics
+	fmt.Fprintln(w, "1. ATOMIC OPERATIONS BASICS")
+	fmt.Fprintln(w, "   - Use atomic.AddInt64 for counters")
+	fmt.Fprintln(w, "   - Implement atomic.Load/Store operations")
+	fmt.Fprintln(w, "   - Use atomic.CompareAndSwap")
+	fmt.Fprintln(w, "   - Implement atomic pointer operations")
+	fmt.Fprintln(w)
+
+	// 2. Lock-Free Counter
+	fmt.Fprintln(w, "2. LOCK-FREE COUNTER")
+	fmt.Fprintln(w, "   - Implement a thread-safe counter")
+	fmt.Fprintln(w, "   - Compare performance with mutex-based counter")
+	fmt.Fprintln(w, "   - Handle overflow scenarios")
+	fmt.Fprintln(w, "   - Implement atomic increment/decrement")
+	fmt.Fprintln(w)
+
+	// 3. Lock-Free Stack
+	fmt.Fprintln(w, "3. LOCK-FREE STACK")
+	fmt.Fprintln(w, "   - Implement a lock-free stack using atomic operations")
+	fmt.Fprintln(w, "   - Handle ABA problem")
+	fmt.Fprintln(w, "   - Implement memory ordering")
+	fmt.Fprintln(w, "   - Test with concurrent push/pop operations")
+	fmt.Fprintln(w)
+
+	// 4. Lock-Free Queue
+	fmt.Fprintln(w, "4. LOCK-FREE QUEUE")
+	fmt.Fprintln(w, "   - Implement a lock-free queue")
+	fmt.Fprintln(w, "   - Handle producer-consumer scenarios")
+	fmt.Fprintln(w, "   - Implement memory barriers")
+	fmt.Fprintln(w, "   - Test with multiple producers/consumers")
+	fmt.Fprintln(w)
+
+	// 5. Memory Ordering
+	fmt.Fprintln(w, "5. MEMORY ORDERING")
+	fmt.Fprintln(w, "   - Understand memory ordering guarantees")
+	fmt.Fprintln(w, "   - Implement acquire/release semantics")
+	fmt.Fprintln(w, "   - Use memory barriers correctly")
+	fmt.Fprintln(w, "   - Test memory ordering scenarios")
+	fmt.Fprintln(w)
+
+	// 6. Advanced Lock-Free Patterns
+	fmt.Fprintln(w, "6. ADVANCED LOCK-FREE PATTERNS")
+	fmt.Fprintln(w, "   - Implement lock-free hash table")
+	fmt.Fprintln(w, "   - Create lock-free ring buffer")
+	fmt.Fprintln(w, "   - Implement hazard pointers")
+	fmt.Fprintln(w, "   - Handle memory reclamation")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Instructions:")
+	fmt.Fprintln(w, "- Use sync/atomic package extensively")
+	fmt.Fprintln(w, "- Understand memory model implications")
+	fmt.Fprintln(w, "- Implement comprehensive testing")
+	fmt.Fprintln(w, "- Compare performance with lock-based alternatives")
+	fmt.Fprintln(w, "- Handle edge cases and race conditions")
+	fmt.Fprintln(w, "- Document memory ordering choices")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run: go run ./cmd/12-lock-free-programming")
+	fmt.Fprintln(w, "Then implement each lock-free pattern!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Important Notes:")
+	fmt.Fprintln(w, "- Lock-free code is complex and error-prone")
+	fmt.Fprintln(w, "- Always benchmark and test thoroughly")
+	fmt.Fprintln(w, "- Consider using existing lock-free libraries")
+	fmt.Fprintln(w, "- Understand the Go memory model")
+}
@@ -1,81 +0,0 @@
-package main
-
-import (
-	"fmt"
-	// "sync/atomic"
-	// "time"
-)
-
-func main() {
-	fmt.Println("=== Lock-Free Programming ===")
-	fmt.Println("This module demonstrates lock-free programming techniques using atomic operations.")
-	fmt.Println("Complete the following exercises:")
-	fmt.Println()
-
-	// TODO: Implement the following lock-free patterns:
-
-	// 1. Atomic Operations Basics
-	fmt.Println("1. ATOMIC OPERATIONS BASICS")
-	fmt.Println("   - Use atomic.AddInt64 for counters")
-	fmt.Println("   - Implement atomic.Load/Store operations")
-	fmt.Println("   - Use atomic.CompareAndSwap")
-	fmt.Println("   - Implement atomic pointer operations")
-	fmt.Println()
-
-	// 2. Lock-Free Counter
-	fmt.Println("2. LOCK-FREE COUNTER")
-	fmt.Println("   - Implement a thread-safe counter")
-	fmt.Println("   - Compare performance with mutex-based counter")
-	fmt.Println("   - Handle overflow scenarios")
-	fmt.Println("   - Implement atomic increment/decrement")
-	fmt.Println()
-
-	// 3. Lock-Free Stack
-	fmt.Println("3. LOCK-FREE STACK")
-	fmt.Println("   - Implement a lock-free stack using atomic operations")
-	fmt.Println("   - Handle ABA problem")
-	fmt.Println("   - Implement memory ordering")
-	fmt.Println("   - Test with concurrent push/pop operations")
-	fmt.Println()
-
-	// 4. Lock-Free Queue
-	fmt.Println("4. LOCK-FREE QUEUE")
-	fmt.Println("   - Implement a lock-free queue")
-	fmt.Println("   - Handle producer-consumer scenarios")
-	fmt.Println("   - Implement memory barriers")
-	fmt.Println("   - Test with multiple producers/consumers")
-	fmt.Println()
-
-	// 5. Memory Ordering
-	fmt.Println("5. MEMORY ORDERING")
-	fmt.Println("   - Understand memory ordering guarantees")
-	fmt.Println("   - Implement acquire/release semantics")
-	fmt.Println("   - Use memory barriers correctly")
-	fmt.Println("   - Test memory ordering scenarios")
-	fmt.Println()
-
-	// 6. Advanced Lock-Free Patterns
-	fmt.Println("6. ADVANCED LOCK-FREE PATTERNS")
-	fmt.Println("   - Implement lock-free hash table")
-	fmt.Println("   - Create lock-free ring buffer")
-	fmt.Println("   - Implement hazard pointers")
-	fmt.Println("   - Handle memory reclamation")
-	fmt.Println()
-
-	fmt.Println("Instructions:")
-	fmt.Println("- Use sync/atomic package extensively")
-	fmt.Println("- Understand memory model implications")
-	fmt.Println("- Implement comprehensive testing")
-	fmt.Println("- Compare performance with lock-based alternatives")
-	fmt.Println("- Handle edge cases and race conditions")
-	fmt.Println("- Document memory ordering choices")
-	fmt.Println()
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each lock-free pattern!")
-	fmt.Println()
-	fmt.Println("Important Notes:")
-	fmt.Println("- Lock-free code is complex and error-prone")
-	fmt.Println("- Always benchmark and test thoroughly")
-	fmt.Println("- Consider using existing lock-free libraries")
-	fmt.Println("- Understand the Go memory model")
-}
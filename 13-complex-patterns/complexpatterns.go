@@ -0,0 +1,82 @@
+// Package complexpatterns is module 13 of the learning path: concurrency patterns for complex systems.
+//
+// Run prints the module's exercises; implement each one in this package.
+package complexpatterns
+
+import (
+	"fmt"
+	"io"
+	// "time"
+)
+
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
+	fmt.Fprintln(w, "=== Complex Concurrency Patterns ===")
+	fmt.Fprintln(w, "This module demonstrates advanced concurrency patterns for complex systems.")
+	fmt.Fprintln(w, "Complete the following exercises:")
+	fmt.Fprintln(w)
+
+	// TODO: Implement the following complex patterns:
+
+	// 1. Actor Model Pattern
+	fmt.Fprintln(w, "1. ACTOR MODEL PATTERN")
+	fmt.Fprintln(w, "   - Implement actor-based concurrency")
+	fmt.Fprintln(w, "   - Create actor mailboxes")
+	fmt.Fprintln(w, "   - Implement actor supervision")
+	fmt.Fprintln(w, "   - Handle actor lifecycle management")
+	fmt.Fprintln(w)
+
+	// 2. CSP (Communicating Sequential Processes) Pattern
+	fmt.Fprintln(w, "2. CSP PATTERN")
+	fmt.Fprintln(w, "   - Implement CSP-style communication")
+	fmt.Fprintln(w, "   - Create process networks")
+	fmt.Fprintln(w, "   - Implement channel-based processes")
+	fmt.Fprintln(w, "   - Handle process synchronization")
+	fmt.Fprintln(w)
+
+	// 3. Reactive Streams Pattern
+	fmt.Fprintln(w, "3. REACTIVE STREAMS PATTERN")
+	fmt.Fprintln(w, "   - Implement reactive programming")
+	fmt.Fprintln(w, "   - Create observable streams")
+	fmt.Fprintln(w, "   - Implement backpressure handling")
+	fmt.Fprintln(w, "   - Add stream operators (map, filter, reduce)")
+	fmt.Fprintln(w)
+
+	// 4. Event Sourcing Pattern
+	fmt.Fprintln(w, "4. EVENT SOURCING PATTERN")
+	fmt.Fprintln(w, "   - Implement event-driven architecture")
+	fmt.Fprintln(w, "   - Create event stores")
+	fmt.Fprintln(w, "   - Implement event replay")
+	fmt.Fprintln(w, "   - Handle event ordering and consistency")
+	fmt.Fprintln(w)
+
+	// 5. Saga Pattern
+	fmt.Fprintln(w, "5. SAGA PATTERN")
+	fmt.Fprintln(w, "   - Implement distributed transactions")
+	fmt.Fprintln(w, "   - Create compensation logic")
+	fmt.Fprintln(w, "   - Handle saga orchestration")
+	fmt.Fprintln(w, "   - Implement saga persistence")
+	fmt.Fprintln(w)
+
+	// 6. CQRS (Command Query Responsibility Segregation) Pattern
+	fmt.Fprintln(w, "6. CQRS PATTERN")
+	fmt.Fprintln(w, "   - Separate command and query models")
+	fmt.Fprintln(w, "   - Implement event handlers")
+	fmt.Fprintln(w, "   - Create read/write model synchronization")
+	fmt.Fprintln(w, "   - Handle eventual consistency")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Instructions:")
+	fmt.Fprintln(w, "- Implement each pattern with proper error handling")
+	fmt.Fprintln(w, "- Add comprehensive logging and monitoring")
+	fmt.Fprintln(w, "- Create realistic examples and use cases")
+	fmt.Fprintln(w, "- Implement proper resource cleanup")
+	fmt.Fprintln(w, "- Add performance metrics and benchmarks")
+	fmt.Fprintln(w, "- Handle failure scenarios and recovery")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run: go run ./cmd/13-complex-patterns")
+	fmt.Fprintln(w, "Then implement each complex pattern!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Note: These patterns are complex and require deep understanding")
+	fmt.Fprintln(w, "of distributed systems and concurrent programming concepts.")
+}
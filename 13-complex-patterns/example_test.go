@@ -0,0 +1,66 @@
+package complexpatterns_test
+
+import (
+	"os"
+
+	complexpatterns "go-concurrency/13-complex-patterns"
+)
+
+func ExampleRun() {
+	complexpatterns.Run(os.Stdout)
+
+	// Output:
+	// === Complex Concurrency Patterns ===
+	// This module demonstrates advanced concurrency patterns for complex systems.
+	// Complete the following exercises:
+	//
+	// 1. ACTOR MODEL PATTERN
+	//    - Implement actor-based concurrency
+	//    - Create actor mailboxes
+	//    - Implement actor supervision
+	//    - Handle actor lifecycle management
+	//
+	// 2. CSP PATTERN
+	//    - Implement CSP-style communication
+	//    - Create process networks
+	//    - Implement channel-based processes
+	//    - Handle process synchronization
+	//
+	// 3. REACTIVE STREAMS PATTERN
+	//    - Implement reactive programming
+	//    - Create observable streams
+	//    - Implement backpressure handling
+	//    - Add stream operators (map, filter, reduce)
+	//
+	// 4. EVENT SOURCING PATTERN
+	//    - Implement event-driven architecture
+	//    - Create event stores
+	//    - Implement event replay
+	//    - Handle event ordering and consistency
+	//
+	// 5. SAGA PATTERN
+	//    - Implement distributed transactions
+	//    - Create compensation logic
+	//    - Handle saga orchestration
+	//    - Implement saga persistence
+	//
+	// 6. CQRS PATTERN
+	//    - Separate command and query models
+	//    - Implement event handlers
+	//    - Create read/write model synchronization
+	//    - Handle eventual consistency
+	//
+	// Instructions:
+	// - Implement each pattern with proper error handling
+	// - Add comprehensive logging and monitoring
+	// - Create realistic examples and use cases
+	// - Implement proper resource cleanup
+	// - Add performance metrics and benchmarks
+	// - Handle failure scenarios and recovery
+	//
+	// Run: go run ./cmd/13-complex-patterns
+	// Then implement each complex pattern!
+	//
+	// Note: These patterns are complex and require deep understanding
+	// of distributed systems and concurrent programming concepts.
+}
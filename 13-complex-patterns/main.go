@@ -1,77 +0,0 @@
-package main
-
-import (
-	"fmt"
-	// "time"
-)
-
-func main() {
-	fmt.Println("=== Complex Concurrency Patterns ===")
-	fmt.Println("This module demonstrates advanced concurrency patterns for complex systems.")
-	fmt.Println("Complete the following exercises:")
-	fmt.Println()
-
-	// TODO: Implement the following complex patterns:
-
-	// 1. Actor Model Pattern
-	fmt.Println("1. ACTOR MODEL PATTERN")
-	fmt.Println("   - Implement actor-based concurrency")
-	fmt.Println("   - Create actor mailboxes")
-	fmt.Println("   - Implement actor supervision")
-	fmt.Println("   - Handle actor lifecycle management")
-	fmt.Println()
-
-	// 2. CSP (Communicating Sequential Processes) Pattern
-	fmt.Println("2. CSP PATTERN")
-	fmt.Println("   - Implement CSP-style communication")
-	fmt.Println("   - Create process networks")
-	fmt.Println("   - Implement channel-based processes")
-	fmt.Println("   - Handle process synchronization")
-	fmt.Println()
-
-	// 3. Reactive Streams Pattern
-	fmt.Println("3. REACTIVE STREAMS PATTERN")
-	fmt.Println("   - Implement reactive programming")
-	fmt.Println("   - Create observable streams")
-	fmt.Println("   - Implement backpressure handling")
-	fmt.Println("   - Add stream operators (map, filter, reduce)")
-	fmt.Println()
-
-	// 4. Event Sourcing Pattern
-	fmt.Println("4. EVENT SOURCING PATTERN")
-	fmt.Println("   - Implement event-driven architecture")
-	fmt.Println("   - Create event stores")
-	fmt.Println("   - Implement event replay")
-	fmt.Println("   - Handle event ordering and consistency")
-	fmt.Println()
-
-	// 5. Saga Pattern
-	fmt.Println("5. SAGA PATTERN")
-	fmt.Println("   - Implement distributed transactions")
-	fmt.Println("   - Create compensation logic")
-	fmt.Println("   - Handle saga orchestration")
-	fmt.Println("   - Implement saga persistence")
-	fmt.Println()
-
-	// 6. CQRS (Command Query Responsibility Segregation) Pattern
-	fmt.Println("6. CQRS PATTERN")
-	fmt.Println("   - Separate command and query models")
-	fmt.Println("   - Implement event handlers")
-	fmt.Println("   - Create read/write model synchronization")
-	fmt.Println("   - Handle eventual consistency")
-	fmt.Println()
-
-	fmt.Println("Instructions:")
-	fmt.Println("- Implement each pattern with proper error handling")
-	fmt.Println("- Add comprehensive logging and monitoring")
-	fmt.Println("- Create realistic examples and use cases")
-	fmt.Println("- Implement proper resource cleanup")
-	fmt.Println("- Add performance metrics and benchmarks")
-	fmt.Println("- Handle failure scenarios and recovery")
-	fmt.Println()
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each complex pattern!")
-	fmt.Println()
-	fmt.Println("Note: These patterns are complex and require deep understanding")
-	fmt.Println("of distributed systems and concurrent programming concepts.")
-}
@@ -0,0 +1,66 @@
+package systemdesign_test
+
+import (
+	"os"
+
+	systemdesign "go-concurrency/14-system-design"
+)
+
+func ExampleRun() {
+	systemdesign.Run(os.Stdout)
+
+	// Output:
+	// === System Design with Concurrency ===
+	// This module demonstrates concurrency patterns in system design.
+	// Complete the following exercises:
+	//
+	// 1. MICROSERVICES COMMUNICATION
+	//    - Implement service-to-service communication
+	//    - Create circuit breakers for services
+	//    - Implement retry mechanisms
+	//    - Handle service discovery
+	//
+	// 2. LOAD BALANCING WITH WORKERS
+	//    - Implement worker-based load balancing
+	//    - Create health checks for workers
+	//    - Implement dynamic worker scaling
+	//    - Handle worker failures gracefully
+	//
+	// 3. DISTRIBUTED CACHING
+	//    - Implement distributed cache
+	//    - Handle cache invalidation
+	//    - Implement cache consistency
+	//    - Add cache warming strategies
+	//
+	// 4. MESSAGE QUEUE SYSTEM
+	//    - Implement producer-consumer with queues
+	//    - Create message persistence
+	//    - Implement message acknowledgments
+	//    - Handle message ordering and deduplication
+	//
+	// 5. FAULT TOLERANCE PATTERNS
+	//    - Implement bulkhead pattern
+	//    - Create timeout and retry mechanisms
+	//    - Implement graceful degradation
+	//    - Handle cascading failures
+	//
+	// 6. SCALABILITY PATTERNS
+	//    - Implement horizontal scaling
+	//    - Create auto-scaling mechanisms
+	//    - Implement data partitioning
+	//    - Handle hot-spot mitigation
+	//
+	// Instructions:
+	// - Design for high availability and scalability
+	// - Implement proper monitoring and observability
+	// - Add comprehensive error handling
+	// - Create realistic load testing scenarios
+	// - Implement proper resource management
+	// - Handle edge cases and failure scenarios
+	//
+	// Run: go run ./cmd/14-system-design
+	// Then implement each system design pattern!
+	//
+	// Note: These patterns require understanding of distributed systems,
+	// microservices architecture, and production system requirements.
+}
@@ -1,77 +0,0 @@
-package main
-
-import (
-	"fmt"
-	// "time"
-)
-
-func main() {
-	fmt.Println("=== System Design with Concurrency ===")
-	fmt.Println("This module demonstrates concurrency patterns in system design.")
-	fmt.Println("Complete the following exercises:")
-	fmt.Println()
-
-	// TODO: Implement the following system design patterns:
-
-	// 1. Microservices Communication
-	fmt.Println("1. MICROSERVICES COMMUNICATION")
-	fmt.Println("   - Implement service-to-service communication")
-	fmt.Println("   - Create circuit breakers for services")
-	fmt.Println("   - Implement retry mechanisms")
-	fmt.Println("   - Handle service discovery")
-	fmt.Println()
-
-	// 2. Load Balancing with Workers
-	fmt.Println("2. LOAD BALANCING WITH WORKERS")
-	fmt.Println("   - Implement worker-based load balancing")
-	fmt.Println("   - Create health checks for workers")
-	fmt.Println("   - Implement dynamic worker scaling")
-	fmt.Println("   - Handle worker failures gracefully")
-	fmt.Println()
-
-	// 3. Distributed Caching
-	fmt.Println("3. DISTRIBUTED CACHING")
-	fmt.Println("   - Implement distributed cache")
-	fmt.Println("   - Handle cache invalidation")
-	fmt.Println("   - Implement cache consistency")
-	fmt.Println("   - Add cache warming strategies")
-	fmt.Println()
-
-	// 4. Message Queue System
-	fmt.Println("4. MESSAGE QUEUE SYSTEM")
-	fmt.Println("   - Implement producer-consumer with queues")
-	fmt.Println("   - Create message persistence")
-	fmt.Println("   - Implement message acknowledgments")
-	fmt.Println("   - Handle message ordering and deduplication")
-	fmt.Println()
-
-	// 5. Fault Tolerance Patterns
-	fmt.Println("5. FAULT TOLERANCE PATTERNS")
-	fmt.Println("   - Implement bulkhead pattern")
-	fmt.Println("   - Create timeout and retry mechanisms")
-	fmt.Println("   - Implement graceful degradation")
-	fmt.Println("   - Handle cascading failures")
-	fmt.Println()
-
-	// 6. Scalability Patterns
-	fmt.Println("6. SCALABILITY PATTERNS")
-	fmt.Println("   - Implement horizontal scaling")
-	fmt.Println("   - Create auto-scaling mechanisms")
-	fmt.Println("   - Implement data partitioning")
-	fmt.Println("   - Handle hot-spot mitigation")
-	fmt.Println()
-
-	fmt.Println("Instructions:")
-	fmt.Println("- Design for high availability and scalability")
-	fmt.Println("- Implement proper monitoring and observability")
-	fmt.Println("- Add comprehensive error handling")
-	fmt.Println("- Create realistic load testing scenarios")
-	fmt.Println("- Implement proper resource management")
-	fmt.Println("- Handle edge cases and failure scenarios")
-	fmt.Println()
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each system design pattern!")
-	fmt.Println()
-	fmt.Println("Note: These patterns require understanding of distributed systems,")
-	fmt.Println("microservices architecture, and production system requirements.")
-}
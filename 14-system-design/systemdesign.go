@@ -0,0 +1,82 @@
+// Package systemdesign is module 14 of the learning path: concurrency in system design.
+//
+// Run prints the module's exercises; implement each one in this package.
+package systemdesign
+
+import (
+	"fmt"
+	"io"
+	// "time"
+)
+
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
+	fmt.Fprintln(w, "=== System Design with Concurrency ===")
+	fmt.Fprintln(w, "This module demonstrates concurrency patterns in system design.")
+	fmt.Fprintln(w, "Complete the following exercises:")
+	fmt.Fprintln(w)
+
+	// TODO: Implement the following system design patterns:
+
+	// 1. Microservices Communication
+	fmt.Fprintln(w, "1. MICROSERVICES COMMUNICATION")
+	fmt.Fprintln(w, "   - Implement service-to-service communication")
+	fmt.Fprintln(w, "   - Create circuit breakers for services")
+	fmt.Fprintln(w, "   - Implement retry mechanisms")
+	fmt.Fprintln(w, "   - Handle service discovery")
+	fmt.Fprintln(w)
+
+	// 2. Load Balancing with Workers
+	fmt.Fprintln(w, "2. LOAD BALANCING WITH WORKERS")
+	fmt.Fprintln(w, "   - Implement worker-based load balancing")
+	fmt.Fprintln(w, "   - Create health checks for workers")
+	fmt.Fprintln(w, "   - Implement dynamic worker scaling")
+	fmt.Fprintln(w, "   - Handle worker failures gracefully")
+	fmt.Fprintln(w)
+
+	// 3. Distributed Caching
+	fmt.Fprintln(w, "3. DISTRIBUTED CACHING")
+	fmt.Fprintln(w, "   - Implement distributed cache")
+	fmt.Fprintln(w, "   - Handle cache invalidation")
+	fmt.Fprintln(w, "   - Implement cache consistency")
+	fmt.Fprintln(w, "   - Add cache warming strategies")
+	fmt.Fprintln(w)
+
+	// 4. Message Queue System
+	fmt.Fprintln(w, "4. MESSAGE QUEUE SYSTEM")
+	fmt.Fprintln(w, "   - Implement producer-consumer with queues")
+	fmt.Fprintln(w, "   - Create message persistence")
+	fmt.Fprintln(w, "   - Implement message acknowledgments")
+	fmt.Fprintln(w, "   - Handle message ordering and deduplication")
+	fmt.Fprintln(w)
+
+	// 5. Fault Tolerance Patterns
+	fmt.Fprintln(w, "5. FAULT TOLERANCE PATTERNS")
+	fmt.Fprintln(w, "   - Implement bulkhead pattern")
+	fmt.Fprintln(w, "   - Create timeout and retry mechanisms")
+	fmt.Fprintln(w, "   - Implement graceful degradation")
+	fmt.Fprintln(w, "   - Handle cascading failures")
+	fmt.Fprintln(w)
+
+	// 6. Scalability Patterns
+	fmt.Fprintln(w, "6. SCALABILITY PATTERNS")
+	fmt.Fprintln(w, "   - Implement horizontal scaling")
+	fmt.Fprintln(w, "   - Create auto-scaling mechanisms")
+	fmt.Fprintln(w, "   - Implement data partitioning")
+	fmt.Fprintln(w, "   - Handle hot-spot mitigation")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Instructions:")
+	fmt.Fprintln(w, "- Design for high availability and scalability")
+	fmt.Fprintln(w, "- Implement proper monitoring and observability")
+	fmt.Fprintln(w, "- Add comprehensive error handling")
+	fmt.Fprintln(w, "- Create realistic load testing scenarios")
+	fmt.Fprintln(w, "- Implement proper resource management")
+	fmt.Fprintln(w, "- Handle edge cases and failure scenarios")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run: go run ./cmd/14-system-design")
+	fmt.Fprintln(w, "Then implement each system design pattern!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Note: These patterns require understanding of distributed systems,")
+	fmt.Fprintln(w, "microservices architecture, and production system requirements.")
+}
@@ -0,0 +1,90 @@
+// Package advancedtopics is module 15 of the learning path: Go concurrency internals and advanced topics.
+//
+// Run prints the module's exercises; implement each one in this package.
+package advancedtopics
+
+import (
+	"fmt"
+	"io"
+	// "runtime"
+	// "time"
+)
+
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
+	fmt.Fprintln(w, "=== Advanced Topics ===")
+	fmt.Fprintln(w, "This module covers advanced Go concurrency topics and internals.")
+	fmt.Fprintln(w, "Complete the following exercises:")
+	fmt.Fprintln(w)
+
+	// TODO: Implement the following advanced topics:
+
+	// 1. Goroutine Scheduling Internals
+	fmt.Fprintln(w, "1. GOROUTINE SCHEDULING INTERNALS")
+	fmt.Fprintln(w, "   - Understand GOMAXPROCS and runtime")
+	fmt.Fprintln(w, "   - Implement goroutine scheduling analysis")
+	fmt.Fprintln(w, "   - Create scheduling visualization")
+	fmt.Fprintln(w, "   - Handle goroutine affinity")
+	fmt.Fprintln(w)
+
+	// 2. Memory Model Understanding
+	fmt.Fprintln(w, "2. MEMORY MODEL UNDERSTANDING")
+	fmt.Fprintln(w, "   - Understand Go's memory model")
+	fmt.Fprintln(w, "   - Implement memory ordering examples")
+	fmt.Fprintln(w, "   - Create race condition demonstrations")
+	fmt.Fprintln(w, "   - Handle memory visibility issues")
+	fmt.Fprintln(w)
+
+	// 3. Performance Tuning at Scale
+	fmt.Fprintln(w, "3. PERFORMANCE TUNING AT SCALE")
+	fmt.Fprintln(w, "   - Implement large-scale optimizations")
+	fmt.Fprintln(w, "   - Create performance profiling tools")
+	fmt.Fprintln(w, "   - Implement resource monitoring")
+	fmt.Fprintln(w, "   - Handle performance regression detection")
+	fmt.Fprintln(w)
+
+	// 4. Debugging Complex Concurrent Systems
+	fmt.Fprintln(w, "4. DEBUGGING COMPLEX CONCURRENT SYSTEMS")
+	fmt.Fprintln(w, "   - Implement debugging tools and utilities")
+	fmt.Fprintln(w, "   - Create trace analysis tools")
+	fmt.Fprintln(w, "   - Implement deadlock detection")
+	fmt.Fprintln(w, "   - Handle goroutine leak detection")
+	fmt.Fprintln(w)
+
+	// 5. Custom Runtime Integration
+	fmt.Fprintln(w, "5. CUSTOM RUNTIME INTEGRATION")
+	fmt.Fprintln(w, "   - Implement custom goroutine pools")
+	fmt.Fprintln(w, "   - Create custom scheduling algorithms")
+	fmt.Fprintln(w, "   - Implement runtime hooks")
+	fmt.Fprintln(w, "   - Handle custom memory management")
+	fmt.Fprintln(w)
+
+	// 6. Production System Patterns
+	fmt.Fprintln(w, "6. PRODUCTION SYSTEM PATTERNS")
+	fmt.Fprintln(w, "   - Implement production-ready patterns")
+	fmt.Fprintln(w, "   - Create operational monitoring")
+	fmt.Fprintln(w, "   - Implement graceful shutdown")
+	fmt.Fprintln(w, "   - Handle production debugging")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Instructions:")
+	fmt.Fprintln(w, "- Deep dive into Go runtime internals")
+	fmt.Fprintln(w, "- Implement production-grade solutions")
+	fmt.Fprintln(w, "- Add comprehensive monitoring and observability")
+	fmt.Fprintln(w, "- Create debugging and troubleshooting tools")
+	fmt.Fprintln(w, "- Handle edge cases and production scenarios")
+	fmt.Fprintln(w, "- Document performance characteristics")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run: go run ./cmd/15-advanced-topics")
+	fmt.Fprintln(w, "Then implement each advanced topic!")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Useful tools:")
+	fmt.Fprintln(w, "- go tool trace")
+	fmt.Fprintln(w, "- go tool pprof")
+	fmt.Fprintln(w, "- runtime.Stack()")
+	fmt.Fprintln(w, "- runtime.NumGoroutine()")
+	fmt.Fprintln(w, "- GOMAXPROCS")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Note: These topics require deep understanding of Go internals")
+	fmt.Fprintln(w, "and production system requirements.")
+}
@@ -0,0 +1,73 @@
+package advancedtopics_test
+
+import (
+	"os"
+
+	advancedtopics "go-concurrency/15-advanced-topics"
+)
+
+func ExampleRun() {
+	advancedtopics.Run(os.Stdout)
+
+	// Output:
+	// === Advanced Topics ===
+	// This module covers advanced Go concurrency topics and internals.
+	// Complete the following exercises:
+	//
+	// 1. GOROUTINE SCHEDULING INTERNALS
+	//    - Understand GOMAXPROCS and runtime
+	//    - Implement goroutine scheduling analysis
+	//    - Create scheduling visualization
+	//    - Handle goroutine affinity
+	//
+	// 2. MEMORY MODEL UNDERSTANDING
+	//    - Understand Go's memory model
+	//    - Implement memory ordering examples
+	//    - Create race condition demonstrations
+	//    - Handle memory visibility issues
+	//
+	// 3. PERFORMANCE TUNING AT SCALE
+	//    - Implement large-scale optimizations
+	//    - Create performance profiling tools
+	//    - Implement resource monitoring
+	//    - Handle performance regression detection
+	//
+	// 4. DEBUGGING COMPLEX CONCURRENT SYSTEMS
+	//    - Implement debugging tools and utilities
+	//    - Create trace analysis tools
+	//    - Implement deadlock detection
+	//    - Handle goroutine leak detection
+	//
+	// 5. CUSTOM RUNTIME INTEGRATION
+	//    - Implement custom goroutine pools
+	//    - Create custom scheduling algorithms
+	//    - Implement runtime hooks
+	//    - Handle custom memory management
+	//
+	// 6. PRODUCTION SYSTEM PATTERNS
+	//    - Implement production-ready patterns
+	//    - Create operational monitoring
+	//    - Implement graceful shutdown
+	//    - Handle production debugging
+	//
+	// Instructions:
+	// - Deep dive into Go runtime internals
+	// - Implement production-grade solutions
+	// - Add comprehensive monitoring and observability
+	// - Create debugging and troubleshooting tools
+	// - Handle edge cases and production scenarios
+	// - Document performance characteristics
+	//
+	// Run: go run ./cmd/15-advanced-topics
+	// Then implement each advanced topic!
+	//
+	// Useful tools:
+	// - go tool trace
+	// - go tool pprof
+	// - runtime.Stack()
+	// - runtime.NumGoroutine()
+	// - GOMAXPROCS
+	//
+	// Note: These topics require deep understanding of Go internals
+	// and production system requirements.
+}
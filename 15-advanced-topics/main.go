@@ -1,85 +0,0 @@
-package main
-
-import (
-	"fmt"
-	// "runtime"
-	// "time"
-)
-
-func main() {
-	fmt.Println("=== Advanced Topics ===")
-	fmt.Println("This module covers advanced Go concurrency topics and internals.")
-	fmt.Println("Complete the following exercises:")
-	fmt.Println()
-
-	// TODO: Implement the following advanced topics:
-
-	// 1. Goroutine Scheduling Internals
-	fmt.Println("1. GOROUTINE SCHEDULING INTERNALS")
-	fmt.Println("   - Understand GOMAXPROCS and runtime")
-	fmt.Println("   - Implement goroutine scheduling analysis")
-	fmt.Println("   - Create scheduling visualization")
-	fmt.Println("   - Handle goroutine affinity")
-	fmt.Println()
-
-	// 2. Memory Model Understanding
-	fmt.Println("2. MEMORY MODEL UNDERSTANDING")
-	fmt.Println("   - Understand Go's memory model")
-	fmt.Println("   - Implement memory ordering examples")
-	fmt.Println("   - Create race condition demonstrations")
-	fmt.Println("   - Handle memory visibility issues")
-	fmt.Println()
-
-	// 3. Performance Tuning at Scale
-	fmt.Println("3. PERFORMANCE TUNING AT SCALE")
-	fmt.Println("   - Implement large-scale optimizations")
-	fmt.Println("   - Create performance profiling tools")
-	fmt.Println("   - Implement resource monitoring")
-	fmt.Println("   - Handle performance regression detection")
-	fmt.Println()
-
-	// 4. Debugging Complex Concurrent Systems
-	fmt.Println("4. DEBUGGING COMPLEX CONCURRENT SYSTEMS")
-	fmt.Println("   - Implement debugging tools and utilities")
-	fmt.Println("   - Create trace analysis tools")
-	fmt.Println("   - Implement deadlock detection")
-	fmt.Println("   - Handle goroutine leak detection")
-	fmt.Println()
-
-	// 5. Custom Runtime Integration
-	fmt.Println("5. CUSTOM RUNTIME INTEGRATION")
-	fmt.Println("   - Implement custom goroutine pools")
-	fmt.Println("   - Create custom scheduling algorithms")
-	fmt.Println("   - Implement runtime hooks")
-	fmt.Println("   - Handle custom memory management")
-	fmt.Println()
-
-	// 6. Production System Patterns
-	fmt.Println("6. PRODUCTION SYSTEM PATTERNS")
-	fmt.Println("   - Implement production-ready patterns")
-	fmt.Println("   - Create operational monitoring")
-	fmt.Println("   - Implement graceful shutdown")
-	fmt.Println("   - Handle production debugging")
-	fmt.Println()
-
-	fmt.Println("Instructions:")
-	fmt.Println("- Deep dive into Go runtime internals")
-	fmt.Println("- Implement production-grade solutions")
-	fmt.Println("- Add comprehensive monitoring and observability")
-	fmt.Println("- Create debugging and troubleshooting tools")
-	fmt.Println("- Handle edge cases and production scenarios")
-	fmt.Println("- Document performance characteristics")
-	fmt.Println()
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each advanced topic!")
-	fmt.Println()
-	fmt.Println("Useful tools:")
-	fmt.Println("- go tool trace")
-	fmt.Println("- go tool pprof")
-	fmt.Println("- runtime.Stack()")
-	fmt.Println("- runtime.NumGoroutine()")
-	fmt.Println("- GOMAXPROCS")
-	fmt.Println()
-	fmt.Println("Note: These topics require deep understanding of Go internals")
-	fmt.Println("and production system requirements.")
-}
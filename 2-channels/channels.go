@@ -0,0 +1,446 @@
+// Package channels is module 2 of the learning path: basic channel
+// operations and patterns.
+//
+// Each example is an exported function that writes what it does to an
+// io.Writer; Run prints the exercises and then runs every example.
+package channels
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go-concurrency/broadcast"
+	"go-concurrency/chantrace"
+	"go-concurrency/chanx"
+	"go-concurrency/clock"
+	"go-concurrency/elastic"
+	"go-concurrency/reqrep"
+	"go-concurrency/selectx"
+	"go-concurrency/stream"
+)
+
+// Run prints the module overview and runs every example on clk, writing
+// to w, without tracing them.
+//
+// The examples that take a *chantrace.Recorder record the operations on
+// the channels they create themselves, so each can be drawn as a sequence
+// diagram, as "go run ./cmd/2-channels -trace dir" does; a nil Recorder
+// records nothing. Channels inside other packages, such as chanx or
+// stream, are not recorded, except for the receives an example makes on a
+// channel such a package hands out, like a broadcast subscription.
+func Run(w io.Writer, clk clock.Clock) {
+	// === Channel Fundamentals ===
+	// This module demonstrates basic channel operations and patterns in Go.
+	// Complete the following exercises:
+
+	// TODO: Implement the following channel patterns:
+
+	// 1. Basic Channel Operations
+	//    - Create channels (buffered and unbuffered)
+	//    - Send and receive data
+	//    - Understand channel blocking behavior
+	//    - Handle channel direction (send-only, receive-only)
+
+	// 2. Buffered vs Unbuffered Channels
+	//    - Compare buffered and unbuffered channels
+	//    - Understand blocking behavior differences
+	//    - Implement producer-consumer patterns
+	//    - Handle channel capacity
+
+	// 3. Channel Closing
+	//    - Properly close channels
+	//    - Detect closed channels
+	//    - Handle closed channel gracefully
+	//    - Use range with channels
+
+	// 4. Select Statement
+	//    - Use select for multiple channel operations
+	//    - Implement timeout handling
+	//    - Handle default case
+	//    - Implement non-blocking operations
+
+	// 5. Channel Patterns
+	//    - Implement fan-in pattern
+	//    - Implement fan-out pattern
+	//    - Create channel pipelines
+	//    - Implement generator pattern
+
+	// 6. Channel Best Practices
+	//    - Avoid channel leaks
+	//    - Implement proper error handling
+	//    - Use channels for communication
+	//    - Handle channel ownership
+
+	// Instructions:
+	// - Implement each pattern in separate functions
+	// - Add proper error handling
+	// - Use meaningful variable names
+	// - Add comments explaining concepts
+	// - Test with different scenarios
+
+	fmt.Fprintln(w, "=== Channel Fundamentals ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/2-channels")
+	fmt.Fprintln(w, "Then implement each channel pattern!")
+	fmt.Fprintln(w)
+
+	// Example implementations (to be replaced with your code):
+	fmt.Fprintln(w, "Example implementations:")
+
+	BasicChannel(w, nil)
+	BufferedChannel(w, nil)
+	SelectStatement(w, clk, nil)
+	ChannelToolkit(w, nil)
+	Broadcast(w, nil)
+	ClosingReasons(w, nil)
+	PrioritySelect(w, nil)
+	RequestReply(w, clk, nil)
+}
+
+// BasicChannel runs example 1: a single value sent over an unbuffered
+// channel, which blocks the sender until the receiver is ready.
+func BasicChannel(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n1. Basic Channel Example:")
+	channel := chantrace.Make[string](trace, "channel", 0)
+	go func() {
+		trace.Name("sender")
+		channel.Send("Hello, World!")
+	}()
+	message, _ := channel.Recv()
+	fmt.Fprintln(w, message)
+}
+
+// BufferedChannel runs example 2: a channel of capacity 2 accepts two sends
+// before anyone receives, and an elastic channel accepts any number.
+func BufferedChannel(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n2. Buffered Channel Example:")
+	buffered := chantrace.Make[int](trace, "buffered", 2)
+	buffered.Send(1)
+	buffered.Send(2)
+	fmt.Fprintln(w, "Sent to buffered channel")
+	for range 2 {
+		v, _ := buffered.Recv()
+		fmt.Fprintln(w, "Received:", v)
+	}
+
+	// A third send before receiving would block this goroutine forever;
+	// an elastic channel grows instead, so its In never waits
+	unbounded := elastic.New[int]()
+	for i := 1; i <= 3; i++ {
+		unbounded.In() <- i
+	}
+	close(unbounded.In())
+	fmt.Fprintln(w, "Sent 3 to elastic channel")
+	for v := range unbounded.Out() {
+		fmt.Fprintln(w, "Received:", v)
+	}
+}
+
+// SelectStatement runs example 3: select over two channels and a timeout,
+// taking whichever is ready first. The senders give up once the select is
+// over, so the one that loses does not block forever.
+func SelectStatement(w io.Writer, clk clock.Clock, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n3. Select Statement Example:")
+	ch1 := chantrace.Make[string](trace, "ch1", 0)
+	ch2 := chantrace.Make[string](trace, "ch2", 0)
+	done := make(chan struct{})
+	defer close(done)
+
+	// send sends msg on ch after delay, unless the select is over first
+	send := func(ch *chantrace.Chan[string], delay time.Duration, msg string) {
+		select {
+		case <-clk.After(delay):
+		case <-done:
+			return
+		}
+		select {
+		case ch.C() <- msg:
+			ch.Sent(msg)
+		case <-done:
+		}
+	}
+	go func() {
+		trace.Name("sender 1")
+		send(ch1, 100*time.Millisecond, "from ch1")
+	}()
+	go func() {
+		trace.Name("sender 2")
+		send(ch2, 200*time.Millisecond, "from ch2")
+	}()
+
+	select {
+	case msg1 := <-ch1.C():
+		ch1.Received(msg1, true)
+		fmt.Fprintln(w, "Received:", msg1)
+	case msg2 := <-ch2.C():
+		ch2.Received(msg2, true)
+		fmt.Fprintln(w, "Received:", msg2)
+	case <-clk.After(300 * time.Millisecond):
+		fmt.Fprintln(w, "Timeout!")
+	}
+}
+
+// ChannelToolkit runs example 4: the section 5 patterns assembled from
+// package chanx, ending with a cancellation that releases every stage.
+func ChannelToolkit(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n4. Channel Toolkit Example:")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Generator fanned out to three workers; results arrive in any order
+	squares := chanx.FanOut(ctx, chanx.Generate(ctx, 1, 2, 3, 4, 5), 3, func(n int) int { return n * n })
+	sum := 0
+	for v := range squares {
+		sum += v
+	}
+	fmt.Fprintln(w, "Sum of squares from 3 workers:", sum)
+
+	// Split across two channels and merged back (fan-out, then fan-in)
+	parts := chanx.Split(ctx, chanx.Generate(ctx, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10), 2)
+	fmt.Fprintln(w, "Values through split and merge:", chanx.Drain(ctx, chanx.Merge(ctx, parts...)))
+
+	// Tee: both copies see every value, so both must be read
+	left, right := chanx.Tee(ctx, chanx.Generate(ctx, "x", "y", "z"))
+	copied := chantrace.Make[[]string](trace, "copied", 0)
+	go func() {
+		var got []string
+		for v := range left {
+			got = append(got, v)
+		}
+		copied.Send(got)
+	}()
+	var got []string
+	for v := range right {
+		got = append(got, v)
+	}
+	leftCopy, _ := copied.Recv()
+	fmt.Fprintln(w, "Tee copies:", leftCopy, got)
+
+	// Bridge: a channel of channels read as one stream, in order
+	var bridged []int
+	for v := range chanx.Bridge(ctx, chanx.Generate(ctx, chanx.Generate(ctx, 1, 2), chanx.Generate(ctx, 3))) {
+		bridged = append(bridged, v)
+	}
+	fmt.Fprintln(w, "Bridged:", bridged)
+
+	// Take the first five values of an endless, buffered generator, then
+	// cancel: the generator closes its channel instead of leaking
+	n := 0
+	naturals := chanx.GenerateFunc(ctx, func() int { n++; return n })
+	var first []int
+	for v := range chanx.OrDone(ctx, chanx.Take(ctx, chanx.Buffer(ctx, naturals, 4), 5)) {
+		first = append(first, v)
+	}
+	fmt.Fprintln(w, "First five naturals:", first)
+	cancel()
+	chanx.Drain(context.Background(), naturals)
+	fmt.Fprintln(w, "Generator stopped after cancel")
+}
+
+// Broadcast runs example 5: one publisher, several subscribers, each with
+// its own buffer and policy for when it falls behind, plus a late joiner
+// that is replayed the last value.
+func Broadcast(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n5. Broadcast Example:")
+	ctx := context.Background()
+	news := broadcast.New[string](broadcast.WithReplay())
+
+	// Nobody reads until the end, so every buffer fills up
+	keepAll, _ := news.Subscribe(4, broadcast.Block)
+	latest, _ := news.Subscribe(1, broadcast.DropOldest)
+	first, _ := news.Subscribe(1, broadcast.DropNewest)
+	strict, _ := news.Subscribe(1, broadcast.Disconnect)
+	for _, headline := range []string{"a", "b", "c"} {
+		if err := news.Publish(ctx, headline); err != nil {
+			fmt.Fprintln(w, "Publish failed:", err)
+			return
+		}
+	}
+	late, _ := news.Subscribe(1, broadcast.Block)
+	news.Close()
+
+	for _, sub := range []struct {
+		name string
+		s    *broadcast.Subscription[string]
+	}{{"block", keepAll}, {"drop-oldest", latest}, {"drop-newest", first}, {"disconnect", strict}, {"late joiner", late}} {
+		var got []string
+		for {
+			v, ok := chantrace.RecvFrom(trace, sub.name, sub.s.C())
+			if !ok {
+				break
+			}
+			got = append(got, v)
+		}
+		fmt.Fprintf(w, "%-12s received %v, dropped %d, ended by: %v\n", sub.name, got, sub.s.Dropped(), sub.s.Err())
+	}
+}
+
+// ClosingReasons runs example 6: a closed channel only says ok == false,
+// while a stream also says why it was closed, so the consumer can tell a
+// producer that finished from one that failed.
+func ClosingReasons(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n6. Closing Reasons Example:")
+	ctx := context.Background()
+	inputs := [][]string{{"1", "2", "3"}, {"4", "five", "6"}}
+
+	// parseChan stops at the first bad line too, but all it can do is close
+	// the channel, just as it does at the end of the input
+	parseChan := func(lines ...string) *chantrace.Chan[int] {
+		numbers := chantrace.Make[int](trace, fmt.Sprint(lines), len(lines))
+		go func() {
+			trace.Name("parser")
+			defer numbers.Close()
+			for _, line := range lines {
+				n, err := strconv.Atoi(line)
+				if err != nil {
+					return
+				}
+				numbers.Send(n)
+			}
+		}()
+		return numbers
+	}
+	for _, input := range inputs {
+		numbers := parseChan(input...)
+		sum := 0
+		for n, ok := numbers.Recv(); ok; n, ok = numbers.Recv() {
+			sum += n
+		}
+		fmt.Fprintf(w, "Channel %v: closed, sum = %d\n", input, sum)
+	}
+
+	// parse sends each line as a number, stopping at the first bad one
+	parse := func(lines ...string) *stream.Stream[int] {
+		numbers := stream.New[int](len(lines))
+		go func() {
+			for i, line := range lines {
+				n, err := strconv.Atoi(line)
+				if err != nil {
+					numbers.CloseWithError(fmt.Errorf("line %d: %w", i+1, err))
+					return
+				}
+				if err := numbers.Send(ctx, n); err != nil {
+					return
+				}
+			}
+			numbers.Close()
+		}()
+		return numbers
+	}
+
+	for _, input := range inputs {
+		numbers := parse(input...)
+		sum := 0
+		for {
+			n, err := numbers.Recv(ctx)
+			if errors.Is(err, io.EOF) {
+				fmt.Fprintf(w, "Stream %v: finished, sum = %d\n", input, sum)
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(w, "Stream %v: failed after sum = %d: %v\n", input, sum, err)
+				break
+			}
+			sum += n
+		}
+	}
+}
+
+// PrioritySelect runs example 7: select picks at random among ready cases,
+// so it cannot promise to handle a control message before queued data; a
+// selectx.Priority can, and with weights it still lets lower channels in.
+func PrioritySelect(w io.Writer, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n7. Priority Select Example:")
+	ctx := context.Background()
+
+	// Data is queued before the stop message, yet stop is handled first
+	control := chantrace.Make[string](trace, "control", 1)
+	data := chantrace.Make[string](trace, "data", 3)
+	data.Send("row 1")
+	data.Send("row 2")
+	control.Send("stop")
+	traced := []*chantrace.Chan[string]{control, data}
+	first, _ := selectx.NewPriority([]<-chan string{control.C(), data.C()}).Recv(ctx)
+	traced[first.Index].Received(first.Value, first.OK)
+	fmt.Fprintf(w, "First received: %q from channel %d\n", first.Value, first.Index)
+
+	// Weighted fairness: with both channels full, high gets 3 in 4
+	high, low := make(chan string, 8), make(chan string, 8)
+	for range 8 {
+		high <- "H"
+		low <- "L"
+	}
+	fair := selectx.NewPriority([]<-chan string{high, low}, selectx.WithWeights(3, 1))
+	var order strings.Builder
+	for range 8 {
+		r, _ := fair.Recv(ctx)
+		order.WriteString(r.Value)
+	}
+	fmt.Fprintln(w, "Weighted 3:1 order:", order.String())
+}
+
+// RequestReply runs example 8: channels carry values one way, so a client
+// that needs an answer sends a request with its own reply channel. Three
+// handlers serve five concurrent clients, then a client whose deadline
+// passes stops waiting and its handler sees the cancellation.
+func RequestReply(w io.Writer, clk clock.Clock, trace *chantrace.Recorder) {
+	fmt.Fprintln(w, "\n8. Request/Reply Example:")
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	squares := reqrep.New[int, int](0)
+	canceled := chantrace.Make[uint64](trace, "canceled", 1)
+	served := chantrace.Make[error](trace, "served", 1)
+	go func() {
+		trace.Name("server")
+		served.Send(squares.Serve(ctx, 3, func(ctx context.Context, id uint64, n int) (int, error) {
+			if n < 0 {
+				return 0, fmt.Errorf("negative input %d", n)
+			}
+			if n > 100 {
+				// Too slow for any caller: wait until the caller gives up
+				<-ctx.Done()
+				canceled.Send(id)
+				return 0, ctx.Err()
+			}
+			return n * n, nil
+		}))
+	}()
+
+	// Each client receives its own answer, whatever order they are served in
+	inputs := []int{2, 3, 4, 5, -1}
+	replies := make([]string, len(inputs))
+	var wg sync.WaitGroup
+	for i, n := range inputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sq, err := squares.Call(ctx, n)
+			if err != nil {
+				replies[i] = fmt.Sprintf("client %d asked %d: error: %v", i, n, err)
+				return
+			}
+			replies[i] = fmt.Sprintf("client %d asked %d: got %d", i, n, sq)
+		}()
+	}
+	wg.Wait()
+	for _, reply := range replies {
+		fmt.Fprintln(w, reply)
+	}
+
+	// A deadline ends the wait and cancels the handler's context too
+	callCtx, cancel := clk.WithTimeout(ctx, 50*time.Millisecond)
+	_, err := squares.Call(callCtx, 1000)
+	cancel()
+	id, _ := canceled.Recv()
+	fmt.Fprintf(w, "client asked 1000: %v; server canceled request %d\n", err, id)
+
+	squares.Close()
+	stopped, _ := served.Recv()
+	fmt.Fprintln(w, "Server stopped:", stopped)
+}
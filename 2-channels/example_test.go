@@ -0,0 +1,100 @@
+package channels_test
+
+import (
+	"os"
+	"time"
+
+	channels "go-concurrency/2-channels"
+	"go-concurrency/clock"
+)
+
+func ExampleBasicChannel() {
+	channels.BasicChannel(os.Stdout, nil)
+
+	// Output:
+	// 1. Basic Channel Example:
+	// Hello, World!
+}
+
+func ExampleBufferedChannel() {
+	channels.BufferedChannel(os.Stdout, nil)
+
+	// Output:
+	// 2. Buffered Channel Example:
+	// Sent to buffered channel
+	// Received: 1
+	// Received: 2
+	// Sent 3 to elastic channel
+	// Received: 1
+	// Received: 2
+	// Received: 3
+}
+
+func ExampleSelectStatement() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { channels.SelectStatement(os.Stdout, clk, nil) })
+
+	// Output:
+	// 3. Select Statement Example:
+	// Received: from ch1
+}
+
+func ExampleChannelToolkit() {
+	channels.ChannelToolkit(os.Stdout, nil)
+
+	// Output:
+	// 4. Channel Toolkit Example:
+	// Sum of squares from 3 workers: 55
+	// Values through split and merge: 10
+	// Tee copies: [x y z] [x y z]
+	// Bridged: [1 2 3]
+	// First five naturals: [1 2 3 4 5]
+	// Generator stopped after cancel
+}
+
+func ExampleBroadcast() {
+	channels.Broadcast(os.Stdout, nil)
+
+	// Output:
+	// 5. Broadcast Example:
+	// block        received [a b c], dropped 0, ended by: broadcast: closed
+	// drop-oldest  received [c], dropped 2, ended by: broadcast: closed
+	// drop-newest  received [a], dropped 2, ended by: broadcast: closed
+	// disconnect   received [a], dropped 1, ended by: broadcast: subscriber too slow, disconnected
+	// late joiner  received [c], dropped 0, ended by: broadcast: closed
+}
+
+func ExampleClosingReasons() {
+	channels.ClosingReasons(os.Stdout, nil)
+
+	// Output:
+	// 6. Closing Reasons Example:
+	// Channel [1 2 3]: closed, sum = 6
+	// Channel [4 five 6]: closed, sum = 4
+	// Stream [1 2 3]: finished, sum = 6
+	// Stream [4 five 6]: failed after sum = 4: line 2: strconv.Atoi: parsing "five": invalid syntax
+}
+
+func ExamplePrioritySelect() {
+	channels.PrioritySelect(os.Stdout, nil)
+
+	// Output:
+	// 7. Priority Select Example:
+	// First received: "stop" from channel 0
+	// Weighted 3:1 order: HHHLHHHL
+}
+
+func ExampleRequestReply() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { channels.RequestReply(os.Stdout, clk, nil) })
+
+	// Output:
+	// 8. Request/Reply Example:
+	// client 0 asked 2: got 4
+	// client 1 asked 3: got 9
+	// client 2 asked 4: got 16
+	// client 3 asked 5: got 25
+	// client 4 asked -1: error: negative input -1
+	// client asked 1000: context deadline exceeded; server canceled request 6
+	// Server stopped: <nil>
+}
@@ -0,0 +1,23 @@
+package channels
+
+import "context"
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 2" to check them.
+type Solution struct {
+	// 5. Channel Patterns: Generator sends 0, 1, ..., n-1 and closes the
+	// channel, stopping early when ctx is done.
+	Generator func(ctx context.Context, n int) <-chan int
+
+	// 5. Channel Patterns: FanIn forwards every value from every input
+	// channel and closes its output once all inputs are closed or ctx is done.
+	FanIn func(ctx context.Context, ins ...<-chan int) <-chan int
+
+	// 5. Channel Patterns: FanOut starts workers goroutines that apply fn
+	// to values from in and closes its output once all of them are done.
+	FanOut func(ctx context.Context, in <-chan int, workers int, fn func(int) int) <-chan int
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
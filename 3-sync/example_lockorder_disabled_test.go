@@ -0,0 +1,23 @@
+//go:build !lockorder
+
+package syncbasics_test
+
+import (
+	"os"
+
+	syncbasics "go-concurrency/3-sync"
+)
+
+// Without the lockorder tag the locks are plain mutexes and nothing is
+// reported; example_lockorder_enabled_test.go has the output with it.
+func ExampleLockOrdering() {
+	syncbasics.LockOrdering(os.Stdout)
+
+	// Output:
+	// 4. Lock Ordering Example:
+	// Lock order checks are off; run with -tags lockorder to see the reports
+	// Opposite orders: 0 report(s)
+	// Ordered by account id: 0 report(s)
+	// Held for 30ms with a 20ms threshold: 0 report(s)
+	// Balances: alice 90, bob 110
+}
@@ -0,0 +1,28 @@
+//go:build lockorder
+
+package syncbasics_test
+
+import (
+	"os"
+
+	syncbasics "go-concurrency/3-sync"
+)
+
+// Built with -tags lockorder, the opposite orders are reported with both
+// stacks and the long hold once, whether the watchdog sees it first or the
+// release does.
+func ExampleLockOrdering() {
+	syncbasics.LockOrdering(os.Stdout)
+
+	// Output:
+	// 4. Lock Ordering Example:
+	// Opposite orders: 1 report(s)
+	//   lock order inversion: alice -> bob -> alice
+	//     at 3-sync.transfer
+	//     at 3-sync.transfer
+	// Ordered by account id: 0 report(s)
+	// Held for 30ms with a 20ms threshold: 1 report(s)
+	//   lock held too long: alice
+	//     at 3-sync.LockOrdering
+	// Balances: alice 90, bob 110
+}
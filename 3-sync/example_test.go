@@ -0,0 +1,90 @@
+package syncbasics_test
+
+import (
+	"os"
+	"time"
+
+	syncbasics "go-concurrency/3-sync"
+	"go-concurrency/clock"
+)
+
+func ExampleMutexCounter() {
+	syncbasics.MutexCounter(os.Stdout)
+
+	// Output:
+	// 1. Mutex Example:
+	// Final counter value: 5000
+}
+
+// The readers print in whatever order the scheduler runs them, but each
+// sleep ends at the same fake time on every run, so they always see the
+// same data.
+func ExampleRWMutexReaders() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { syncbasics.RWMutexReaders(os.Stdout, clk) })
+
+	// Unordered output:
+	// 2. RWMutex Example:
+	// Reader 2: data = map[key0:0]
+	// Reader 0: data = map[key0:0]
+	// Reader 1: data = map[key0:0]
+	// Reader 2: data = map[key0:0]
+	// Reader 0: data = map[key0:0]
+	// Reader 1: data = map[key0:0]
+	// Reader 2: data = map[key0:0 key1:10]
+	// Reader 0: data = map[key0:0 key1:10]
+	// Reader 1: data = map[key0:0 key1:10]
+	// Reader 2: data = map[key0:0 key1:10]
+	// Reader 0: data = map[key0:0 key1:10]
+	// Reader 1: data = map[key0:0 key1:10]
+	// Reader 2: data = map[key0:0 key1:10 key2:20]
+	// Reader 0: data = map[key0:0 key1:10 key2:20]
+	// Reader 1: data = map[key0:0 key1:10 key2:20]
+	// Reader 2: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 0: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 1: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 2: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 0: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 1: data = map[key0:0 key1:10 key2:20 key3:30]
+	// Reader 2: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 0: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 1: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 2: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 0: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 1: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 2: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 0: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+	// Reader 1: data = map[key0:0 key1:10 key2:20 key3:30 key4:40]
+}
+
+func ExampleOnceInit() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { syncbasics.OnceInit(os.Stdout, clk) })
+
+	// Output:
+	// 3. sync.Once Example:
+	// This will only be called once!
+}
+
+func ExampleRetryableOnce() {
+	syncbasics.RetryableOnce(os.Stdout)
+
+	// Output:
+	// 6. Retryable Once Example:
+	// 5 callers, 1 attempt(s), first error: connection refused
+	// Called again during backoff: connection refused, still 1 attempt(s)
+	// After 100ms: connection refused, 2 attempts; next retry in 200ms
+	// After 200ms more: conn#1, <nil>, 3 attempts
+	// Impatient caller: context deadline exceeded; next caller: loaded
+}
+
+func ExampleDynamicWaitGroup() {
+	syncbasics.DynamicWaitGroup(os.Stdout)
+
+	// Output:
+	// 8. Dynamic WaitGroup Example:
+	// Final counter value: 5000, never more than 2 running: true, error: <nil>
+	// Walked 8 paths; 2 errors: /home/ann: permission denied, /home/bob: permission denied
+	// Impatient Wait: context deadline exceeded; next Wait: <nil>
+	// Go after Wait: waitgroup: Go called after Wait returned; start goroutines before Wait or from the Group's own goroutines, and use a new Group to start over
+}
@@ -0,0 +1,31 @@
+package syncbasics
+
+// Counter is a counter that is safe for concurrent use.
+type Counter interface {
+	Inc()
+	Value() int
+}
+
+// Cache is a string-keyed map that is safe for concurrent use.
+type Cache interface {
+	Get(key string) (int, bool)
+	Set(key string, value int)
+}
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 3" to check them.
+type Solution struct {
+	// 1. Mutex and RWMutex: NewCounter returns a Counter guarded by a mutex.
+	NewCounter func() Counter
+
+	// 3. Once: OnceValue returns a function that calls init the first time
+	// it is called, from any goroutine, and returns its result every time.
+	OnceValue func(init func() int) func() int
+
+	// 5. Map: NewCache returns an empty Cache.
+	NewCache func() Cache
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -0,0 +1,544 @@
+// Package syncbasics is module 3 of the learning path: synchronization
+// primitives (Mutex, RWMutex, WaitGroup, Once).
+//
+// Each example is an exported function that writes what it does to an
+// io.Writer, which must be safe for concurrent use because several
+// goroutines write to it; Run prints the exercises and then runs every
+// example.
+package syncbasics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/lazy"
+	"go-concurrency/lockorder"
+	"go-concurrency/lockstat"
+	"go-concurrency/rwlock"
+	"go-concurrency/waitgroup"
+)
+
+// Run prints the module overview and runs every example on clk, writing
+// to w.
+func Run(w io.Writer, clk clock.Clock) {
+	// === Synchronization Primitives ===
+	// This module demonstrates synchronization primitives in Go.
+	// Complete the following exercises:
+
+	// TODO: Implement the following synchronization patterns:
+
+	// 1. Mutex and RWMutex
+	//    - Use sync.Mutex for exclusive access
+	//    - Use sync.RWMutex for read-write operations
+	//    - Understand lock contention
+	//    - Implement proper lock ordering
+
+	// 2. WaitGroup
+	//    - Use sync.WaitGroup for goroutine coordination
+	//    - Handle dynamic goroutine counts
+	//    - Implement proper Add/Done/Wait usage
+	//    - Avoid common WaitGroup pitfalls
+
+	// 3. Once
+	//    - Use sync.Once for one-time initialization
+	//    - Implement singleton patterns
+	//    - Handle initialization errors
+	//    - Understand Once behavior
+
+	// 4. Cond (Condition Variables)
+	//    - Use sync.Cond for condition-based waiting
+	//    - Implement producer-consumer with conditions
+	//    - Handle spurious wakeups
+	//    - Use Signal() and Broadcast()
+
+	// 5. Map
+	//    - Use sync.Map for concurrent map operations
+	//    - Compare with regular map + mutex
+	//    - Implement concurrent cache
+	//    - Handle map operations safely
+
+	// 6. Pool
+	//    - Use sync.Pool for object reuse
+	//    - Implement connection pooling
+	//    - Handle pool exhaustion
+	//    - Implement custom pool cleanup
+
+	// Instructions:
+	// - Implement each pattern in separate functions
+	// - Add proper error handling
+	// - Use meaningful variable names
+	// - Add comments explaining concepts
+	// - Test with different scenarios
+
+	fmt.Fprintln(w, "=== Synchronization Primitives ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/3-sync")
+	fmt.Fprintln(w, "Then implement each synchronization pattern!")
+	fmt.Fprintln(w)
+
+	// Example implementations (to be replaced with your code):
+	fmt.Fprintln(w, "Example implementations:")
+
+	MutexCounter(w)
+	RWMutexReaders(w, clk)
+	OnceInit(w, clk)
+	LockOrdering(w)
+	LockContention(w)
+	RetryableOnce(w)
+	RWLockPolicies(w)
+	DynamicWaitGroup(w)
+}
+
+// MutexCounter runs example 1: five goroutines increment a shared counter
+// 1000 times each under a sync.Mutex.
+func MutexCounter(w io.Writer) {
+	fmt.Fprintln(w, "\n1. Mutex Example:")
+	var (
+		counter int
+		mutex   sync.Mutex
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				mutex.Lock()
+				counter++
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	fmt.Fprintf(w, "Final counter value: %d\n", counter)
+}
+
+// RWMutexReaders runs example 2: three readers share a map under an
+// RWMutex while one writer updates it.
+func RWMutexReaders(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n2. RWMutex Example:")
+	var (
+		data    = make(map[string]int)
+		rwMutex sync.RWMutex
+	)
+
+	// Writer goroutine; the readers start after its first write, so what
+	// each of them reads depends only on the clock
+	firstWrite := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			rwMutex.Lock()
+			data[fmt.Sprintf("key%d", i)] = i * 10
+			rwMutex.Unlock()
+			if i == 0 {
+				close(firstWrite)
+			}
+			clk.Sleep(50 * time.Millisecond)
+		}
+	}()
+	<-firstWrite
+
+	// Reader goroutines
+	for i := 0; i < 3; i++ {
+		go func(id int) {
+			for j := 0; j < 10; j++ {
+				rwMutex.RLock()
+				fmt.Fprintf(w, "Reader %d: data = %v\n", id, data)
+				rwMutex.RUnlock()
+				clk.Sleep(30 * time.Millisecond)
+			}
+		}(i)
+	}
+
+	clk.Sleep(1 * time.Second)
+}
+
+// OnceInit runs example 3: five goroutines race to call once.Do and the
+// initializer runs exactly once.
+func OnceInit(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n3. sync.Once Example:")
+	var once sync.Once
+	initFunc := func() {
+		fmt.Fprintln(w, "This will only be called once!")
+	}
+
+	for i := 0; i < 5; i++ {
+		go once.Do(initFunc)
+	}
+
+	clk.Sleep(100 * time.Millisecond)
+}
+
+// account is a bank account guarded by a lock-order-checked mutex.
+type account struct {
+	id      int
+	mu      lockorder.Mutex
+	balance int
+}
+
+// transfer locks from, then to: two transfers in opposite directions take
+// the same locks in opposite orders and can deadlock.
+func transfer(from, to *account, amount int) {
+	from.mu.Lock()
+	defer from.mu.Unlock()
+	to.mu.Lock()
+	defer to.mu.Unlock()
+	from.balance -= amount
+	to.balance += amount
+}
+
+// orderedTransfer locks the account with the lower id first, whichever
+// direction the money goes, so every goroutine takes them in one order.
+func orderedTransfer(from, to *account, amount int) {
+	first, second := from, to
+	if second.id < first.id {
+		first, second = second, first
+	}
+	first.mu.Lock()
+	defer first.mu.Unlock()
+	second.mu.Lock()
+	defer second.mu.Unlock()
+	from.balance -= amount
+	to.balance += amount
+}
+
+// LockOrdering runs example 4: two transfers lock the same pair of
+// accounts in opposite orders. They run one after the other, so nothing
+// deadlocks, yet built with -tags lockorder the second is reported as a
+// potential deadlock; locking in a fixed order is not. A lock held longer
+// than a threshold is reported while it is still held.
+func LockOrdering(w io.Writer) {
+	fmt.Fprintln(w, "\n4. Lock Ordering Example:")
+	if !lockorder.Enabled {
+		fmt.Fprintln(w, "Lock order checks are off; run with -tags lockorder to see the reports")
+	}
+
+	var (
+		mu      sync.Mutex
+		reports []lockorder.Report
+	)
+	lockorder.Reset()
+	defer lockorder.Reset()
+	defer lockorder.SetReporter(lockorder.SetReporter(func(r lockorder.Report) {
+		mu.Lock()
+		reports = append(reports, r)
+		mu.Unlock()
+	}))
+	defer lockorder.SetHoldThreshold(lockorder.SetHoldThreshold(20 * time.Millisecond))
+	flush := func(what string) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "%s: %d report(s)\n", what, len(reports))
+		for _, r := range reports {
+			locks := strings.Join(r.Locks, " -> ")
+			if r.Kind == lockorder.Inversion {
+				locks += " -> " + r.Locks[0] // back to where the cycle started
+			}
+			fmt.Fprintf(w, "  %s: %s\n", r.Kind, locks)
+			for _, stack := range r.Stacks {
+				if len(stack) > 0 {
+					fmt.Fprintf(w, "    at %s\n", path.Base(stack[0].Function))
+				}
+			}
+		}
+		reports = nil
+	}
+
+	alice, bob := &account{id: 1, balance: 100}, &account{id: 2, balance: 100}
+	alice.mu.SetName("alice")
+	bob.mu.SetName("bob")
+	run := func(fn func()) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			fn()
+		}()
+		<-done
+	}
+
+	run(func() { transfer(alice, bob, 10) })
+	run(func() { transfer(bob, alice, 5) })
+	flush("Opposite orders")
+
+	lockorder.Reset()
+	run(func() { orderedTransfer(alice, bob, 10) })
+	run(func() { orderedTransfer(bob, alice, 5) })
+	flush("Ordered by account id")
+
+	alice.mu.Lock()
+	time.Sleep(30 * time.Millisecond) // slow work under the lock
+	alice.mu.Unlock()
+	flush("Held for 30ms with a 20ms threshold")
+	fmt.Fprintf(w, "Balances: alice %d, bob %d\n", alice.balance, bob.balance)
+}
+
+// LockContention runs example 5: the counter of example 1 and a map read
+// and written like in example 2, behind named locks that measure their
+// contention, ranked in a hottest locks report next to the runtime's own
+// mutex profile. The config lock is held while the report is written, so
+// it shows its holder.
+func LockContention(w io.Writer) {
+	fmt.Fprintln(w, "\n5. Lock Contention Example:")
+	defer runtime.SetMutexProfileFraction(runtime.SetMutexProfileFraction(1))
+	var (
+		reg      = lockstat.NewRegistry()
+		counterM = lockstat.NewMutex("counter", lockstat.WithRegistry(reg))
+		cacheM   = lockstat.NewRWMutex("cache", lockstat.WithRegistry(reg))
+		configM  = lockstat.NewMutex("config", lockstat.WithRegistry(reg))
+		wg       sync.WaitGroup
+	)
+
+	// Many short critical sections: frequent, brief waits. The gate
+	// starts the goroutines together so they compete for the lock.
+	counter := 0
+	start := make(chan struct{})
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for range 1000 {
+				counterM.Lock()
+				counter++
+				counterM.Unlock()
+			}
+		}()
+	}
+
+	close(start)
+
+	// A writer that works while holding the lock keeps readers waiting
+	cache := make(map[int]int)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			cacheM.Lock()
+			cache[i] = i * i
+			time.Sleep(100 * time.Microsecond)
+			cacheM.Unlock()
+		}
+	}()
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				cacheM.RLock()
+				_ = cache[i%20]
+				cacheM.RUnlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	configM.Lock()
+	defer configM.Unlock()
+	fmt.Fprintf(w, "Counter: %d, cache entries: %d\n", counter, len(cache))
+	reg.WriteReport(w, 3)
+}
+
+// RetryableOnce runs example 6: initialization that can fail. Five
+// goroutines share one failing attempt, retries wait out a backoff, and a
+// caller can stop waiting for a slow initializer without cancelling it.
+func RetryableOnce(w io.Writer) {
+	fmt.Fprintln(w, "\n6. Retryable Once Example:")
+	ctx := context.Background()
+
+	// A connection that is refused twice; backoff is on a fake clock
+	fake := clock.NewFake(time.Time{})
+	var attempts atomic.Int32
+	conn := lazy.New(func(ctx context.Context) (string, error) {
+		if attempts.Add(1) <= 2 {
+			return "", errors.New("connection refused")
+		}
+		return "conn#1", nil
+	}, lazy.WithBackoff(100*time.Millisecond, time.Second), lazy.WithClock(fake))
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = conn.Get(ctx)
+		}()
+	}
+	wg.Wait()
+	fmt.Fprintf(w, "5 callers, %d attempt(s), first error: %v\n", attempts.Load(), errs[0])
+	_, err := conn.Get(ctx)
+	fmt.Fprintf(w, "Called again during backoff: %v, still %d attempt(s)\n", err, attempts.Load())
+	fake.Advance(100 * time.Millisecond)
+	_, err = conn.Get(ctx)
+	fmt.Fprintf(w, "After 100ms: %v, %d attempts; next retry in 200ms\n", err, attempts.Load())
+	fake.Advance(200 * time.Millisecond)
+	c, err := conn.Get(ctx)
+	fmt.Fprintf(w, "After 200ms more: %s, %v, %d attempts\n", c, err, attempts.Load())
+
+	// A caller that gives up does not abandon the initialization
+	release := make(chan struct{})
+	config := lazy.New(func(ctx context.Context) (string, error) {
+		<-release
+		return "loaded", nil
+	})
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	_, err = config.Get(short)
+	cancel()
+	close(release)
+	v, _ := config.Get(ctx)
+	fmt.Fprintf(w, "Impatient caller: %v; next caller: %s\n", err, v)
+}
+
+// RWLockPolicies runs example 7: the same read-heavy load on an rwlock
+// RWMutex with each policy. Four readers hold the lock in turn, yielding
+// while they do so that their holds overlap, and a writer tries to lock it
+// every millisecond, giving up after 20ms. With readers preferred the
+// writer starves; otherwise it only waits for the readers already in.
+func RWLockPolicies(w io.Writer) {
+	fmt.Fprintln(w, "\n7. RWLock Policies Example:")
+
+	l := rwlock.New(rwlock.WriterPreferring)
+	l.RLock()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	err := l.LockContext(ctx)
+	cancel()
+	fmt.Fprintf(w, "While read-locked: TryLock = %v, LockContext: %v\n", l.TryLock(), err)
+	l.RUnlock()
+	fmt.Fprintf(w, "After RUnlock: TryLock = %v\n", l.TryLock())
+	l.Unlock()
+
+	for _, p := range []rwlock.Policy{rwlock.ReaderPreferring, rwlock.WriterPreferring, rwlock.PhaseFair} {
+		l := rwlock.New(p)
+		var (
+			wg   sync.WaitGroup
+			stop atomic.Bool
+		)
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for !stop.Load() {
+					l.RLock()
+					runtime.Gosched()
+					l.RUnlock()
+				}
+			}()
+		}
+
+		for deadline := time.Now().Add(200 * time.Millisecond); time.Now().Before(deadline); {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			if l.LockContext(ctx) == nil {
+				l.Unlock()
+			}
+			cancel()
+			time.Sleep(time.Millisecond)
+		}
+		stop.Store(true)
+		wg.Wait()
+
+		s := l.Stats()
+		fmt.Fprintf(w, "%-17s  writes %3d  gave up %3d  max write wait %-8v  reads %d\n",
+			p, s.Writes, s.AbandonedWrites, s.MaxWriteWait.Round(time.Microsecond), s.Reads)
+	}
+}
+
+// DynamicWaitGroup runs example 8: example 1's counter on a waitgroup
+// Group limited to two goroutines at a time, goroutines that start more
+// goroutines while Wait is already waiting, every error collected, a Wait
+// that gives up, and the panic for a Go after the Group has finished.
+func DynamicWaitGroup(w io.Writer) {
+	fmt.Fprintln(w, "\n8. Dynamic WaitGroup Example:")
+	ctx := context.Background()
+
+	// Example 1's counter, two incrementers at a time
+	var (
+		counter       int
+		mutex         sync.Mutex
+		running, most atomic.Int32
+	)
+	g := waitgroup.New(waitgroup.WithLimit(2))
+	for range 5 {
+		g.Go(func() error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for cur := most.Load(); n > cur && !most.CompareAndSwap(cur, n); cur = most.Load() {
+			}
+			for j := 0; j < 1000; j++ {
+				mutex.Lock()
+				counter++
+				mutex.Unlock()
+				if j%100 == 0 {
+					runtime.Gosched()
+				}
+			}
+			return nil
+		})
+	}
+	err := g.Wait(ctx)
+	fmt.Fprintf(w, "Final counter value: %d, never more than 2 running: %v, error: %v\n", counter, most.Load() <= 2, err)
+
+	// A tree walk: each node starts its children, so the count may grow
+	// while Wait is waiting, and every failing node's error is kept
+	tree := map[string][]string{
+		"/":     {"/etc", "/home", "/tmp"},
+		"/etc":  {"/etc/hosts", "/etc/passwd"},
+		"/home": {"/home/ann", "/home/bob"},
+		"/tmp":  {},
+	}
+	var visited atomic.Int32
+	g = waitgroup.New()
+	var walk func(dir string) error
+	walk = func(dir string) error {
+		visited.Add(1)
+		children, ok := tree[dir]
+		if !ok && strings.HasPrefix(dir, "/home/") {
+			return fmt.Errorf("%s: permission denied", dir)
+		}
+		for _, c := range children {
+			g.Go(func() error { return walk(c) })
+		}
+		return nil
+	}
+	g.Go(func() error { return walk("/") })
+	err = g.Wait(ctx)
+	var denied []string
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			denied = append(denied, e.Error())
+		}
+	} else if err != nil {
+		denied = append(denied, err.Error())
+	}
+	slices.Sort(denied)
+	fmt.Fprintf(w, "Walked %d paths; %d errors: %s\n", visited.Load(), len(denied), strings.Join(denied, ", "))
+
+	// Wait gives up, the goroutine carries on, and a later Wait sees it end
+	release := make(chan struct{})
+	g = waitgroup.New()
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	err = g.Wait(short)
+	cancel()
+	close(release)
+	fmt.Fprintf(w, "Impatient Wait: %v; next Wait: %v\n", err, g.Wait(ctx))
+
+	// Go after the Group has finished would not be waited for
+	func() {
+		defer func() { fmt.Fprintf(w, "Go after Wait: %v\n", recover()) }()
+		g.Go(func() error { return nil })
+	}()
+}
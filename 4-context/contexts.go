@@ -0,0 +1,141 @@
+// Package contexts is module 4 of the learning path: the context package
+// for cancellation and timeouts.
+//
+// Each example is an exported function that writes what it does to an
+// io.Writer; Run prints the exercises and then runs every example.
+package contexts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"go-concurrency/clock"
+)
+
+// Run prints the module overview and runs every example on clk, writing
+// to w.
+func Run(w io.Writer, clk clock.Clock) {
+	// === Context Package ===
+	// This module demonstrates context usage for cancellation and timeouts in Go.
+	// Complete the following exercises:
+
+	// TODO: Implement the following context patterns:
+
+	// 1. Basic Context Operations
+	//    - Create context.Background()
+	//    - Use context.TODO() for placeholders
+	//    - Understand context hierarchy
+	//    - Implement context propagation
+
+	// 2. Context with Timeout
+	//    - Use context.WithTimeout()
+	//    - Handle timeout scenarios
+	//    - Implement cascading timeouts
+	//    - Handle timeout errors
+
+	// 3. Context with Cancellation
+	//    - Use context.WithCancel()
+	//    - Implement manual cancellation
+	//    - Handle cancellation propagation
+	//    - Implement cleanup on cancellation
+
+	// 4. Context with Deadline
+	//    - Use context.WithDeadline()
+	//    - Handle deadline scenarios
+	//    - Implement deadline-based operations
+	//    - Handle deadline exceeded errors
+
+	// 5. Context with Values
+	//    - Use context.WithValue()
+	//    - Implement request-scoped data
+	//    - Handle context value propagation
+	//    - Avoid context value abuse
+
+	// 6. Context Best Practices
+	//    - Pass context as first parameter
+	//    - Don't store context in structs
+	//    - Use context for cancellation only
+	//    - Implement proper context cleanup
+
+	// Instructions:
+	// - Implement each pattern in separate functions
+	// - Add proper error handling
+	// - Use meaningful variable names
+	// - Add comments explaining concepts
+	// - Test with different scenarios
+
+	fmt.Fprintln(w, "=== Context Package ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/4-context")
+	fmt.Fprintln(w, "Then implement each context pattern!")
+	fmt.Fprintln(w)
+
+	// Example implementations (to be replaced with your code):
+	fmt.Fprintln(w, "Example implementations:")
+
+	WithTimeout(w, clk)
+	WithCancellation(w, clk)
+	WithDeadline(w, clk)
+}
+
+// WithTimeout runs example 1: work that finishes in 1s under a 2s timeout.
+func WithTimeout(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n1. Context with Timeout:")
+	ctx, cancel := clk.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-clk.After(1 * time.Second):
+			fmt.Fprintln(w, "Work completed successfully")
+		case <-ctx.Done():
+			fmt.Fprintln(w, "Work cancelled:", ctx.Err())
+		}
+	}()
+
+	clk.Sleep(3 * time.Second)
+}
+
+// WithCancellation runs example 2: a worker loop stopped by calling cancel.
+func WithCancellation(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n2. Context with Cancellation:")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				fmt.Fprintln(w, "Goroutine cancelled:", ctx.Err())
+				return
+			default:
+				fmt.Fprintln(w, "Working...")
+				clk.Sleep(200 * time.Millisecond)
+			}
+		}
+	}()
+
+	clk.Sleep(500 * time.Millisecond)
+	cancel()
+	clk.Sleep(100 * time.Millisecond)
+}
+
+// WithDeadline runs example 3: work that would take 2s cut off by a
+// deadline 1s away.
+func WithDeadline(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n3. Context with Deadline:")
+	deadline := clk.Now().Add(1 * time.Second)
+	ctx, cancel := clk.WithDeadline(context.Background(), deadline)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-clk.After(2 * time.Second):
+			fmt.Fprintln(w, "This should not print")
+		case <-ctx.Done():
+			fmt.Fprintln(w, "Deadline reached:", ctx.Err())
+		}
+	}()
+
+	clk.Sleep(2 * time.Second)
+}
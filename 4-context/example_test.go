@@ -0,0 +1,39 @@
+package contexts_test
+
+import (
+	"os"
+	"time"
+
+	contexts "go-concurrency/4-context"
+	"go-concurrency/clock"
+)
+
+func ExampleWithTimeout() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { contexts.WithTimeout(os.Stdout, clk) })
+
+	// Output:
+	// 1. Context with Timeout:
+	// Work completed successfully
+}
+
+func ExampleWithCancellation() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { contexts.WithCancellation(os.Stdout, clk) })
+
+	// Output:
+	// 2. Context with Cancellation:
+	// Working...
+	// Working...
+	// Working...
+	// Goroutine cancelled: context canceled
+}
+
+func ExampleWithDeadline() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	clk.Drive(func() { contexts.WithDeadline(os.Stdout, clk) })
+
+	// Output:
+	// 3. Context with Deadline:
+	// Deadline reached: context deadline exceeded
+}
@@ -0,0 +1,23 @@
+package contexts
+
+import (
+	"context"
+	"time"
+)
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 4" to check them.
+type Solution struct {
+	// 2. Context with Timeout: WithTimeout runs work with a context that
+	// expires after d and returns context.DeadlineExceeded if it does.
+	WithTimeout func(ctx context.Context, d time.Duration, work func(ctx context.Context) error) error
+
+	// 5. Context with Values: WithRequestID stores id in ctx and RequestID
+	// reads it back, reporting false if ctx has none.
+	WithRequestID func(ctx context.Context, id string) context.Context
+	RequestID     func(ctx context.Context) (string, bool)
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
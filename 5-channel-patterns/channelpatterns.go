@@ -1,10 +1,15 @@
-package main
+// Package channelpatterns is module 5 of the learning path: advanced channel patterns.
+//
+// Run prints the module's exercises; implement each one in this package.
+package channelpatterns
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Channel Patterns ===
 	// This module demonstrates advanced channel patterns in Go.
 	// Complete the following exercises:
@@ -50,7 +55,7 @@ func main() {
 	// - Add comments explaining the pattern
 	// - Test each pattern with different scenarios
 
-	fmt.Println("=== Channel Patterns ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each pattern one by one!")
+	fmt.Fprintln(w, "=== Channel Patterns ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/5-channel-patterns")
+	fmt.Fprintln(w, "Then implement each pattern one by one!")
 }
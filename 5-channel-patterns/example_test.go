@@ -0,0 +1,16 @@
+package channelpatterns_test
+
+import (
+	"os"
+
+	channelpatterns "go-concurrency/5-channel-patterns"
+)
+
+func ExampleRun() {
+	channelpatterns.Run(os.Stdout)
+
+	// Output:
+	// === Channel Patterns ===
+	// Run: go run ./cmd/5-channel-patterns
+	// Then implement each pattern one by one!
+}
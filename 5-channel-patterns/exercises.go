@@ -0,0 +1,31 @@
+package channelpatterns
+
+import "context"
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 5" to check them.
+//
+// Every function returns a channel it owns: it must close the channel when
+// it is done and stop, without leaking goroutines, when ctx is done.
+type Solution struct {
+	// 3. Pipeline Pattern, stage 1: Generate sends nums in order.
+	Generate func(ctx context.Context, nums ...int) <-chan int
+
+	// 3. Pipeline Pattern, stage 2: Square sends the square of each value
+	// from in, in order.
+	Square func(ctx context.Context, in <-chan int) <-chan int
+
+	// 4. Fan-in Pattern: Merge forwards every value from every input.
+	Merge func(ctx context.Context, ins ...<-chan int) <-chan int
+
+	// 5. Fan-out Pattern: FanOut applies fn to values from in on workers
+	// goroutines.
+	FanOut func(ctx context.Context, in <-chan int, workers int, fn func(int) int) <-chan int
+
+	// 6. Generator Pattern: Counter sends 0, 1, 2, ... until ctx is done.
+	Counter func(ctx context.Context) <-chan int
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -1,10 +1,15 @@
-package main
+// Package errorhandling is module 6 of the learning path: error handling patterns for concurrent code.
+//
+// Run prints the module's exercises; implement each one in this package.
+package errorhandling
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Error Handling in Concurrent Code ===
 	// This module demonstrates proper error handling patterns in Go concurrency.
 	// Complete the following exercises:
@@ -54,7 +59,7 @@ func main() {
 	// - Add unit tests for error scenarios
 	// - Handle edge cases and race conditions
 
-	fmt.Println("=== Error Handling in Concurrent Code ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each error handling pattern!")
+	fmt.Fprintln(w, "=== Error Handling in Concurrent Code ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/6-error-handling")
+	fmt.Fprintln(w, "Then implement each error handling pattern!")
 }
@@ -0,0 +1,16 @@
+package errorhandling_test
+
+import (
+	"os"
+
+	errorhandling "go-concurrency/6-error-handling"
+)
+
+func ExampleRun() {
+	errorhandling.Run(os.Stdout)
+
+	// Output:
+	// === Error Handling in Concurrent Code ===
+	// Run: go run ./cmd/6-error-handling
+	// Then implement each error handling pattern!
+}
@@ -0,0 +1,40 @@
+package errorhandling
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrOpen is returned by CircuitBreaker.Call while the circuit is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker stops calling a failing dependency until it recovers.
+type CircuitBreaker interface {
+	// Call runs fn unless the circuit is open, in which case it returns
+	// ErrOpen without calling fn.
+	Call(fn func() error) error
+}
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 6" to check them.
+type Solution struct {
+	// 1. Error Channel Pattern: CollectErrors runs every task concurrently
+	// and returns all of their non-nil errors.
+	CollectErrors func(tasks ...func() error) []error
+
+	// 2. Panic Recovery Pattern: SafeGo runs fn in a goroutine and sends a
+	// non-nil error on the returned channel if fn panics, nil otherwise.
+	SafeGo func(fn func()) <-chan error
+
+	// 4. Error Propagation Pattern: Retry calls fn up to attempts times
+	// until it succeeds, stopping early when ctx is done.
+	Retry func(ctx context.Context, attempts int, fn func() error) error
+
+	// 5. Circuit Breaker Pattern: NewCircuitBreaker opens after maxFailures
+	// consecutive failures.
+	NewCircuitBreaker func(maxFailures int) CircuitBreaker
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -0,0 +1,16 @@
+package syncadvanced_test
+
+import (
+	"os"
+
+	syncadvanced "go-concurrency/7-sync-advanced"
+)
+
+func ExampleRun() {
+	syncadvanced.Run(os.Stdout)
+
+	// Output:
+	// === Advanced Synchronization Primitives ===
+	// Run: go run ./cmd/7-sync-advanced
+	// Then implement each advanced sync pattern!
+}
@@ -0,0 +1,40 @@
+package syncadvanced
+
+import (
+	"context"
+	"sync"
+)
+
+// Semaphore limits how many goroutines hold it at once.
+type Semaphore interface {
+	// Acquire waits for a slot or returns ctx.Err() if ctx is done first.
+	Acquire(ctx context.Context) error
+	// Release frees a slot taken by Acquire.
+	Release()
+}
+
+// Barrier blocks goroutines until a fixed number of them have arrived.
+type Barrier interface {
+	// Await waits until all parties have called Await, or returns ctx.Err()
+	// if ctx is done first.
+	Await(ctx context.Context) error
+}
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 7" to check them.
+type Solution struct {
+	// 4. Custom Synchronization Pattern: NewSemaphore allows n holders.
+	NewSemaphore func(n int) Semaphore
+
+	// 4. Custom Synchronization Pattern: NewBarrier releases parties
+	// goroutines at a time.
+	NewBarrier func(parties int) Barrier
+
+	// 5. Channel-based Synchronization Pattern: NewChanMutex returns a
+	// mutex built from a channel.
+	NewChanMutex func() sync.Locker
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -1,10 +1,15 @@
-package main
+// Package syncadvanced is module 7 of the learning path: advanced sync primitives.
+//
+// Run prints the module's exercises; implement each one in this package.
+package syncadvanced
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Advanced Synchronization Primitives ===
 	// This module demonstrates advanced sync package usage in Go.
 	// Complete the following exercises:
@@ -54,7 +59,7 @@ func main() {
 	// - Handle edge cases and race conditions
 	// - Add comprehensive comments
 
-	fmt.Println("=== Advanced Synchronization Primitives ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each advanced sync pattern!")
+	fmt.Fprintln(w, "=== Advanced Synchronization Primitives ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/7-sync-advanced")
+	fmt.Fprintln(w, "Then implement each advanced sync pattern!")
 }
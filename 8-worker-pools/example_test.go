@@ -0,0 +1,28 @@
+package workerpools_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	workerpools "go-concurrency/8-worker-pools"
+	"go-concurrency/clock"
+)
+
+// Five jobs paced by a 200ms ticker, plus 100ms of work on the last one,
+// take 1.1s of fake time, which Drive skips through at once.
+func ExampleRateLimitedWorkerPool() {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	start := clk.Now()
+	clk.Drive(func() { workerpools.RateLimitedWorkerPool(os.Stdout, clk) })
+	fmt.Println("Took", clk.Since(start))
+
+	// Output:
+	// 2. Worker Pool with Rate Limiting:
+	// Processing task-1
+	// Processing task-2
+	// Processing task-3
+	// Processing task-4
+	// Processing task-5
+	// Took 1.1s
+}
@@ -0,0 +1,25 @@
+package workerpools
+
+import "context"
+
+// Limiter paces callers to a fixed rate.
+type Limiter interface {
+	// Wait blocks until the caller may proceed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 8" to check them.
+type Solution struct {
+	// 1. Basic Worker Pool: Pool processes jobs with fn on workers
+	// goroutines and closes the results channel once jobs is drained.
+	Pool func(ctx context.Context, workers int, jobs <-chan int, fn func(int) int) <-chan int
+
+	// 5. Worker Pool with Rate Limiting: NewTokenBucket allows perSecond
+	// events per second on average with bursts of up to burst.
+	NewTokenBucket func(perSecond float64, burst int) Limiter
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
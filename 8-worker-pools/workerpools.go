@@ -0,0 +1,217 @@
+// Package workerpools is module 8 of the learning path: worker pool
+// patterns and implementations.
+//
+// Each example is an exported function that writes what it does to an
+// io.Writer, which must be safe for concurrent use because several
+// goroutines write to it; Run prints the exercises and then runs every
+// example.
+package workerpools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"go-concurrency/chanmetrics"
+	"go-concurrency/clock"
+)
+
+// Run prints the module overview and runs every example on clk, writing
+// to w. The instrumented channels of InstrumentedWorkerPool are not served;
+// "go run ./cmd/8-worker-pools -metrics addr" serves them.
+func Run(w io.Writer, clk clock.Clock) {
+	// === Worker Pool Patterns ===
+	// This module demonstrates various worker pool implementations in Go.
+	// Complete the following exercises:
+
+	// TODO: Implement the following worker pool patterns:
+
+	// 1. Basic Worker Pool
+	//    - Create fixed number of workers
+	//    - Use channels for job distribution
+	//    - Implement result collection
+	//    - Handle worker lifecycle
+
+	// 2. Dynamic Worker Pool
+	//    - Scale workers based on load
+	//    - Implement worker health checks
+	//    - Handle worker failures
+	//    - Implement graceful scaling
+
+	// 3. Priority Worker Pool
+	//    - Implement priority queues
+	//    - Handle high-priority jobs first
+	//    - Implement job preemption
+	//    - Handle priority inversion
+
+	// 4. Worker Pool with Backpressure
+	//    - Implement flow control
+	//    - Handle queue overflow
+	//    - Implement rate limiting
+	//    - Handle backpressure scenarios
+
+	// 5. Worker Pool with Rate Limiting
+	//    - Implement rate limiting
+	//    - Handle burst traffic
+	//    - Implement token bucket
+	//    - Handle rate limit violations
+
+	// 6. Worker Pool Best Practices
+	//    - Implement proper error handling
+	//    - Add monitoring and metrics
+	//    - Handle graceful shutdown
+	//    - Implement resource cleanup
+
+	// Instructions:
+	// - Implement each pattern in separate functions
+	// - Add proper error handling
+	// - Use meaningful variable names
+	// - Add comments explaining concepts
+	// - Test with different scenarios
+
+	fmt.Fprintln(w, "=== Worker Pool Patterns ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/8-worker-pools")
+	fmt.Fprintln(w, "Then implement each worker pool pattern!")
+	fmt.Fprintln(w)
+
+	// Example implementations (to be replaced with your code):
+	fmt.Fprintln(w, "Example implementations:")
+
+	BasicWorkerPool(w, clk)
+	RateLimitedWorkerPool(w, clk)
+	InstrumentedWorkerPool(w, clk, nil)
+
+	fmt.Fprintln(w, "All worker pool examples completed!")
+}
+
+// BasicWorkerPool runs example 1: three workers drain five jobs from a
+// channel and send doubled results back.
+func BasicWorkerPool(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n1. Basic Worker Pool:")
+	jobs := make(chan int, 5)
+	results := make(chan int, 5)
+
+	// Start workers
+	numWorkers := 3
+	var wg sync.WaitGroup
+
+	for i := 1; i <= numWorkers; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			for job := range jobs {
+				fmt.Fprintf(w, "Worker %d processing job %d\n", workerID, job)
+				clk.Sleep(500 * time.Millisecond) // Simulate work
+				results <- job * 2
+			}
+		}(i)
+	}
+
+	// Send jobs
+	go func() {
+		for i := 1; i <= 5; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	// Collect results
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Print results
+	for result := range results {
+		fmt.Fprintf(w, "Result: %d\n", result)
+	}
+}
+
+// RateLimitedWorkerPool runs example 2: jobs processed no faster than one
+// every 200ms.
+func RateLimitedWorkerPool(w io.Writer, clk clock.Clock) {
+	fmt.Fprintln(w, "\n2. Worker Pool with Rate Limiting:")
+	jobs := make(chan string, 10)
+	rateLimiter := clk.NewTicker(200 * time.Millisecond) // Process one job every 200ms
+	defer rateLimiter.Stop()
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			jobs <- fmt.Sprintf("task-%d", i)
+		}
+		close(jobs)
+	}()
+
+	for job := range jobs {
+		<-rateLimiter.C() // Wait for rate limit
+		fmt.Fprintf(w, "Processing %s\n", job)
+		clk.Sleep(100 * time.Millisecond)
+	}
+}
+
+// InstrumentedWorkerPool runs example 3: the basic pool with jobs and
+// results on instrumented channels, reporting how full they got, how long
+// each side waited and how fast values went through. The channels are
+// registered with reg, so a program can serve them over HTTP while the
+// example runs; a nil reg keeps them to the example.
+func InstrumentedWorkerPool(w io.Writer, clk clock.Clock, reg *chanmetrics.Registry) {
+	fmt.Fprintln(w, "\n3. Instrumented Worker Pool:")
+	ctx := context.Background()
+	if reg == nil {
+		reg = chanmetrics.NewRegistry()
+	}
+	jobs := chanmetrics.New[int]("jobs", 5, chanmetrics.WithClock(clk), chanmetrics.WithRegistry(reg))
+	results := chanmetrics.New[int]("results", 5, chanmetrics.WithClock(clk), chanmetrics.WithRegistry(reg))
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				job, err := jobs.Recv(ctx)
+				if err != nil {
+					return // chanmetrics.ErrClosed: no more jobs
+				}
+				clk.Sleep(100 * time.Millisecond) // Simulate work
+				if err := results.Send(ctx, job*2); err != nil {
+					return
+				}
+			}
+		}()
+	}
+
+	// More jobs than buffer space, so the sender has to wait for workers
+	go func() {
+		defer jobs.Close()
+		for i := 1; i <= 10; i++ {
+			if err := jobs.Send(ctx, i); err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		results.Close()
+	}()
+
+	sum := 0
+	for {
+		result, err := results.Recv(ctx)
+		if err != nil {
+			break
+		}
+		sum += result
+	}
+	fmt.Fprintln(w, "Sum of results:", sum)
+
+	for _, s := range []chanmetrics.Snapshot{jobs.Snapshot(), results.Snapshot()} {
+		fmt.Fprintf(w, "%-8s sent %d, received %d, max length %d/%d, %.0f/s\n",
+			s.Name, s.Sent, s.Received, s.MaxLen, s.Cap, s.RecvRate)
+		fmt.Fprintf(w, "         senders waited %d times (max %v), receivers %d times (max %v)\n",
+			s.SendWait.Blocked, s.SendWait.Max.Round(time.Millisecond), s.RecvWait.Blocked, s.RecvWait.Max.Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,16 @@
+package pipelines_test
+
+import (
+	"os"
+
+	pipelines "go-concurrency/9-pipeline-patterns"
+)
+
+func ExampleRun() {
+	pipelines.Run(os.Stdout)
+
+	// Output:
+	// === Pipeline Patterns ===
+	// Run: go run ./cmd/9-pipeline-patterns
+	// Then implement each pipeline pattern!
+}
@@ -0,0 +1,27 @@
+package pipelines
+
+import (
+	"context"
+	"time"
+)
+
+// Solution holds your implementations of this module's exercises. Assign
+// them to Exercises and run "go run ./cmd/gocon grade 9" to check them.
+//
+// Every stage owns its output channel: it must close it once its input is
+// closed and stop, without leaking goroutines, when ctx is done.
+type Solution struct {
+	// 1. Simple Pipeline Pattern: Map applies fn to each value, in order.
+	Map func(ctx context.Context, in <-chan int, fn func(int) int) <-chan int
+
+	// 1. Simple Pipeline Pattern: Filter keeps values for which keep is true.
+	Filter func(ctx context.Context, in <-chan int, keep func(int) bool) <-chan int
+
+	// 6. Batch Processing Pipeline Pattern: Batch groups values into slices
+	// of up to size, flushing a partial batch after wait without new values.
+	Batch func(ctx context.Context, in <-chan int, size int, wait time.Duration) <-chan []int
+}
+
+// Exercises is the solution graded by gocon grade; fill in each field as
+// you implement it.
+var Exercises Solution
@@ -1,10 +1,15 @@
-package main
+// Package pipelines is module 9 of the learning path: pipeline patterns for data processing.
+//
+// Run prints the module's exercises; implement each one in this package.
+package pipelines
 
 import (
 	"fmt"
+	"io"
 )
 
-func main() {
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
 	// === Pipeline Patterns ===
 	// This module demonstrates various pipeline patterns for data processing.
 	// Complete the following exercises:
@@ -56,7 +61,7 @@ func main() {
 	// - Handle resource cleanup and goroutine leaks
 	// - Test with different data sizes and patterns
 
-	fmt.Println("=== Pipeline Patterns ===")
-	fmt.Println("Run: go run main.go")
-	fmt.Println("Then implement each pipeline pattern!")
+	fmt.Fprintln(w, "=== Pipeline Patterns ===")
+	fmt.Fprintln(w, "Run: go run ./cmd/9-pipeline-patterns")
+	fmt.Fprintln(w, "Then implement each pipeline pattern!")
 }
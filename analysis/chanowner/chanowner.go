@@ -0,0 +1,481 @@
+// Package chanowner defines an Analyzer that checks channels are closed
+// and used according to who owns them.
+//
+// The owner of a channel is the function that creates it, together with
+// the function literals it starts; only the owner should close it, exactly
+// once, and nobody may send on it afterwards. The Analyzer reports:
+//
+//   - closing a channel received as a chan T parameter, which the caller
+//     created and may still send on or close itself. A chan<- T parameter
+//     is treated as the caller handing over the job of closing it;
+//   - closing, inside a loop, a channel declared outside that loop, unless
+//     the close is followed by leaving the loop or only runs on the first
+//     iteration, as in "if i == 0 { close(ch) }" in a counting loop: the
+//     second iteration panics with "close of closed channel";
+//   - sending on a channel after closing it in the same function, which
+//     panics with "send on closed channel";
+//   - a chan T parameter that the function only receives from (or only
+//     sends on), which should say so with <-chan T (or chan<- T) so the
+//     compiler rejects misuse.
+//
+// The checks are local to one function and follow channels held in
+// variables; channels in struct fields, slices or maps are not tracked.
+package chanowner
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check channel ownership: who closes, when, and in which direction
+
+Reports closes of channels the function did not create, closes inside
+loops, sends after close, and bidirectional channel parameters that are
+only used in one direction.`
+
+// Analyzer reports channel ownership and direction mistakes.
+var Analyzer = &analysis.Analyzer{
+	Name:     "chanowner",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/go-concurrency/analysis/chanowner",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	filter := []ast.Node{(*ast.CallExpr)(nil), (*ast.SendStmt)(nil)}
+	inspect.WithStack(filter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if v := closed(pass, n); v != nil {
+				checkClose(pass, n, v, stack)
+			}
+		case *ast.SendStmt:
+			if v := chanVar(pass, n.Chan); v != nil {
+				checkSend(pass, n, v, stack)
+			}
+		}
+		return true
+	})
+
+	funcs := []ast.Node{(*ast.FuncDecl)(nil), (*ast.GoStmt)(nil)}
+	inspect.Preorder(funcs, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			// A method's signature may be dictated by an interface
+			if n.Recv == nil && n.Body != nil {
+				checkDirections(pass, n.Type, n.Body)
+			}
+		case *ast.GoStmt:
+			// A literal started directly has no other caller to satisfy
+			if lit, ok := ast.Unparen(n.Call.Fun).(*ast.FuncLit); ok {
+				checkDirections(pass, lit.Type, lit.Body)
+			}
+		}
+	})
+	return nil, nil
+}
+
+// checkClose reports a close of v by a function that does not own it, or
+// inside a loop that can close it twice.
+func checkClose(pass *analysis.Pass, call *ast.CallExpr, v *types.Var, stack []ast.Node) {
+	name := v.Name()
+	if isParam(v, stack) && isBidirectional(v.Type()) {
+		pass.Reportf(call.Pos(), "close of %s, which was created by the caller; let the owner close it, or take a chan<- parameter to hand over ownership", name)
+		return
+	}
+
+	// Walk outwards to the enclosing function, looking for a loop that
+	// v was declared outside of
+	deferred := false
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return
+		case *ast.DeferStmt:
+			deferred = true
+		case *ast.ForStmt, *ast.RangeStmt:
+			if within(v.Pos(), n) {
+				return // a fresh channel every iteration
+			}
+			if !deferred && leavesLoop(stack[i+1:]) {
+				return
+			}
+			if firstIteration(pass, n, stack[i+1:]) {
+				return
+			}
+			pass.Reportf(call.Pos(), "close of %s inside a loop; the next iteration closes it again and panics", name)
+			return
+		}
+	}
+}
+
+// checkSend reports a send on v preceded by close(v) in the same or an
+// enclosing statement list of the same function.
+func checkSend(pass *analysis.Pass, send *ast.SendStmt, v *types.Var, stack []ast.Node) {
+	for i := len(stack) - 2; i >= 0; i-- {
+		var list []ast.Stmt
+		switch n := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		default:
+			continue
+		}
+		child := stack[i+1]
+		for _, stmt := range list {
+			if stmt == child {
+				break
+			}
+			expr, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			if call, ok := expr.X.(*ast.CallExpr); ok && closed(pass, call) == v {
+				pass.Reportf(send.Pos(), "send on %s after it was closed; this panics with send on closed channel", v.Name())
+				return
+			}
+		}
+	}
+}
+
+// checkDirections reports bidirectional channel parameters that body uses
+// in one direction only.
+func checkDirections(pass *analysis.Pass, ftype *ast.FuncType, body *ast.BlockStmt) {
+	const (
+		recv = 1 << iota
+		send
+		other
+	)
+	uses := make(map[*types.Var]int)
+	var params []*ast.Ident
+	for _, field := range ftype.Params.List {
+		for _, id := range field.Names {
+			if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok && isBidirectional(v.Type()) {
+				uses[v] = 0
+				params = append(params, id)
+			}
+		}
+	}
+	if len(params) == 0 {
+		return
+	}
+
+	var stack []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		defer func() { stack = append(stack, n) }()
+
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+		if !ok {
+			return true
+		}
+		if _, tracked := uses[v]; !tracked {
+			return true
+		}
+		switch parent := stack[len(stack)-1].(type) {
+		case *ast.UnaryExpr:
+			if parent.Op == token.ARROW {
+				uses[v] |= recv
+				return true
+			}
+		case *ast.RangeStmt:
+			if parent.X == id {
+				uses[v] |= recv
+				return true
+			}
+		case *ast.SendStmt:
+			if parent.Chan == id {
+				uses[v] |= send
+				return true
+			}
+		case *ast.CallExpr:
+			switch builtin(pass, parent) {
+			case "close":
+				uses[v] |= send
+				return true
+			case "len", "cap":
+				return true
+			}
+		}
+		uses[v] |= other // passed on, stored or compared: leave it alone
+		return true
+	})
+
+	for _, id := range params {
+		v := pass.TypesInfo.Defs[id].(*types.Var)
+		elem := types.TypeString(v.Type().Underlying().(*types.Chan).Elem(), types.RelativeTo(pass.Pkg))
+		switch uses[v] {
+		case recv:
+			pass.Reportf(id.Pos(), "channel parameter %s is only received from; declare it <-chan %s", id.Name, elem)
+		case send:
+			pass.Reportf(id.Pos(), "channel parameter %s is only sent on; declare it chan<- %s", id.Name, elem)
+		}
+	}
+}
+
+// leavesLoop reports whether one of the statement lists on path, the
+// nodes between a loop and a close inside it, ends by leaving the loop.
+func leavesLoop(path []ast.Node) bool {
+	nested := false // inside a switch or select, where break leaves only that
+	for _, n := range path {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+			nested = true
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+		if len(list) > 0 && exits(list[len(list)-1], nested) {
+			return true
+		}
+	}
+	return false
+}
+
+// firstIteration reports whether one of the if statements on path, the
+// nodes between loop and a close inside it, only takes its then branch on
+// the first iteration: loop counts with a variable the body leaves alone,
+// and the condition compares it for equality with its initial value.
+func firstIteration(pass *analysis.Pass, loop ast.Node, path []ast.Node) bool {
+	var (
+		counter *types.Var
+		start   constant.Value
+		body    *ast.BlockStmt
+	)
+	switch loop := loop.(type) {
+	case *ast.ForStmt:
+		counter, start = loopCounter(pass, loop)
+		body = loop.Body
+	case *ast.RangeStmt:
+		counter, start = rangeIndex(pass, loop)
+		body = loop.Body
+	}
+	if counter == nil || assigns(pass, body, counter) {
+		return false
+	}
+	for i, n := range path[:len(path)-1] {
+		ifStmt, ok := n.(*ast.IfStmt)
+		if !ok || path[i+1] != ifStmt.Body {
+			continue
+		}
+		cond, ok := ast.Unparen(ifStmt.Cond).(*ast.BinaryExpr)
+		if !ok || cond.Op != token.EQL {
+			continue
+		}
+		for _, operands := range [][2]ast.Expr{{cond.X, cond.Y}, {cond.Y, cond.X}} {
+			id, ok := ast.Unparen(operands[0]).(*ast.Ident)
+			if !ok || pass.TypesInfo.Uses[id] != counter {
+				continue
+			}
+			if value := pass.TypesInfo.Types[operands[1]].Value; value != nil && constant.Compare(value, token.EQL, start) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loopCounter returns the variable loop declares in its init statement
+// and its constant initial value, if loop's post statement only ever moves
+// it away from that value by a constant step.
+func loopCounter(pass *analysis.Pass, loop *ast.ForStmt) (*types.Var, constant.Value) {
+	init, ok := loop.Init.(*ast.AssignStmt)
+	if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+		return nil, nil
+	}
+	id, ok := init.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil
+	}
+	counter, ok := pass.TypesInfo.Defs[id].(*types.Var)
+	start := pass.TypesInfo.Types[init.Rhs[0]].Value
+	if !ok || start == nil {
+		return nil, nil
+	}
+
+	var target ast.Expr
+	switch post := loop.Post.(type) {
+	case *ast.IncDecStmt:
+		target = post.X
+	case *ast.AssignStmt:
+		if (post.Tok != token.ADD_ASSIGN && post.Tok != token.SUB_ASSIGN) || len(post.Rhs) != 1 {
+			return nil, nil
+		}
+		step := pass.TypesInfo.Types[post.Rhs[0]].Value
+		if step == nil || constant.Sign(step) == 0 {
+			return nil, nil
+		}
+		target = post.Lhs[0]
+	default:
+		return nil, nil
+	}
+	if id, ok := ast.Unparen(target).(*ast.Ident); !ok || pass.TypesInfo.Uses[id] != counter {
+		return nil, nil
+	}
+	return counter, start
+}
+
+// rangeIndex returns the index variable loop declares, and 0, if loop
+// ranges over something whose indexes count up from 0.
+func rangeIndex(pass *analysis.Pass, loop *ast.RangeStmt) (*types.Var, constant.Value) {
+	id, ok := loop.Key.(*ast.Ident)
+	if !ok || loop.Tok != token.DEFINE {
+		return nil, nil
+	}
+	index, ok := pass.TypesInfo.Defs[id].(*types.Var)
+	if !ok {
+		return nil, nil
+	}
+	t := pass.TypesInfo.TypeOf(loop.X).Underlying()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem().Underlying()
+	}
+	switch t := t.(type) {
+	case *types.Slice, *types.Array:
+	case *types.Basic:
+		if t.Info()&(types.IsString|types.IsInteger) == 0 {
+			return nil, nil
+		}
+	default:
+		return nil, nil
+	}
+	return index, constant.MakeInt64(0)
+}
+
+// assigns reports whether body assigns to v, or takes its address so that
+// something else could.
+func assigns(pass *analysis.Pass, body *ast.BlockStmt, v *types.Var) bool {
+	is := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		return ok && pass.TypesInfo.Uses[id] == v
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			found = found || slices.ContainsFunc(n.Lhs, is)
+		case *ast.IncDecStmt:
+			found = found || is(n.X)
+		case *ast.UnaryExpr:
+			found = found || n.Op == token.AND && is(n.X)
+		}
+		return !found
+	})
+	return found
+}
+
+// exits reports whether stmt leaves the enclosing loop.
+func exits(stmt ast.Stmt, nested bool) bool {
+	switch stmt := stmt.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		switch stmt.Tok {
+		case token.GOTO:
+			return true
+		case token.BREAK:
+			return stmt.Label != nil || !nested
+		}
+	case *ast.ExprStmt:
+		if call, ok := stmt.X.(*ast.CallExpr); ok {
+			if id, ok := ast.Unparen(call.Fun).(*ast.Ident); ok && id.Name == "panic" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// closed returns the channel variable closed by call, if call is close(v).
+func closed(pass *analysis.Pass, call *ast.CallExpr) *types.Var {
+	if builtin(pass, call) != "close" || len(call.Args) != 1 {
+		return nil
+	}
+	return chanVar(pass, call.Args[0])
+}
+
+// builtin returns the name of the builtin function call invokes, or "".
+func builtin(pass *analysis.Pass, call *ast.CallExpr) string {
+	id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	if b, ok := pass.TypesInfo.Uses[id].(*types.Builtin); ok {
+		return b.Name()
+	}
+	return ""
+}
+
+// chanVar returns the variable e names, if it is a local channel variable.
+func chanVar(pass *analysis.Pass, e ast.Expr) *types.Var {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	if !ok || v.IsField() || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+		return nil
+	}
+	if _, ok := v.Type().Underlying().(*types.Chan); !ok {
+		return nil
+	}
+	return v
+}
+
+// isParam reports whether v is a parameter of one of the functions
+// enclosing the node at the top of stack.
+func isParam(v *types.Var, stack []ast.Node) bool {
+	for _, n := range stack {
+		var ftype *ast.FuncType
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			ftype = n.Type
+		case *ast.FuncLit:
+			ftype = n.Type
+		default:
+			continue
+		}
+		if ftype.Params != nil && within(v.Pos(), ftype.Params) {
+			return true
+		}
+	}
+	return false
+}
+
+func isBidirectional(t types.Type) bool {
+	ch, ok := t.Underlying().(*types.Chan)
+	return ok && ch.Dir() == types.SendRecv
+}
+
+func within(pos token.Pos, n ast.Node) bool {
+	return n.Pos() <= pos && pos < n.End()
+}
@@ -0,0 +1,13 @@
+package chanowner_test
+
+import (
+	"testing"
+
+	"go-concurrency/analysis/chanowner"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), chanowner.Analyzer, "a")
+}
@@ -0,0 +1,256 @@
+package a
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// basicWorkerPool is 8-worker-pools' BasicWorkerPool: the function that
+// makes jobs and results closes each once, from the goroutines it starts.
+func basicWorkerPool() {
+	jobs := make(chan int, 5)
+	results := make(chan int, 5)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 3; i++ {
+		wg.Add(1)
+		go func(workerID int) {
+			defer wg.Done()
+			for job := range jobs {
+				results <- job * 2
+			}
+		}(i)
+	}
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		fmt.Println(result)
+	}
+}
+
+// The same pool with each worker closing results when its jobs run out.
+func workersCloseResults() {
+	jobs := make(chan int, 5)
+	results := make(chan int, 5)
+
+	for i := 1; i <= 3; i++ {
+		go worker(jobs, results)
+	}
+	for i := 1; i <= 5; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	for result := range results {
+		fmt.Println(result)
+	}
+}
+
+func worker(jobs chan int, results chan int) { // want `channel parameter jobs is only received from; declare it <-chan int` `channel parameter results is only sent on; declare it chan<- int`
+	for job := range jobs {
+		results <- job * 2
+	}
+	close(results) // want `close of results, which was created by the caller`
+}
+
+// Directional parameters say who does what; chan<- hands over closing.
+func directionalWorker(jobs <-chan int, results chan<- int) {
+	defer close(results)
+	for job := range jobs {
+		results <- job * 2
+	}
+}
+
+// The producer loop of BasicWorkerPool with close moved into the loop.
+func closeEveryIteration() {
+	jobs := make(chan int, 5)
+	go func() {
+		for i := 1; i <= 5; i++ {
+			jobs <- i
+			close(jobs) // want `close of jobs inside a loop`
+		}
+	}()
+	for job := range jobs {
+		fmt.Println(job)
+	}
+}
+
+func deferCloseInLoop() {
+	for i := 0; i < 3; i++ {
+		done := make(chan struct{})
+		defer close(done) // a new channel each iteration
+	}
+
+	shared := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		defer close(shared) // want `close of shared inside a loop`
+	}
+}
+
+// Closing and then leaving the loop closes once.
+func closeThenLeave(events <-chan int) {
+	out := make(chan int)
+	go func() {
+		for {
+			select {
+			case v, ok := <-events:
+				if !ok {
+					close(out)
+					return
+				}
+				out <- v
+			}
+		}
+	}()
+
+	stop := make(chan struct{})
+	for i := 0; ; i++ {
+		if i == 3 {
+			close(stop)
+			break
+		}
+	}
+
+	quit := make(chan struct{})
+	for {
+		select {
+		case <-stop:
+			close(quit) // want `close of quit inside a loop`
+			// break leaves the select, not the loop
+			break
+		}
+	}
+}
+
+// 2-channels' BufferedChannel, closing before the last send.
+func bufferedSendAfterClose() {
+	buffered := make(chan int, 2)
+	buffered <- 1
+	close(buffered)
+	buffered <- 2 // want `send on buffered after it was closed`
+	fmt.Println(<-buffered)
+}
+
+func sendAfterCloseInBranch(fail bool) {
+	ch := make(chan int, 1)
+	if fail {
+		close(ch)
+		return
+	}
+	ch <- 1 // only reached when the branch did not close ch
+	close(ch)
+	for {
+		ch <- 2 // want `send on ch after it was closed`
+	}
+}
+
+// 2-channels' SelectStatement: the ch1 goroutine only sends, so a
+// literal started with go should say so.
+func selectStatement() {
+	ch1 := make(chan string)
+	go func(out chan string) { // want `channel parameter out is only sent on; declare it chan<- string`
+		time.Sleep(100 * time.Millisecond)
+		out <- "from ch1"
+	}(ch1)
+
+	select {
+	case msg := <-ch1:
+		fmt.Println(msg)
+	case <-time.After(300 * time.Millisecond):
+		fmt.Println("Timeout!")
+	}
+}
+
+// Parameters passed on, stored or used both ways are left alone.
+func passThrough(ch chan int) {
+	worker(ch, ch)
+}
+
+func bothWays(ch chan int) {
+	ch <- <-ch
+}
+
+type relay struct{}
+
+// Methods may have to match an interface, so their parameters are not
+// checked for direction.
+func (relay) Relay(in chan int) {
+	for range in {
+	}
+}
+
+// 3-sync's RWMutexReaders: the writer closes firstWrite after its first
+// write only.
+func closeOnFirstIteration(data map[int]int) {
+	firstWrite := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			data[i] = i * 10
+			if i == 0 {
+				close(firstWrite)
+			}
+		}
+	}()
+	<-firstWrite
+
+	header := make(chan struct{})
+	for i := range 3 {
+		if i == 0 {
+			close(header)
+		}
+	}
+
+	ready := make(chan struct{})
+	for n := 10; n > 0; n -= 2 {
+		if 10 == n {
+			close(ready)
+		}
+	}
+}
+
+// Conditions that hold on more than the first iteration.
+func closeOnLaterIterations(ids <-chan int) {
+	done := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		if i == 1 {
+			close(done) // want `close of done inside a loop`
+		}
+	}
+
+	restarted := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		if i == 0 {
+			close(restarted) // want `close of restarted inside a loop`
+		}
+		if i == 3 {
+			i = -1
+		}
+	}
+
+	last := make(chan struct{})
+	for i := 0; i < 5; i++ {
+		if i == 0 {
+			continue
+		} else {
+			close(last) // want `close of last inside a loop`
+		}
+	}
+
+	first := make(chan struct{})
+	for id := range ids {
+		if id == 0 {
+			close(first) // want `close of first inside a loop`
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Package gocapture defines an Analyzer that reports goroutines whose
+// function literal captures a local variable that the enclosing function
+// goes on to mutate.
+//
+// The goroutine reads the variable by reference, so it observes whatever
+// value the variable holds when it eventually runs rather than the value it
+// had when the goroutine was started:
+//
+//	current := 0
+//	for _, v := range values {
+//		current = v
+//		go func() {
+//			use(current) // reports: current is reassigned after the goroutine starts
+//		}()
+//	}
+//
+// Loop variables declared by a for or range clause are only reported for
+// files built with a Go version older than 1.22, where they were shared
+// between iterations. From Go 1.22 on each iteration gets a fresh copy, so
+// only explicit assignments in the loop body are a problem.
+//
+// Besides go statements, function literals passed to any method named Go
+// (taskgroup.Group, supervisor.Supervisor, errgroup.Group, ...) are checked.
+//
+// A call to a method named Wait (sync.WaitGroup, errgroup.Group, ...) is
+// taken to join the goroutines started before it: a write that can only
+// run after such a call, made in a block that also started the goroutine,
+// is not reported.
+//
+//	for _, p := range parts {
+//		wg.Add(1)
+//		go func() { defer wg.Done(); total += p }()
+//	}
+//	wg.Wait()
+//	total *= 2 // not reported
+package gocapture
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `report goroutines capturing variables mutated later by the enclosing function
+
+A function literal run as a goroutine shares captured variables with the
+function that started it. If that function assigns to the variable after
+starting the goroutine, the goroutine sees a value that depends on timing.
+Pass the value as an argument or copy it into a fresh variable instead.`
+
+// Analyzer reports goroutines that capture later-mutated variables.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gocapture",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/go-concurrency/analysis/gocapture",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	filter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)}
+	inspect.Preorder(filter, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body == nil {
+			return
+		}
+		checkFunc(pass, body)
+	})
+	return nil, nil
+}
+
+// spawn is a function literal started as a goroutine.
+type spawn struct {
+	lit   *ast.FuncLit
+	loops []ast.Stmt // enclosing loops inside the function, outermost first
+}
+
+// join is a statement calling a method named Wait, which is assumed to wait
+// for the goroutines started before it.
+type join struct {
+	pos   token.Pos
+	block *ast.BlockStmt // the block the statement is directly in
+}
+
+// write is an assignment to a local variable.
+type write struct {
+	pos     token.Pos
+	loops   []ast.Stmt
+	loopVar bool // the loop clause updating its own iteration variable
+}
+
+// checkFunc reports spawns directly inside body. Nested function literals
+// are visited separately by run.
+func checkFunc(pass *analysis.Pass, body *ast.BlockStmt) {
+	var (
+		spawns []spawn
+		writes = make(map[*types.Var][]write)
+		joins  []join
+		loops  []ast.Stmt
+	)
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			// Writes inside nested literals happen on their own schedule
+			// and are not the enclosing function's mutation.
+			return false
+
+		case *ast.ForStmt:
+			loops = append(loops, n)
+			if n.Init != nil {
+				ast.Inspect(n.Init, visit)
+			}
+			if n.Cond != nil {
+				ast.Inspect(n.Cond, visit)
+			}
+			ast.Inspect(n.Body, visit)
+			if n.Post != nil {
+				for _, v := range assigned(pass, n.Post) {
+					writes[v] = append(writes[v], write{
+						pos:     n.Post.Pos(),
+						loops:   clone(loops),
+						loopVar: declaredIn(v, n.Init),
+					})
+				}
+			}
+			loops = loops[:len(loops)-1]
+			return false
+
+		case *ast.RangeStmt:
+			loops = append(loops, n)
+			ast.Inspect(n.X, visit)
+			for _, v := range assigned(pass, n) {
+				writes[v] = append(writes[v], write{
+					pos:     n.Pos(),
+					loops:   clone(loops),
+					loopVar: n.Tok == token.DEFINE,
+				})
+			}
+			ast.Inspect(n.Body, visit)
+			loops = loops[:len(loops)-1]
+			return false
+
+		case *ast.BlockStmt:
+			for _, stmt := range n.List {
+				if isWait(stmt) {
+					joins = append(joins, join{pos: stmt.Pos(), block: n})
+				}
+			}
+
+		case *ast.GoStmt:
+			if lit, ok := ast.Unparen(n.Call.Fun).(*ast.FuncLit); ok {
+				spawns = append(spawns, spawn{lit: lit, loops: clone(loops)})
+			}
+
+		case *ast.CallExpr:
+			if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Go" {
+				for _, arg := range n.Args {
+					if lit, ok := ast.Unparen(arg).(*ast.FuncLit); ok {
+						spawns = append(spawns, spawn{lit: lit, loops: clone(loops)})
+					}
+				}
+			}
+
+		case *ast.AssignStmt, *ast.IncDecStmt:
+			for _, v := range assigned(pass, n.(ast.Stmt)) {
+				writes[v] = append(writes[v], write{pos: n.Pos(), loops: clone(loops)})
+			}
+		}
+		return true
+	}
+	ast.Inspect(body, visit)
+
+	for _, s := range spawns {
+		perIteration := fileVersionAtLeast(pass, s.lit, "go1.22")
+		for v, use := range captured(pass, s.lit) {
+			for _, w := range writes[v] {
+				if w.loopVar && perIteration {
+					continue
+				}
+				if w.loopVar || mutatedAfter(v, s, w, joins) {
+					pass.Reportf(use.Pos(), "goroutine captures %s, which is reassigned after the goroutine starts; pass it as an argument or copy it", v.Name())
+					break
+				}
+			}
+		}
+	}
+}
+
+// mutatedAfter reports whether w can run after the goroutine s was started
+// and before it is joined: either it follows s in the source, or both sit
+// in a loop that is entered again after s while v, declared outside that
+// loop, keeps its identity. Either way, a join that every path from s to w
+// goes through orders w after the goroutine.
+func mutatedAfter(v *types.Var, s spawn, w write, joins []join) bool {
+	if w.pos > s.lit.End() {
+		return !joined(s, joins, func(j join) bool { return j.pos < w.pos })
+	}
+	for _, loop := range s.loops {
+		if contains(w.loops, loop) && !within(v.Pos(), loop) &&
+			!joined(s, joins, func(j join) bool { return within(j.pos, loop) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// joined reports whether one of the joins accepted by ok always runs after
+// s: it follows s in a block that contains s, so control reaches it from s
+// unless the function returns or the loop is left first.
+func joined(s spawn, joins []join, ok func(join) bool) bool {
+	for _, j := range joins {
+		if j.pos > s.lit.End() && within(s.lit.Pos(), j.block) && ok(j) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWait reports whether stmt is a call to a method named Wait, such as
+// wg.Wait() or err := g.Wait().
+func isWait(stmt ast.Stmt) bool {
+	var call ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) != 1 {
+			return false
+		}
+		call = stmt.Rhs[0]
+	default:
+		return false
+	}
+	c, ok := ast.Unparen(call).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := ast.Unparen(c.Fun).(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Wait"
+}
+
+// captured returns the local variables referenced by lit but declared
+// outside it, keyed to their first use.
+func captured(pass *analysis.Pass, lit *ast.FuncLit) map[*types.Var]*ast.Ident {
+	vars := make(map[*types.Var]*ast.Ident)
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+		if !ok || v.IsField() || v.Parent() == nil || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+		if within(v.Pos(), lit) {
+			return true
+		}
+		if _, seen := vars[v]; !seen {
+			vars[v] = id
+		}
+		return true
+	})
+	return vars
+}
+
+// assigned returns the local variables stmt assigns to.
+func assigned(pass *analysis.Pass, stmt ast.Stmt) []*types.Var {
+	var lhs []ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok == token.DEFINE {
+			// := may redeclare, but a redeclared variable is still assigned.
+			for _, e := range stmt.Lhs {
+				if id, ok := e.(*ast.Ident); ok && pass.TypesInfo.Defs[id] == nil {
+					lhs = append(lhs, e)
+				}
+			}
+		} else {
+			lhs = stmt.Lhs
+		}
+	case *ast.IncDecStmt:
+		lhs = []ast.Expr{stmt.X}
+	case *ast.RangeStmt:
+		lhs = []ast.Expr{stmt.Key, stmt.Value}
+	}
+
+	var vars []*types.Var
+	for _, e := range lhs {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+			vars = append(vars, v)
+		}
+	}
+	return vars
+}
+
+// declaredIn reports whether v is declared by the for loop's init statement.
+func declaredIn(v *types.Var, init ast.Stmt) bool {
+	return init != nil && within(v.Pos(), init)
+}
+
+func fileVersionAtLeast(pass *analysis.Pass, n ast.Node, min string) bool {
+	for _, f := range pass.Files {
+		if within(n.Pos(), f) {
+			v := pass.TypesInfo.FileVersions[f]
+			return v == "" || version.Compare(v, min) >= 0
+		}
+	}
+	return true
+}
+
+func within(pos token.Pos, n ast.Node) bool {
+	return n.Pos() <= pos && pos < n.End()
+}
+
+func contains(stmts []ast.Stmt, s ast.Stmt) bool {
+	for _, x := range stmts {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func clone(stmts []ast.Stmt) []ast.Stmt {
+	return append([]ast.Stmt(nil), stmts...)
+}
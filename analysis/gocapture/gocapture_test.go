@@ -0,0 +1,13 @@
+package gocapture_test
+
+import (
+	"testing"
+
+	"go-concurrency/analysis/gocapture"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), gocapture.Analyzer, "a", "old")
+}
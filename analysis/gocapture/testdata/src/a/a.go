@@ -0,0 +1,143 @@
+package a
+
+import (
+	"fmt"
+	"sync"
+)
+
+type group struct{}
+
+func (group) Go(fn func()) {}
+
+func sharedVariable() {
+	var wg sync.WaitGroup
+	current := 0
+	for i := 1; i <= 3; i++ {
+		current = i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Println(current) // want `goroutine captures current, which is reassigned after the goroutine starts`
+		}()
+	}
+	wg.Wait()
+}
+
+func mutatedAfterStart() {
+	done := make(chan struct{})
+	status := "starting"
+	go func() {
+		fmt.Println(status) // want `goroutine captures status, which is reassigned after the goroutine starts`
+		close(done)
+	}()
+	status = "running"
+	<-done
+}
+
+func incrementedAfterStart() {
+	var g group
+	n := 0
+	g.Go(func() {
+		fmt.Println(n) // want `goroutine captures n, which is reassigned after the goroutine starts`
+	})
+	n++
+}
+
+func loopVariable() {
+	// Each iteration has its own i since Go 1.22.
+	for i := 1; i <= 3; i++ {
+		go func() {
+			fmt.Println(i)
+		}()
+	}
+	for _, msg := range []string{"a", "b"} {
+		go func() {
+			fmt.Println(msg)
+		}()
+	}
+}
+
+func passedAsArgument() {
+	for i := 1; i <= 3; i++ {
+		go func(id int) {
+			fmt.Println(id)
+		}(i)
+	}
+}
+
+func copiedPerIteration() {
+	current := 0
+	for i := 1; i <= 3; i++ {
+		current = i
+		value := current
+		go func() {
+			fmt.Println(value)
+		}()
+	}
+}
+
+func mutatedBeforeStart() {
+	msg := "hello"
+	msg += ", world"
+	go func() {
+		fmt.Println(msg)
+	}()
+}
+
+func mutatedAfterWait() {
+	var wg sync.WaitGroup
+	var got []int
+	var mu sync.Mutex
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	got = append(got, 0)
+	fmt.Println(got)
+}
+
+func waitedInEachIteration() {
+	var wg sync.WaitGroup
+	current := 0
+	for i := 1; i <= 3; i++ {
+		current = i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fmt.Println(current)
+		}()
+		wg.Wait()
+	}
+}
+
+func mutatedBeforeWait() {
+	var wg sync.WaitGroup
+	status := "starting"
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fmt.Println(status) // want `goroutine captures status, which is reassigned after the goroutine starts`
+	}()
+	status = "running"
+	wg.Wait()
+}
+
+func waitedConditionally(wait bool) {
+	var wg sync.WaitGroup
+	status := "starting"
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fmt.Println(status) // want `goroutine captures status, which is reassigned after the goroutine starts`
+	}()
+	if wait {
+		wg.Wait()
+	}
+	status = "done"
+}
@@ -0,0 +1,19 @@
+//go:build go1.21
+
+// Before Go 1.22 loop variables were shared by every iteration.
+package old
+
+import "fmt"
+
+func loopVariable() {
+	for i := 1; i <= 3; i++ {
+		go func() {
+			fmt.Println(i) // want `goroutine captures i, which is reassigned after the goroutine starts`
+		}()
+	}
+	for _, msg := range []string{"a", "b"} {
+		go func() {
+			fmt.Println(msg) // want `goroutine captures msg, which is reassigned after the goroutine starts`
+		}()
+	}
+}
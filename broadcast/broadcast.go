@@ -0,0 +1,280 @@
+// Package broadcast delivers every published value to every subscriber,
+// which a single Go channel cannot do: a value received from a channel is
+// gone for everyone else.
+//
+// Each Subscription owns a buffered channel and a Policy that decides what
+// happens when its reader falls behind. Subscribers may join and leave at
+// any time, including while a Publish is in progress; a publisher blocked
+// on a subscriber that leaves is released at once.
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go-concurrency/internal/closeguard"
+)
+
+var (
+	// ErrClosed is returned by Publish and Subscribe once the Broadcaster is
+	// closed, and by Subscription.Err for subscriptions it closed.
+	ErrClosed = errors.New("broadcast: closed")
+
+	// ErrSlowSubscriber is returned by Subscription.Err for a Disconnect
+	// subscription that was dropped because its buffer was full.
+	ErrSlowSubscriber = errors.New("broadcast: subscriber too slow, disconnected")
+)
+
+// Policy decides what Publish does when a subscriber's buffer is full.
+type Policy int
+
+const (
+	// Block makes Publish wait until the subscriber has room, it leaves, or
+	// the publisher's context is done.
+	Block Policy = iota
+	// DropNewest discards the value being published for this subscriber.
+	DropNewest
+	// DropOldest discards the oldest buffered value to make room.
+	DropOldest
+	// Disconnect unsubscribes the subscriber, closing its channel.
+	Disconnect
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	case Disconnect:
+		return "disconnect"
+	}
+	return "unknown"
+}
+
+// Broadcaster publishes values of T to its subscribers. Create one with New.
+type Broadcaster[T any] struct {
+	replay bool
+
+	mu     sync.Mutex
+	closed bool
+	subs   map[*Subscription[T]]struct{}
+	last   T
+	has    bool
+}
+
+// Option configures a Broadcaster.
+type Option func(*options)
+
+type options struct {
+	replay bool
+}
+
+// WithReplay makes new subscribers receive the most recently published
+// value first, if there is one and their buffer can hold it.
+func WithReplay() Option {
+	return func(o *options) { o.replay = true }
+}
+
+// New returns a Broadcaster with no subscribers.
+func New[T any](opts ...Option) *Broadcaster[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Broadcaster[T]{replay: o.replay, subs: make(map[*Subscription[T]]struct{})}
+}
+
+// Subscribe adds a subscriber whose channel buffers up to buffer values and
+// applies policy when it is full. With an unbuffered channel every Publish
+// counts as full unless the reader is already waiting.
+func (b *Broadcaster[T]) Subscribe(buffer int, policy Policy) (*Subscription[T], error) {
+	s := &Subscription[T]{
+		b:      b,
+		policy: policy,
+		ch:     make(chan T, max(buffer, 0)),
+		guard:  closeguard.New(),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	// Replaying under b.mu orders the replayed value before anything
+	// published after this subscriber joined
+	if b.replay && b.has && cap(s.ch) > 0 {
+		s.ch <- b.last
+	}
+	b.subs[s] = struct{}{}
+	return s, nil
+}
+
+// Publish sends v to every current subscriber, applying each one's policy.
+// Values from a single publisher reach each subscriber in order. Publish
+// returns ErrClosed once the Broadcaster is closed.
+//
+// If ctx is done while a Block subscriber is full, Publish skips that
+// subscriber, counting v as dropped for it, and goes on with the others:
+// every subscriber that has room still gets v, and Publish returns
+// ctx.Err() once it has tried them all.
+func (b *Broadcaster[T]) Publish(ctx context.Context, v T) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrClosed
+	}
+	b.last, b.has = v, true
+	subs := make([]*Subscription[T], 0, len(b.subs))
+	for s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.Unlock()
+
+	// Deliver outside b.mu so subscribers can join and leave meanwhile
+	var err error
+	for _, s := range subs {
+		if e := s.deliver(ctx, v); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// Subscribers returns the number of current subscribers.
+func (b *Broadcaster[T]) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subs)
+}
+
+// Close closes every subscriber's channel. Later calls to Publish and
+// Subscribe return ErrClosed.
+func (b *Broadcaster[T]) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	subs := b.subs
+	b.subs = nil
+	b.mu.Unlock()
+
+	for s := range subs {
+		s.close(ErrClosed)
+	}
+}
+
+// Subscription is one subscriber of a Broadcaster.
+type Subscription[T any] struct {
+	b      *Broadcaster[T]
+	policy Policy
+	ch     chan T
+
+	guard   *closeguard.Guard
+	err     error // set by the close
+	dropped atomic.Int64
+}
+
+// C returns the channel values are delivered on. It is closed when the
+// subscription ends.
+func (s *Subscription[T]) C() <-chan T { return s.ch }
+
+// Unsubscribe ends the subscription and closes its channel. Values still
+// buffered can be received until the channel is drained.
+func (s *Subscription[T]) Unsubscribe() {
+	s.b.mu.Lock()
+	delete(s.b.subs, s)
+	s.b.mu.Unlock()
+	s.close(nil)
+}
+
+// Err reports why the subscription ended: nil while it is active or after
+// Unsubscribe, ErrSlowSubscriber if it was disconnected, and ErrClosed if
+// the Broadcaster was closed.
+func (s *Subscription[T]) Err() error {
+	if !s.guard.Closed() {
+		return nil
+	}
+	return s.err
+}
+
+// Dropped returns how many values the policy has discarded for this
+// subscriber, including those a Block subscriber missed because the
+// publisher's context was done.
+func (s *Subscription[T]) Dropped() int64 { return s.dropped.Load() }
+
+// deliver sends v according to the subscriber's policy.
+func (s *Subscription[T]) deliver(ctx context.Context, v T) error {
+	if !s.guard.Enter() {
+		return nil
+	}
+
+	full := false
+	switch s.policy {
+	case Block:
+		// Try without waiting first: once ctx is done, select would pick
+		// at random between it and a send that is ready
+		select {
+		case s.ch <- v:
+		default:
+			select {
+			case s.ch <- v:
+			case <-s.guard.Done():
+			case <-ctx.Done():
+				s.dropped.Add(1)
+				s.guard.Exit()
+				return ctx.Err()
+			}
+		}
+	case DropOldest:
+	send:
+		for {
+			select {
+			case s.ch <- v:
+				break send
+			default:
+			}
+			if cap(s.ch) == 0 {
+				// Nothing buffered to drop; v is the oldest there is
+				s.dropped.Add(1)
+				break send
+			}
+			// Make room; the reader may have beaten us to it
+			select {
+			case <-s.ch:
+				s.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		select {
+		case s.ch <- v:
+		default:
+			s.dropped.Add(1)
+			full = true
+		}
+	}
+	s.guard.Exit()
+
+	if full && s.policy == Disconnect {
+		s.b.mu.Lock()
+		delete(s.b.subs, s)
+		s.b.mu.Unlock()
+		s.close(ErrSlowSubscriber)
+	}
+	return nil
+}
+
+// close ends the subscription with err, once.
+func (s *Subscription[T]) close(err error) {
+	s.guard.Close(func() {
+		s.err = err
+		close(s.ch)
+	})
+}
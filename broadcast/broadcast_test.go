@@ -0,0 +1,188 @@
+package broadcast_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go-concurrency/broadcast"
+	"go-concurrency/leakcheck/leaktest"
+)
+
+// received returns the values buffered on ch without waiting.
+func received[T any](ch <-chan T) []T {
+	var vs []T
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return vs
+			}
+			vs = append(vs, v)
+		default:
+			return vs
+		}
+	}
+}
+
+func subscribe(t *testing.T, b *broadcast.Broadcaster[int], buffer int, policy broadcast.Policy) *broadcast.Subscription[int] {
+	t.Helper()
+	s, err := b.Subscribe(buffer, policy)
+	if err != nil {
+		t.Fatalf("Subscribe() = %v", err)
+	}
+	return s
+}
+
+func TestPolicies(t *testing.T) {
+	b := broadcast.New[int]()
+	ctx := context.Background()
+	newest := subscribe(t, b, 2, broadcast.DropNewest)
+	oldest := subscribe(t, b, 2, broadcast.DropOldest)
+	slow := subscribe(t, b, 2, broadcast.Disconnect)
+	for v := range 4 {
+		if err := b.Publish(ctx, v); err != nil {
+			t.Fatalf("Publish(%d) = %v", v, err)
+		}
+	}
+
+	if got := received(newest.C()); len(got) != 2 || got[0] != 0 || got[1] != 1 {
+		t.Errorf("drop-newest received %v, want [0 1]", got)
+	}
+	if got := received(oldest.C()); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("drop-oldest received %v, want [2 3]", got)
+	}
+	if newest.Dropped() != 2 || oldest.Dropped() != 2 {
+		t.Errorf("Dropped() = %d and %d, want 2 and 2", newest.Dropped(), oldest.Dropped())
+	}
+	if got := received(slow.C()); len(got) != 2 || !errors.Is(slow.Err(), broadcast.ErrSlowSubscriber) {
+		t.Errorf("disconnect received %v and ended with %v, want 2 values and %v", got, slow.Err(), broadcast.ErrSlowSubscriber)
+	}
+	if n := b.Subscribers(); n != 2 {
+		t.Errorf("Subscribers() = %d after a disconnect, want 2", n)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	b := broadcast.New[int](broadcast.WithReplay())
+	if err := b.Publish(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	s := subscribe(t, b, 1, broadcast.Block)
+	if got := received(s.C()); len(got) != 1 || got[0] != 7 {
+		t.Errorf("late subscriber received %v, want the replayed [7]", got)
+	}
+}
+
+// A full Block subscriber whose publisher gives up misses the value, but
+// the subscribers after it still get it.
+func TestPublishSkipsFullBlockSubscriberOnCancel(t *testing.T) {
+	b := broadcast.New[int]()
+	var subs []*broadcast.Subscription[int]
+	for range 5 {
+		subs = append(subs, subscribe(t, b, 1, broadcast.Block))
+	}
+	full := subs[2]
+	if err := b.Publish(context.Background(), 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range subs {
+		if s != full {
+			<-s.C() // every buffer but full's has room again
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Publish(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Publish() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	for i, s := range subs {
+		got := received(s.C())
+		switch {
+		case s == full && (len(got) != 1 || got[0] != 0 || s.Dropped() != 1):
+			t.Errorf("full subscriber received %v, dropped %d; want [0], 1", got, s.Dropped())
+		case s != full && (len(got) != 1 || got[0] != 1):
+			t.Errorf("subscriber %d received %v, want [1]", i, got)
+		}
+	}
+}
+
+// Subscribers join and leave while values are published: every subscriber
+// sees the values published while it was subscribed in order, nothing is
+// sent on a closed channel, and a publisher blocked on a subscriber that
+// leaves is released.
+func TestChurn(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		b := broadcast.New[int]()
+		ctx := context.Background()
+		const publishes = 2000
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		for r := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; ; i++ {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					s, err := b.Subscribe(r%3, broadcast.Policy(r%4))
+					if err != nil {
+						return // closed
+					}
+					last := -1
+					for range i%5 + 1 {
+						v, ok := <-s.C()
+						if !ok {
+							break
+						}
+						if v <= last {
+							t.Errorf("received %d after %d", v, last)
+						}
+						last = v
+					}
+					// Leave with values possibly still being published
+					s.Unsubscribe()
+					for range s.C() {
+					}
+				}
+			}()
+		}
+
+		for v := range publishes {
+			if err := b.Publish(ctx, v); err != nil {
+				t.Errorf("Publish(%d) = %v", v, err)
+			}
+		}
+		close(stop)
+		b.Close()
+		wg.Wait()
+		if err := b.Publish(ctx, 0); !errors.Is(err, broadcast.ErrClosed) {
+			t.Errorf("Publish() after Close = %v, want %v", err, broadcast.ErrClosed)
+		}
+	})
+}
+
+func TestUnsubscribeReleasesBlockedPublisher(t *testing.T) {
+	b := broadcast.New[int]()
+	s := subscribe(t, b, 0, broadcast.Block)
+	published := make(chan error)
+	go func() { published <- b.Publish(context.Background(), 1) }()
+
+	time.Sleep(10 * time.Millisecond)
+	s.Unsubscribe()
+	select {
+	case err := <-published:
+		if err != nil {
+			t.Errorf("Publish() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publish still blocked after the subscriber left")
+	}
+}
@@ -0,0 +1,197 @@
+// Package catalog lists the learning modules and their numbered sections so
+// tools such as cmd/gocon can discover and run them.
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	basicgoroutine "go-concurrency/1-basic-goroutine"
+	performance "go-concurrency/10-performance-optimization"
+	testingconcurrency "go-concurrency/11-testing-concurrency"
+	lockfree "go-concurrency/12-lock-free-programming"
+	complexpatterns "go-concurrency/13-complex-patterns"
+	systemdesign "go-concurrency/14-system-design"
+	advancedtopics "go-concurrency/15-advanced-topics"
+	channels "go-concurrency/2-channels"
+	syncbasics "go-concurrency/3-sync"
+	contexts "go-concurrency/4-context"
+	channelpatterns "go-concurrency/5-channel-patterns"
+	errorhandling "go-concurrency/6-error-handling"
+	syncadvanced "go-concurrency/7-sync-advanced"
+	workerpools "go-concurrency/8-worker-pools"
+	pipelines "go-concurrency/9-pipeline-patterns"
+	"go-concurrency/chanmetrics"
+	channelpatternsref "go-concurrency/channel-patterns"
+	"go-concurrency/chantrace"
+	"go-concurrency/clock"
+	"go-concurrency/lifecycle"
+)
+
+// Section is one numbered example of a module, titled like the heading it
+// prints, e.g. "1. Basic Channel Example".
+type Section struct {
+	Number int
+	Title  string
+	Run    func(w io.Writer)
+}
+
+func (s Section) String() string {
+	return fmt.Sprintf("%d. %s", s.Number, s.Title)
+}
+
+// Module is a learning module.
+type Module struct {
+	// Number orders the module in the learning path; it is zero for
+	// modules outside the numbered path.
+	Number int
+	// Dir is the module's directory, e.g. "2-channels".
+	Dir string
+	// Title is the module's heading.
+	Title string
+	// Run prints the module overview and runs every section.
+	Run func(w io.Writer)
+	// Sections are the module's runnable examples. Modules that only list
+	// exercises have none.
+	Sections []Section
+}
+
+// Section returns the section numbered n.
+func (m Module) Section(n int) (Section, bool) {
+	for _, s := range m.Sections {
+		if s.Number == n {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// Modules lists every module in learning-path order.
+var Modules = []Module{
+	{
+		Number: 1,
+		Dir:    "1-basic-goroutine",
+		Title:  "Basic Goroutines",
+		Run:    func(w io.Writer) { basicgoroutine.Run(w, clock.Real(), lifecycle.WithText(w)) },
+		Sections: []Section{
+			{1, "Basic Goroutine Creation", demo(basicgoroutine.BasicGoroutineCreation)},
+			{2, "Goroutine with Parameters", demo(basicgoroutine.GoroutineWithParameters)},
+			{3, "Multiple Goroutines", demo(basicgoroutine.MultipleGoroutines)},
+			{4, "Goroutine Communication", demo(basicgoroutine.GoroutineCommunication)},
+			{5, "Goroutine Error Handling", demo(basicgoroutine.GoroutineErrorHandling)},
+			{6, "Goroutine Best Practices", demo(basicgoroutine.GoroutineBestPractices)},
+		},
+	},
+	{
+		Number: 2,
+		Dir:    "2-channels",
+		Title:  "Channel Fundamentals",
+		Run:    wallClock(channels.Run),
+		Sections: []Section{
+			{1, "Basic Channel Example", untraced(channels.BasicChannel)},
+			{2, "Buffered Channel Example", untraced(channels.BufferedChannel)},
+			{3, "Select Statement Example", wallClock(untracedOnClock(channels.SelectStatement))},
+			{4, "Channel Toolkit Example", untraced(channels.ChannelToolkit)},
+			{5, "Broadcast Example", untraced(channels.Broadcast)},
+			{6, "Closing Reasons Example", untraced(channels.ClosingReasons)},
+			{7, "Priority Select Example", untraced(channels.PrioritySelect)},
+			{8, "Request/Reply Example", wallClock(untracedOnClock(channels.RequestReply))},
+		},
+	},
+	{
+		Number: 3,
+		Dir:    "3-sync",
+		Title:  "Synchronization Primitives",
+		Run:    wallClock(syncbasics.Run),
+		Sections: []Section{
+			{1, "Mutex Example", syncbasics.MutexCounter},
+			{2, "RWMutex Example", wallClock(syncbasics.RWMutexReaders)},
+			{3, "sync.Once Example", wallClock(syncbasics.OnceInit)},
+			{4, "Lock Ordering Example", syncbasics.LockOrdering},
+			{5, "Lock Contention Example", syncbasics.LockContention},
+			{6, "Retryable Once Example", syncbasics.RetryableOnce},
+			{7, "RWLock Policies Example", syncbasics.RWLockPolicies},
+			{8, "Dynamic WaitGroup Example", syncbasics.DynamicWaitGroup},
+		},
+	},
+	{
+		Number: 4,
+		Dir:    "4-context",
+		Title:  "Context Package",
+		Run:    wallClock(contexts.Run),
+		Sections: []Section{
+			{1, "Context with Timeout", wallClock(contexts.WithTimeout)},
+			{2, "Context with Cancellation", wallClock(contexts.WithCancellation)},
+			{3, "Context with Deadline", wallClock(contexts.WithDeadline)},
+		},
+	},
+	{Number: 5, Dir: "5-channel-patterns", Title: "Channel Patterns", Run: channelpatterns.Run},
+	{Number: 6, Dir: "6-error-handling", Title: "Error Handling in Concurrent Code", Run: errorhandling.Run},
+	{Number: 7, Dir: "7-sync-advanced", Title: "Advanced Synchronization Primitives", Run: syncadvanced.Run},
+	{
+		Number: 8,
+		Dir:    "8-worker-pools",
+		Title:  "Worker Pool Patterns",
+		Run:    wallClock(workerpools.Run),
+		Sections: []Section{
+			{1, "Basic Worker Pool", wallClock(workerpools.BasicWorkerPool)},
+			{2, "Worker Pool with Rate Limiting", wallClock(workerpools.RateLimitedWorkerPool)},
+			{3, "Instrumented Worker Pool", wallClock(unserved(workerpools.InstrumentedWorkerPool))},
+		},
+	},
+	{Number: 9, Dir: "9-pipeline-patterns", Title: "Pipeline Patterns", Run: pipelines.Run},
+	{Number: 10, Dir: "10-performance-optimization", Title: "Performance Optimization", Run: performance.Run},
+	{Number: 11, Dir: "11-testing-concurrency", Title: "Testing Concurrent Code", Run: testingconcurrency.Run},
+	{Number: 12, Dir: "12-lock-free-programming", Title: "Lock-Free Programming", Run: lockfree.Run},
+	{Number: 13, Dir: "13-complex-patterns", Title: "Complex Concurrency Patterns", Run: complexpatterns.Run},
+	{Number: 14, Dir: "14-system-design", Title: "System Design with Concurrency", Run: systemdesign.Run},
+	{Number: 15, Dir: "15-advanced-topics", Title: "Advanced Topics", Run: advancedtopics.Run},
+
+	// The original channel patterns exercise list, kept outside the numbered path.
+	{Dir: "channel-patterns", Title: "Channel Patterns Examples", Run: channelpatternsref.Run},
+}
+
+// Find returns the module named by its number ("2") or directory ("2-channels").
+func Find(name string) (Module, bool) {
+	name = strings.TrimSuffix(name, "/")
+	n, err := strconv.Atoi(name)
+	for _, m := range Modules {
+		if m.Dir == name || (err == nil && n > 0 && m.Number == n) {
+			return m, true
+		}
+	}
+	return Module{}, false
+}
+
+// demo adapts a basicgoroutine.Demo to a Section, logging its lifecycle
+// events as text to the same writer.
+func demo(d basicgoroutine.Demo) func(w io.Writer) {
+	return func(w io.Writer) {
+		d(w, lifecycle.New(lifecycle.WithText(w), lifecycle.WithMemory()), clock.Real())
+	}
+}
+
+// untraced adapts a 2-channels example that records its channels on a
+// chantrace.Recorder to a Section that records nothing.
+func untraced(fn func(io.Writer, *chantrace.Recorder)) func(w io.Writer) {
+	return func(w io.Writer) { fn(w, nil) }
+}
+
+// untracedOnClock is untraced for an example that also takes a clock.
+func untracedOnClock(fn func(io.Writer, clock.Clock, *chantrace.Recorder)) func(io.Writer, clock.Clock) {
+	return func(w io.Writer, clk clock.Clock) { fn(w, clk, nil) }
+}
+
+// unserved adapts an 8-worker-pools example that registers its channels on
+// a chanmetrics.Registry to one whose channels are not served.
+func unserved(fn func(io.Writer, clock.Clock, *chanmetrics.Registry)) func(io.Writer, clock.Clock) {
+	return func(w io.Writer, clk clock.Clock) { fn(w, clk, nil) }
+}
+
+// wallClock adapts an example that measures time on a clock.Clock to a Section
+// running on the wall clock.
+func wallClock(fn func(io.Writer, clock.Clock)) func(w io.Writer) {
+	return func(w io.Writer) { fn(w, clock.Real()) }
+}
@@ -0,0 +1,237 @@
+// Package chanmetrics provides a channel that measures itself: how full it
+// is, how long senders and receivers wait, how many values pass through
+// per second and when it was closed.
+//
+// A Chan is used through Send and Recv, which behave like channel
+// operations with a context. Snapshot returns the measurements so far, and
+// a Registry serves the snapshots of many channels in the Prometheus text
+// format:
+//
+//	reg := chanmetrics.NewRegistry()
+//	jobs := chanmetrics.New[int]("jobs", 10, chanmetrics.WithRegistry(reg))
+//	http.Handle("/metrics", reg)
+package chanmetrics
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/internal/closeguard"
+	"go-concurrency/internal/metric"
+)
+
+// ErrClosed is returned by Send once the channel is closed, and by Recv
+// once it is closed and drained.
+var ErrClosed = errors.New("chanmetrics: channel closed")
+
+// Chan is an instrumented channel of T values. Create one with New.
+type Chan[T any] struct {
+	name    string
+	ch      chan T
+	clk     clock.Clock
+	created time.Time
+
+	guard    *closeguard.Guard
+	closedAt time.Time // set by the close
+
+	sent     atomic.Int64
+	received atomic.Int64
+	maxLen   atomic.Int64
+	lenSum   atomic.Int64
+	samples  atomic.Int64
+	sendWait metric.Histogram
+	recvWait metric.Histogram
+}
+
+// Option configures a Chan.
+type Option func(*options)
+
+type options struct {
+	clk clock.Clock
+	reg *Registry
+}
+
+// WithClock measures waits and rates on clk instead of the wall clock.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) { o.clk = clk }
+}
+
+// WithRegistry registers the channel with reg under its name, replacing
+// any channel registered under the same name.
+func WithRegistry(reg *Registry) Option {
+	return func(o *options) { o.reg = reg }
+}
+
+// New returns an open channel that buffers up to capacity values. The name
+// identifies it in snapshots and metrics.
+func New[T any](name string, capacity int, opts ...Option) *Chan[T] {
+	o := options{clk: clock.Real()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &Chan[T]{
+		name:    name,
+		ch:      make(chan T, capacity),
+		clk:     o.clk,
+		created: o.clk.Now(),
+		guard:   closeguard.New(),
+	}
+	if o.reg != nil {
+		o.reg.Register(c)
+	}
+	return c
+}
+
+// Name returns the channel's name.
+func (c *Chan[T]) Name() string { return c.name }
+
+// Send sends v, waiting for room until ctx is done. It returns ErrClosed
+// if the channel is or becomes closed first.
+func (c *Chan[T]) Send(ctx context.Context, v T) error {
+	if !c.guard.Enter() {
+		return ErrClosed
+	}
+	defer c.guard.Exit()
+
+	// Only time sends that actually have to wait
+	select {
+	case c.ch <- v:
+		c.sendWait.Observe(0, false)
+	default:
+		start := c.clk.Now()
+		select {
+		case c.ch <- v:
+			c.sendWait.Observe(c.clk.Since(start), true)
+		case <-c.guard.Done():
+			return ErrClosed
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c.sent.Add(1)
+	c.sample()
+	return nil
+}
+
+// Recv receives a value, waiting until ctx is done. It returns ErrClosed
+// once the channel is closed and every value has been received.
+func (c *Chan[T]) Recv(ctx context.Context) (T, error) {
+	var (
+		v  T
+		ok bool
+	)
+	select {
+	case v, ok = <-c.ch:
+		if ok {
+			c.recvWait.Observe(0, false)
+		}
+	default:
+		start := c.clk.Now()
+		select {
+		case v, ok = <-c.ch:
+			if ok {
+				c.recvWait.Observe(c.clk.Since(start), true)
+			}
+		case <-ctx.Done():
+			return v, ctx.Err()
+		}
+	}
+	if !ok {
+		return v, ErrClosed
+	}
+	c.received.Add(1)
+	c.sample()
+	return v, nil
+}
+
+// Close closes the channel. Blocked senders return ErrClosed; receivers
+// drain what is buffered. Closing more than once has no further effect.
+func (c *Chan[T]) Close() {
+	c.guard.Close(func() {
+		c.closedAt = c.clk.Now()
+		close(c.ch)
+	})
+}
+
+// Len returns the number of values buffered.
+func (c *Chan[T]) Len() int { return len(c.ch) }
+
+// Cap returns the channel's capacity.
+func (c *Chan[T]) Cap() int { return cap(c.ch) }
+
+// sample records the current occupancy.
+func (c *Chan[T]) sample() {
+	n := int64(len(c.ch))
+	for {
+		cur := c.maxLen.Load()
+		if n <= cur || c.maxLen.CompareAndSwap(cur, n) {
+			break
+		}
+	}
+	c.lenSum.Add(n)
+	c.samples.Add(1)
+}
+
+// Snapshot is a point-in-time view of a Chan's measurements.
+type Snapshot struct {
+	Name     string
+	Len      int     // values buffered now
+	Cap      int     // capacity
+	MaxLen   int     // most values seen buffered after a send or receive
+	MeanLen  float64 // average values buffered after a send or receive
+	Sent     int64   // values sent
+	Received int64   // values received
+	SendRate float64 // values sent per second since creation, until closed
+	RecvRate float64 // values received per second since creation, until closed
+	SendWait Wait    // time senders spent waiting for room
+	RecvWait Wait    // time receivers spent waiting for a value
+	Created  time.Time
+	Closed   bool
+	ClosedAt time.Time // zero while open
+}
+
+// Wait summarizes the waits of one side of a channel. Operations that did
+// not have to wait count as zero waits; abandoned ones are not counted.
+// Blocked counts the operations that had to wait.
+type Wait = metric.Summary
+
+// Bucket counts the waits no longer than UpperBound.
+type Bucket = metric.Bucket
+
+// Snapshot returns the channel's measurements so far.
+func (c *Chan[T]) Snapshot() Snapshot {
+	closed := c.guard.Closed()
+	var closedAt time.Time
+	if closed {
+		closedAt = c.closedAt
+	}
+
+	s := Snapshot{
+		Name:     c.name,
+		Len:      len(c.ch),
+		Cap:      cap(c.ch),
+		MaxLen:   int(c.maxLen.Load()),
+		Sent:     c.sent.Load(),
+		Received: c.received.Load(),
+		SendWait: c.sendWait.Summary(),
+		RecvWait: c.recvWait.Summary(),
+		Created:  c.created,
+		Closed:   closed,
+		ClosedAt: closedAt,
+	}
+	if n := c.samples.Load(); n > 0 {
+		s.MeanLen = float64(c.lenSum.Load()) / float64(n)
+	}
+	end := c.clk.Now()
+	if closed {
+		end = closedAt
+	}
+	if elapsed := end.Sub(c.created).Seconds(); elapsed > 0 {
+		s.SendRate = float64(s.Sent) / elapsed
+		s.RecvRate = float64(s.Received) / elapsed
+	}
+	return s
+}
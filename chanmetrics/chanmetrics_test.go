@@ -0,0 +1,129 @@
+package chanmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-concurrency/chanmetrics"
+	"go-concurrency/clock"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// blockedFor starts op in a goroutine, lets it block, moves the fake clock
+// on by d and returns a channel closed once op has returned.
+func blockedFor(fake *clock.Fake, d time.Duration, op func()) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		op()
+	}()
+	time.Sleep(10 * time.Millisecond) // let op start waiting
+	fake.Advance(d)
+	return done
+}
+
+// Only operations that have to wait are timed; the others count as zero
+// waits.
+func TestWaits(t *testing.T) {
+	fake := clock.NewFake(start)
+	c := chanmetrics.New[int]("jobs", 1, chanmetrics.WithClock(fake))
+	ctx := context.Background()
+
+	c.Send(ctx, 1)
+	sent := blockedFor(fake, 50*time.Millisecond, func() { c.Send(ctx, 2) })
+	c.Recv(ctx) // makes room for 2
+	<-sent
+	c.Recv(ctx)
+	var got int
+	received := blockedFor(fake, 20*time.Millisecond, func() { got, _ = c.Recv(ctx) })
+	c.Send(ctx, 3)
+	<-received
+	if got != 3 {
+		t.Fatalf("blocked Recv() = %d, want 3", got)
+	}
+
+	s := c.Snapshot()
+	for _, tt := range []struct {
+		side string
+		w    chanmetrics.Wait
+		max  time.Duration
+	}{{"send", s.SendWait, 50 * time.Millisecond}, {"receive", s.RecvWait, 20 * time.Millisecond}} {
+		if tt.w.Count != 3 || tt.w.Blocked != 1 || tt.w.Max != tt.max || tt.w.Total != tt.max {
+			t.Errorf("%s wait: count %d, blocked %d, max %v, total %v; want 3, 1, %v, %v",
+				tt.side, tt.w.Count, tt.w.Blocked, tt.w.Max, tt.w.Total, tt.max, tt.max)
+		}
+		for _, b := range tt.w.Buckets {
+			want := int64(3)
+			if b.UpperBound < tt.max {
+				want = 2 // the two that did not wait
+			}
+			if b.Count != want {
+				t.Errorf("%s wait: %d in the bucket up to %v, want %d", tt.side, b.Count, b.UpperBound, want)
+			}
+		}
+	}
+}
+
+func TestLengthsRatesAndClose(t *testing.T) {
+	fake := clock.NewFake(start)
+	c := chanmetrics.New[string]("events", 4, chanmetrics.WithClock(fake))
+	ctx := context.Background()
+	for _, v := range []string{"a", "b", "c", "d"} {
+		c.Send(ctx, v)
+	}
+	c.Recv(ctx)
+	c.Recv(ctx)
+	fake.Advance(2 * time.Second)
+
+	s := c.Snapshot()
+	// Lengths after each operation: 1, 2, 3, 4, 3, 2
+	if s.Len != 2 || s.Cap != 4 || s.MaxLen != 4 || s.MeanLen != 2.5 {
+		t.Errorf("Len %d, Cap %d, MaxLen %d, MeanLen %v; want 2, 4, 4, 2.5", s.Len, s.Cap, s.MaxLen, s.MeanLen)
+	}
+	if s.Sent != 4 || s.Received != 2 || s.SendRate != 2 || s.RecvRate != 1 {
+		t.Errorf("sent %d at %v/s, received %d at %v/s; want 4 at 2/s, 2 at 1/s", s.Sent, s.SendRate, s.Received, s.RecvRate)
+	}
+	if s.Closed || !s.ClosedAt.IsZero() || !s.Created.Equal(start) {
+		t.Errorf("open channel: Closed %v, ClosedAt %v, Created %v", s.Closed, s.ClosedAt, s.Created)
+	}
+
+	c.Close()
+	fake.Advance(2 * time.Second)
+	s = c.Snapshot()
+	if !s.Closed || !s.ClosedAt.Equal(start.Add(2*time.Second)) {
+		t.Errorf("closed channel: Closed %v, ClosedAt %v; want true, %v", s.Closed, s.ClosedAt, start.Add(2*time.Second))
+	}
+	if s.SendRate != 2 || s.RecvRate != 1 {
+		t.Errorf("rates after Close = %v/s and %v/s, want them to stop at the close: 2/s and 1/s", s.SendRate, s.RecvRate)
+	}
+
+	if err := c.Send(ctx, "e"); !errors.Is(err, chanmetrics.ErrClosed) {
+		t.Errorf("Send() after Close = %v, want %v", err, chanmetrics.ErrClosed)
+	}
+	for _, want := range []string{"c", "d"} {
+		if v, err := c.Recv(ctx); v != want || err != nil {
+			t.Errorf("Recv() after Close = %q, %v; want %q, nil", v, err, want)
+		}
+	}
+	if _, err := c.Recv(ctx); !errors.Is(err, chanmetrics.ErrClosed) {
+		t.Errorf("Recv() once drained = %v, want %v", err, chanmetrics.ErrClosed)
+	}
+}
+
+func TestGivingUpIsNotCounted(t *testing.T) {
+	c := chanmetrics.New[int]("full", 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.Send(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if _, err := c.Recv(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Recv() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if s := c.Snapshot(); s.SendWait.Count != 0 || s.RecvWait.Count != 0 || s.Sent != 0 {
+		t.Errorf("abandoned operations counted: %+v", s)
+	}
+}
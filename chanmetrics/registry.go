@@ -0,0 +1,126 @@
+package chanmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-concurrency/internal/metric"
+)
+
+// Collector is anything that can report a Snapshot; every Chan is one.
+type Collector interface {
+	Name() string
+	Snapshot() Snapshot
+}
+
+// Registry is a named set of channels whose metrics are served together.
+// It is an http.Handler serving the Prometheus text format.
+type Registry struct {
+	collectors metric.Registry[Collector]
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds c, replacing any collector with the same name.
+func (r *Registry) Register(c Collector) { r.collectors.Register(c) }
+
+// Unregister removes the collector with the given name.
+func (r *Registry) Unregister(name string) { r.collectors.Unregister(name) }
+
+// Snapshots returns a snapshot of every registered channel, by name.
+func (r *Registry) Snapshots() []Snapshot {
+	collectors := r.collectors.Collectors()
+	snaps := make([]Snapshot, len(collectors))
+	for i, c := range collectors {
+		snaps[i] = c.Snapshot()
+	}
+	return snaps
+}
+
+// ServeHTTP writes the registered channels' metrics in the Prometheus text
+// exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := WritePrometheus(w, r.Snapshots()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// WritePrometheus writes snaps in the Prometheus text exposition format,
+// one metric family at a time with a channel label per series.
+func WritePrometheus(w io.Writer, snaps []Snapshot) error {
+	bw := bufio.NewWriter(w)
+
+	gauge := func(name, help string, value func(Snapshot) float64) {
+		family(bw, name, "gauge", help)
+		for _, s := range snaps {
+			fmt.Fprintf(bw, "%s{channel=\"%s\"} %s\n", name, labelValue(s.Name), formatFloat(value(s)))
+		}
+	}
+	counter := func(name, help string, value func(Snapshot) int64) {
+		family(bw, name, "counter", help)
+		for _, s := range snaps {
+			fmt.Fprintf(bw, "%s{channel=\"%s\"} %d\n", name, labelValue(s.Name), value(s))
+		}
+	}
+	histogram := func(name, help string, wait func(Snapshot) Wait) {
+		family(bw, name, "histogram", help)
+		for _, s := range snaps {
+			h := wait(s)
+			for _, b := range h.Buckets {
+				fmt.Fprintf(bw, "%s_bucket{channel=\"%s\",le=\"%s\"} %d\n", name, labelValue(s.Name), formatFloat(b.UpperBound.Seconds()), b.Count)
+			}
+			fmt.Fprintf(bw, "%s_bucket{channel=\"%s\",le=\"+Inf\"} %d\n", name, labelValue(s.Name), h.Count)
+			fmt.Fprintf(bw, "%s_sum{channel=\"%s\"} %s\n", name, labelValue(s.Name), formatFloat(h.Total.Seconds()))
+			fmt.Fprintf(bw, "%s_count{channel=\"%s\"} %d\n", name, labelValue(s.Name), h.Count)
+		}
+	}
+
+	gauge("chan_length", "Values currently buffered in the channel.", func(s Snapshot) float64 { return float64(s.Len) })
+	gauge("chan_capacity", "Capacity of the channel.", func(s Snapshot) float64 { return float64(s.Cap) })
+	gauge("chan_length_max", "Most values seen buffered in the channel.", func(s Snapshot) float64 { return float64(s.MaxLen) })
+	gauge("chan_length_mean", "Average values buffered in the channel after each operation.", func(s Snapshot) float64 { return s.MeanLen })
+	counter("chan_sent_total", "Values sent on the channel.", func(s Snapshot) int64 { return s.Sent })
+	counter("chan_received_total", "Values received from the channel.", func(s Snapshot) int64 { return s.Received })
+	histogram("chan_send_wait_seconds", "Time senders waited for room in the channel.", func(s Snapshot) Wait { return s.SendWait })
+	histogram("chan_receive_wait_seconds", "Time receivers waited for a value from the channel.", func(s Snapshot) Wait { return s.RecvWait })
+	gauge("chan_created_timestamp_seconds", "Unix time the channel was created.", func(s Snapshot) float64 { return unixSeconds(s.Created) })
+	gauge("chan_closed_timestamp_seconds", "Unix time the channel was closed, or 0 while it is open.", func(s Snapshot) float64 {
+		if !s.Closed {
+			return 0
+		}
+		return unixSeconds(s.ClosedAt)
+	})
+
+	return bw.Flush()
+}
+
+func family(w io.Writer, name, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+// labelEscaper escapes the only three characters the text format escapes
+// in label values. %q would also write Go escapes such as \t or \u00e9,
+// which readers of the format take literally.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue returns s escaped for use as a label value.
+func labelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func unixSeconds(t time.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
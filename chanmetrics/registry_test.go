@@ -0,0 +1,79 @@
+package chanmetrics_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/chanmetrics"
+	"go-concurrency/clock"
+)
+
+func TestWritePrometheusEscapesLabelValues(t *testing.T) {
+	for name, want := range map[string]string{
+		`jobs`:          `chan_length{channel="jobs"} 0`,
+		`say "hi"`:      `chan_length{channel="say \"hi\""} 0`,
+		`C:\queue`:      `chan_length{channel="C:\\queue"} 0`,
+		"two\nlines":    `chan_length{channel="two\nlines"} 0`,
+		"tab\tand café": "chan_length{channel=\"tab\tand café\"} 0",
+	} {
+		var b strings.Builder
+		if err := chanmetrics.WritePrometheus(&b, []chanmetrics.Snapshot{{Name: name}}); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(b.String(), want+"\n") {
+			t.Errorf("channel %q: output has no line %s:\n%s", name, want, b.String())
+		}
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	reg := chanmetrics.NewRegistry()
+	jobs := chanmetrics.New[int]("jobs", 2, chanmetrics.WithClock(fake), chanmetrics.WithRegistry(reg))
+	chanmetrics.New[int]("gone", 1, chanmetrics.WithRegistry(reg))
+	reg.Unregister("gone")
+	results := chanmetrics.New[int]("results", 1, chanmetrics.WithClock(fake), chanmetrics.WithRegistry(reg))
+	ctx := context.Background()
+	jobs.Send(ctx, 1)
+	jobs.Send(ctx, 2)
+	jobs.Recv(ctx)
+	fake.Advance(time.Second)
+	results.Close()
+
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", ct)
+	}
+	body := rec.Body.String()
+	if strings.Contains(body, "gone") {
+		t.Errorf("unregistered channel served:\n%s", body)
+	}
+	want := []string{
+		"# HELP chan_length Values currently buffered in the channel.\n# TYPE chan_length gauge\n" +
+			`chan_length{channel="jobs"} 1` + "\n" + `chan_length{channel="results"} 0`,
+		`chan_length_max{channel="jobs"} 2`,
+		`chan_length_mean{channel="jobs"} 1.3333333333333333`,
+		"# TYPE chan_sent_total counter\n" + `chan_sent_total{channel="jobs"} 2`,
+		`chan_received_total{channel="jobs"} 1`,
+		"# TYPE chan_send_wait_seconds histogram\n" + `chan_send_wait_seconds_bucket{channel="jobs",le="1e-06"} 2`,
+		`chan_send_wait_seconds_bucket{channel="jobs",le="10"} 2` + "\n" +
+			`chan_send_wait_seconds_bucket{channel="jobs",le="+Inf"} 2` + "\n" +
+			`chan_send_wait_seconds_sum{channel="jobs"} 0` + "\n" +
+			`chan_send_wait_seconds_count{channel="jobs"} 2`,
+		`chan_receive_wait_seconds_count{channel="jobs"} 1`,
+		`chan_created_timestamp_seconds{channel="jobs"} 1.7e+09`,
+		`chan_closed_timestamp_seconds{channel="jobs"} 0` + "\n" + `chan_closed_timestamp_seconds{channel="results"} 1.700000001e+09`,
+	}
+	for _, w := range want {
+		if !strings.Contains(body, w+"\n") {
+			t.Errorf("output has no\n%s", w)
+		}
+	}
+	if t.Failed() {
+		t.Log(body)
+	}
+}
@@ -0,0 +1,73 @@
+// Package channelpatterns lists the exercises for advanced channel patterns.
+//
+// Run prints the exercises; implement each one in this package.
+package channelpatterns
+
+import (
+	"fmt"
+	"io"
+)
+
+// Run prints the module overview and its exercises to w.
+func Run(w io.Writer) {
+	fmt.Fprintln(w, "=== Channel Patterns Examples ===")
+	fmt.Fprintln(w, "This module demonstrates advanced channel patterns in Go.")
+	fmt.Fprintln(w, "Complete the following exercises:")
+	fmt.Fprintln(w)
+
+	// TODO: Implement the following patterns:
+
+	// 1. Select Statement Pattern
+	fmt.Fprintln(w, "1. SELECT STATEMENT PATTERN")
+	fmt.Fprintln(w, "   - Create multiple channels")
+	fmt.Fprintln(w, "   - Use select to handle multiple channel operations")
+	fmt.Fprintln(w, "   - Implement timeout handling")
+	fmt.Fprintln(w, "   - Handle default case")
+	fmt.Fprintln(w)
+
+	// 2. Channel Closing Pattern
+	fmt.Fprintln(w, "2. CHANNEL CLOSING PATTERN")
+	fmt.Fprintln(w, "   - Demonstrate proper channel closing")
+	fmt.Fprintln(w, "   - Detect closed channels")
+	fmt.Fprintln(w, "   - Handle closed channel gracefully")
+	fmt.Fprintln(w)
+
+	// 3. Pipeline Pattern
+	fmt.Fprintln(w, "3. PIPELINE PATTERN")
+	fmt.Fprintln(w, "   - Create a 3-stage pipeline")
+	fmt.Fprintln(w, "   - Stage 1: Generate numbers")
+	fmt.Fprintln(w, "   - Stage 2: Square the numbers")
+	fmt.Fprintln(w, "   - Stage 3: Print the results")
+	fmt.Fprintln(w)
+
+	// 4. Fan-in Pattern
+	fmt.Fprintln(w, "4. FAN-IN PATTERN")
+	fmt.Fprintln(w, "   - Create multiple producer goroutines")
+	fmt.Fprintln(w, "   - Merge all outputs into a single channel")
+	fmt.Fprintln(w, "   - Use select to multiplex channels")
+	fmt.Fprintln(w)
+
+	// 5. Fan-out Pattern
+	fmt.Fprintln(w, "5. FAN-OUT PATTERN")
+	fmt.Fprintln(w, "   - Create multiple consumer goroutines")
+	fmt.Fprintln(w, "   - Distribute work from a single channel")
+	fmt.Fprintln(w, "   - Implement load balancing")
+	fmt.Fprintln(w)
+
+	// 6. Generator Pattern
+	fmt.Fprintln(w, "6. GENERATOR PATTERN")
+	fmt.Fprintln(w, "   - Create a function that returns a channel")
+	fmt.Fprintln(w, "   - Generate values on demand")
+	fmt.Fprintln(w, "   - Implement cleanup when done")
+	fmt.Fprintln(w)
+
+	fmt.Fprintln(w, "Instructions:")
+	fmt.Fprintln(w, "- Implement each pattern in separate functions")
+	fmt.Fprintln(w, "- Add proper error handling")
+	fmt.Fprintln(w, "- Use meaningful variable names")
+	fmt.Fprintln(w, "- Add comments explaining the pattern")
+	fmt.Fprintln(w, "- Test each pattern with different scenarios")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run: go run ./cmd/channel-patterns")
+	fmt.Fprintln(w, "Then implement each pattern one by one!")
+}
@@ -0,0 +1,58 @@
+package channelpatterns_test
+
+import (
+	"os"
+
+	channelpatterns "go-concurrency/channel-patterns"
+)
+
+func ExampleRun() {
+	channelpatterns.Run(os.Stdout)
+
+	// Output:
+	// === Channel Patterns Examples ===
+	// This module demonstrates advanced channel patterns in Go.
+	// Complete the following exercises:
+	//
+	// 1. SELECT STATEMENT PATTERN
+	//    - Create multiple channels
+	//    - Use select to handle multiple channel operations
+	//    - Implement timeout handling
+	//    - Handle default case
+	//
+	// 2. CHANNEL CLOSING PATTERN
+	//    - Demonstrate proper channel closing
+	//    - Detect closed channels
+	//    - Handle closed channel gracefully
+	//
+	// 3. PIPELINE PATTERN
+	//    - Create a 3-stage pipeline
+	//    - Stage 1: Generate numbers
+	//    - Stage 2: Square the numbers
+	//    - Stage 3: Print the results
+	//
+	// 4. FAN-IN PATTERN
+	//    - Create multiple producer goroutines
+	//    - Merge all outputs into a single channel
+	//    - Use select to multiplex channels
+	//
+	// 5. FAN-OUT PATTERN
+	//    - Create multiple consumer goroutines
+	//    - Distribute work from a single channel
+	//    - Implement load balancing
+	//
+	// 6. GENERATOR PATTERN
+	//    - Create a function that returns a channel
+	//    - Generate values on demand
+	//    - Implement cleanup when done
+	//
+	// Instructions:
+	// - Implement each pattern in separate functions
+	// - Add proper error handling
+	// - Use meaningful variable names
+	// - Add comments explaining the pattern
+	// - Test each pattern with different scenarios
+	//
+	// Run: go run ./cmd/channel-patterns
+	// Then implement each pattern one by one!
+}
@@ -0,0 +1,307 @@
+// Package chantrace records every send, receive and close on instrumented
+// channels, with the goroutine that made it and when, so that a timeline
+// can show why an unbuffered send waits for its receiver and a buffered
+// one does not.
+//
+// A Chan is made on a Recorder and used through Send, Recv and Close,
+// which behave like the channel operations they record. An operation that
+// has to wait is recorded twice: once when it starts waiting and once when
+// it completes. An operation that does not wait is recorded together
+// with the operation itself, so the receive that takes a value is always
+// recorded after its send, or after the send started waiting, and the
+// receive that finds the channel closed after the close. The Recorder
+// renders its timeline as a text sequence diagram, a Mermaid diagram or an
+// SVG image:
+//
+//	rec := chantrace.NewRecorder()
+//	ch := chantrace.Make[string](rec, "ch", 0)
+//	go ch.Send("hello")
+//	ch.Recv()
+//	rec.WriteText(os.Stdout)
+package chantrace
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/internal/goid"
+	"go-concurrency/internal/metric"
+)
+
+// Op is the channel operation an event records.
+type Op int
+
+const (
+	// Send is a value sent on the channel.
+	Send Op = iota
+	// Recv is a value received from the channel, or the zero value from a
+	// closed and drained one.
+	Recv
+	// Close is the channel being closed.
+	Close
+)
+
+var opNames = [...]string{"send", "recv", "close"}
+
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+	return opNames[op]
+}
+
+// Event is one recorded channel operation.
+type Event struct {
+	// Seq orders events in the sequence they were recorded.
+	Seq int
+	// At is the time since the Recorder was created.
+	At time.Duration
+	// Goroutine is the ID of the goroutine that made the operation.
+	Goroutine int64
+	// Chan names the channel.
+	Chan string
+	// Op is the operation.
+	Op Op
+	// Value is the value sent or received, formatted with %v.
+	Value string
+	// Closed marks a receive that found the channel closed and drained.
+	Closed bool
+	// Waiting marks an operation that could not complete at once and
+	// starts to wait; a second event records when it completes.
+	Waiting bool
+	// Waited is how long a completed operation waited.
+	Waited time.Duration
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("g%d %s: %s", e.Goroutine, e.Chan, e.label())
+}
+
+// label describes the operation, e.g. "send 42 blocks".
+func (e Event) label() string {
+	s := e.Op.String()
+	switch {
+	case e.Closed:
+		s += " (closed)"
+	case e.Op == Send, e.Op == Recv && !e.Waiting:
+		s += " " + e.Value
+	}
+	switch {
+	case e.Waiting:
+		s += " blocks"
+	case e.Waited > 0:
+		s += fmt.Sprintf(" after %v", metric.Round(e.Waited))
+	}
+	return s
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithClock timestamps events on clk instead of the wall clock.
+func WithClock(clk clock.Clock) Option {
+	return func(r *Recorder) { r.clk = clk }
+}
+
+// Recorder records the operations on the channels made with it. It is safe
+// for concurrent use. A nil *Recorder records nothing, so instrumented
+// code runs unchanged when tracing is off.
+type Recorder struct {
+	clk   clock.Clock
+	start time.Time
+
+	mu     sync.Mutex
+	seq    int
+	events []Event
+	names  map[int64]string
+	caps   map[string]int
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder(opts ...Option) *Recorder {
+	r := &Recorder{clk: clock.Real(), names: make(map[int64]string), caps: make(map[string]int)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.start = r.clk.Now()
+	return r
+}
+
+// Name labels the current goroutine name in diagrams.
+func (r *Recorder) Name(name string) {
+	if r == nil {
+		return
+	}
+	id := goid.Current()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.names[id] = name
+}
+
+// Events returns a copy of the events recorded so far, in recording order.
+func (r *Recorder) Events() []Event {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func (r *Recorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.add(e)
+}
+
+// add appends e to the timeline; r.mu is held.
+func (r *Recorder) add(e Event) {
+	e.Seq, e.At = r.seq, r.clk.Since(r.start)
+	r.seq++
+	r.events = append(r.events, e)
+}
+
+// wait records that e is about to wait and returns when it started.
+func (r *Recorder) wait(e Event) time.Time {
+	e.Waiting = true
+	r.record(e)
+	return r.clk.Now()
+}
+
+// done records that e completed after waiting since start.
+func (r *Recorder) done(e Event, start time.Time) {
+	e.Waited = r.clk.Since(start)
+	r.record(e)
+}
+
+// Chan is a channel of T values whose operations are recorded. Make one
+// with Make.
+type Chan[T any] struct {
+	r    *Recorder
+	name string
+	ch   chan T
+}
+
+// Make returns a channel named name that buffers up to capacity values and
+// records its operations on r.
+func Make[T any](r *Recorder, name string, capacity int) *Chan[T] {
+	if r != nil {
+		r.mu.Lock()
+		r.caps[name] = capacity
+		r.mu.Unlock()
+	}
+	return &Chan[T]{r: r, name: name, ch: make(chan T, capacity)}
+}
+
+// Send sends v, waiting like ch <- v does.
+func (c *Chan[T]) Send(v T) {
+	if c.r == nil {
+		c.ch <- v
+		return
+	}
+	e := c.event(Send, v)
+	c.r.mu.Lock()
+	select {
+	case c.ch <- v:
+		c.r.add(e)
+		c.r.mu.Unlock()
+		return
+	default:
+	}
+	c.r.mu.Unlock()
+	start := c.r.wait(e)
+	c.ch <- v
+	c.r.done(e, start)
+}
+
+// Recv receives a value, waiting like <-ch does. ok is false if the channel
+// is closed and drained.
+func (c *Chan[T]) Recv() (v T, ok bool) {
+	return recv(c.r, c.name, c.ch)
+}
+
+// RecvFrom receives from ch, a channel made elsewhere such as by another
+// package, recording the receive on r under name. The sends on ch are not
+// recorded.
+func RecvFrom[T any](r *Recorder, name string, ch <-chan T) (v T, ok bool) {
+	if r != nil {
+		r.mu.Lock()
+		r.caps[name] = cap(ch)
+		r.mu.Unlock()
+	}
+	return recv(r, name, ch)
+}
+
+func recv[T any](r *Recorder, name string, ch <-chan T) (v T, ok bool) {
+	if r == nil {
+		v, ok = <-ch
+		return v, ok
+	}
+	e := Event{Goroutine: goid.Current(), Chan: name, Op: Recv}
+	r.mu.Lock()
+	select {
+	case v, ok = <-ch:
+		r.add(received(e, v, ok))
+		r.mu.Unlock()
+		return v, ok
+	default:
+	}
+	r.mu.Unlock()
+	start := r.wait(e)
+	v, ok = <-ch
+	r.done(received(e, v, ok), start)
+	return v, ok
+}
+
+// Close closes the channel.
+func (c *Chan[T]) Close() {
+	if c.r == nil {
+		close(c.ch)
+		return
+	}
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	close(c.ch)
+	c.r.add(Event{Goroutine: goid.Current(), Chan: c.name, Op: Close})
+}
+
+// C returns the underlying channel for use in select statements, whose
+// operations cannot be recorded for it; record them with Sent and Received.
+// Those are recorded after the operation, so the other end of a send may
+// be recorded before it.
+func (c *Chan[T]) C() chan T { return c.ch }
+
+// Sent records a send of v made directly on C.
+func (c *Chan[T]) Sent(v T) {
+	if c.r != nil {
+		c.r.record(c.event(Send, v))
+	}
+}
+
+// Received records a receive made directly on C.
+func (c *Chan[T]) Received(v T, ok bool) {
+	if c.r != nil {
+		c.r.record(received(Event{Goroutine: goid.Current(), Chan: c.name, Op: Recv}, v, ok))
+	}
+}
+
+// Len returns the number of values buffered.
+func (c *Chan[T]) Len() int { return len(c.ch) }
+
+// Cap returns the channel's capacity.
+func (c *Chan[T]) Cap() int { return cap(c.ch) }
+
+func (c *Chan[T]) event(op Op, v T) Event {
+	return Event{Goroutine: goid.Current(), Chan: c.name, Op: op, Value: fmt.Sprintf("%v", v)}
+}
+
+func received[T any](e Event, v T, ok bool) Event {
+	if ok {
+		e.Value = fmt.Sprintf("%v", v)
+	} else {
+		e.Value, e.Closed = "", true
+	}
+	return e
+}
@@ -0,0 +1,125 @@
+package chantrace_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/chantrace"
+)
+
+// A receive is never recorded before the send of its value, or before the
+// send started waiting, nor before the close it reports, however the
+// goroutines are scheduled.
+func TestReceiveRecordedAfterItsSend(t *testing.T) {
+	for _, capacity := range []int{0, 2} {
+		rec := chantrace.NewRecorder()
+		ch := chantrace.Make[int](rec, "ch", capacity)
+		const n = 200
+		go func() {
+			for v := range n {
+				ch.Send(v)
+			}
+			ch.Close()
+		}()
+		for {
+			if _, ok := ch.Recv(); !ok {
+				break
+			}
+		}
+
+		sent := make(map[string]bool)
+		closed := false
+		for _, e := range rec.Events() {
+			switch {
+			case e.Op == chantrace.Send:
+				sent[e.Value] = true
+			case e.Op == chantrace.Close:
+				closed = true
+			case e.Op == chantrace.Recv && e.Closed && !closed:
+				t.Fatalf("cap %d: receive of the close recorded before the close", capacity)
+			case e.Op == chantrace.Recv && !e.Waiting && !e.Closed && !sent[e.Value]:
+				t.Fatalf("cap %d: receive of %s recorded before its send", capacity, e.Value)
+			}
+		}
+		if len(sent) != n {
+			t.Errorf("cap %d: %d sends recorded, want %d", capacity, len(sent), n)
+		}
+	}
+}
+
+func TestBlockedOperationReport(t *testing.T) {
+	rec := chantrace.NewRecorder()
+	ch := chantrace.Make[string](rec, "ch", 0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rec.Name("stuck")
+		ch.Recv()
+	}()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		if ev := rec.Events(); len(ev) == 1 && ev[0].Waiting {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the receive was never recorded as waiting")
+		}
+	}
+
+	var text, mermaid strings.Builder
+	rec.WriteText(&text)
+	rec.WriteMermaid(&mermaid)
+	for name, out := range map[string]string{"text": text.String(), "mermaid": mermaid.String()} {
+		if !strings.Contains(out, ") still blocked: recv on ch") || !strings.Contains(out, "stuck (g") {
+			t.Errorf("%s diagram does not report the blocked receive:\n%s", name, out)
+		}
+	}
+
+	ch.Close()
+	<-done
+	text.Reset()
+	rec.WriteText(&text)
+	if strings.Contains(text.String(), "still blocked") {
+		t.Errorf("diagram still reports a blocked receive once it completed:\n%s", text.String())
+	}
+}
+
+func TestRecvFrom(t *testing.T) {
+	rec := chantrace.NewRecorder()
+	ch := make(chan int, 3)
+	ch <- 7
+	close(ch)
+	if v, ok := chantrace.RecvFrom(rec, "library", ch); v != 7 || !ok {
+		t.Errorf("RecvFrom() = %d, %v; want 7, true", v, ok)
+	}
+	if _, ok := chantrace.RecvFrom(rec, "library", ch); ok {
+		t.Error("RecvFrom() of a closed channel reported ok")
+	}
+	var text strings.Builder
+	rec.WriteText(&text)
+	for _, want := range []string{"library (cap 3)", "recv 7", "recv (closed)"} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("diagram has no %q:\n%s", want, text.String())
+		}
+	}
+}
+
+// A nil Recorder records nothing and leaves the channels working.
+func TestNilRecorder(t *testing.T) {
+	var rec *chantrace.Recorder
+	rec.Name("main")
+	ch := chantrace.Make[string](rec, "ch", 1)
+	ch.Send("x")
+	if v, ok := ch.Recv(); v != "x" || !ok {
+		t.Errorf("Recv() = %q, %v; want x, true", v, ok)
+	}
+	ch.Close()
+	if events := rec.Events(); events != nil {
+		t.Errorf("Events() = %v, want nil", events)
+	}
+	var text strings.Builder
+	rec.WriteText(&text)
+	if !strings.HasPrefix(text.String(), "(no channel operations recorded)") {
+		t.Errorf("WriteText() = %q", text.String())
+	}
+}
@@ -0,0 +1,233 @@
+package chantrace
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"go-concurrency/internal/metric"
+)
+
+// diagram is a Recorder's timeline laid out as a sequence diagram: one
+// lifeline per goroutine and channel, in order of first appearance, and
+// one arrow per event.
+type diagram struct {
+	parts   []string // participant labels
+	rows    []arrow
+	blocked []string // operations still waiting when the diagram was drawn
+}
+
+type arrow struct {
+	at       time.Duration
+	from, to int
+	waiting  bool
+	close    bool
+	label    string
+}
+
+func (r *Recorder) diagram() diagram {
+	if r == nil {
+		return diagram{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var d diagram
+	index := make(map[string]int)
+	part := func(key, label string) int {
+		i, ok := index[key]
+		if !ok {
+			i = len(d.parts)
+			index[key] = i
+			d.parts = append(d.parts, label)
+		}
+		return i
+	}
+	waiting := make(map[int64]Event)
+	var order []int64 // goroutines in the order they started waiting
+	for _, e := range r.events {
+		g := part(fmt.Sprint("g", e.Goroutine), r.goroutine(e.Goroutine))
+		c := part("chan "+e.Chan, fmt.Sprintf("%s (cap %d)", e.Chan, r.caps[e.Chan]))
+		a := arrow{at: e.At, from: g, to: c, waiting: e.Waiting, close: e.Op == Close, label: e.label()}
+		if e.Op == Recv {
+			a.from, a.to = c, g
+		}
+		d.rows = append(d.rows, a)
+
+		if e.Waiting {
+			waiting[e.Goroutine] = e
+			order = append(order, e.Goroutine)
+		} else {
+			delete(waiting, e.Goroutine)
+		}
+	}
+	for _, g := range order {
+		if e, ok := waiting[g]; ok {
+			d.blocked = append(d.blocked, fmt.Sprintf("%s still blocked: %s on %s", r.goroutine(g), e.Op, e.Chan))
+			delete(waiting, g)
+		}
+	}
+	return d
+}
+
+// goroutine labels goroutine id; r.mu is held.
+func (r *Recorder) goroutine(id int64) string {
+	if name, ok := r.names[id]; ok {
+		return fmt.Sprintf("%s (g%d)", name, id)
+	}
+	return fmt.Sprint("g", id)
+}
+
+// WriteText writes the timeline as a text sequence diagram: a column per
+// goroutine and channel, and a row per event with its time since the
+// Recorder was created. Dotted arrows are operations that start to wait.
+func (r *Recorder) WriteText(w io.Writer) error {
+	d := r.diagram()
+	bw := bufio.NewWriter(w)
+	if len(d.rows) == 0 {
+		fmt.Fprintln(bw, "(no channel operations recorded)")
+		return bw.Flush()
+	}
+
+	width := 12
+	for _, p := range d.parts {
+		width = max(width, len([]rune(p))+2)
+	}
+	center := func(i int) int { return i*width + width/2 }
+	const timeWidth = 11
+
+	line := make([]rune, len(d.parts)*width)
+	lifelines := func() {
+		for i := range line {
+			line[i] = ' '
+		}
+		for i := range d.parts {
+			line[center(i)] = '|'
+		}
+	}
+
+	for i := range line {
+		line[i] = ' '
+	}
+	for i, p := range d.parts {
+		label := []rune(p)
+		copy(line[center(i)-len(label)/2:], label)
+	}
+	fmt.Fprintf(bw, "%*s %s\n", timeWidth, "", strings.TrimRight(string(line), " "))
+
+	for _, a := range d.rows {
+		lifelines()
+		lo, hi := min(center(a.from), center(a.to)), max(center(a.from), center(a.to))
+		for x := lo + 1; x < hi; x++ {
+			line[x] = '-'
+			if a.waiting {
+				line[x] = '.'
+			}
+		}
+		head := '>'
+		if a.close {
+			head = 'x'
+		}
+		if a.to < a.from {
+			if !a.close {
+				head = '<'
+			}
+			line[lo+1] = head
+		} else {
+			line[hi-1] = head
+		}
+		fmt.Fprintf(bw, "%*v %s  %s\n", timeWidth, metric.Round(a.at), string(line), a.label)
+	}
+	for _, b := range d.blocked {
+		fmt.Fprintln(bw, b)
+	}
+	return bw.Flush()
+}
+
+// WriteMermaid writes the timeline as a Mermaid sequence diagram.
+func (r *Recorder) WriteMermaid(w io.Writer) error {
+	d := r.diagram()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "sequenceDiagram")
+	for i, p := range d.parts {
+		fmt.Fprintf(bw, "    participant p%d as %s\n", i, mermaid(p))
+	}
+	for _, a := range d.rows {
+		kind := "->>"
+		switch {
+		case a.close:
+			kind = "-x"
+		case a.waiting:
+			kind = "-->>"
+		}
+		fmt.Fprintf(bw, "    p%d%sp%d: %v %s\n", a.from, kind, a.to, metric.Round(a.at), mermaid(a.label))
+	}
+	if len(d.blocked) > 0 && len(d.parts) > 0 {
+		fmt.Fprintf(bw, "    Note over p0,p%d: %s\n", len(d.parts)-1, mermaid(strings.Join(d.blocked, "<br/>")))
+	}
+	return bw.Flush()
+}
+
+// mermaid escapes the characters Mermaid gives a meaning in labels.
+func mermaid(s string) string {
+	return strings.NewReplacer("#", "#35;", ";", "#59;", "\n", " ").Replace(s)
+}
+
+// WriteSVG writes the timeline as a standalone SVG image of the sequence
+// diagram.
+func (r *Recorder) WriteSVG(w io.Writer) error {
+	d := r.diagram()
+	const (
+		left   = 90  // room for the time column
+		colW   = 170 // distance between lifelines
+		top    = 50  // below the participant boxes
+		rowH   = 30
+		boxH   = 26
+		margin = 20
+	)
+	width := left + len(d.parts)*colW
+	height := top + (len(d.rows)+1)*rowH + len(d.blocked)*rowH/2 + margin
+	center := func(i int) int { return left + i*colW + colW/2 }
+	lifelineEnd := top + len(d.rows)*rowH + rowH/2
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n", width, height)
+	fmt.Fprintln(bw, `<defs>`)
+	fmt.Fprintln(bw, `<marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="8" markerHeight="8" orient="auto-start-reverse"><path d="M0,0 L10,5 L0,10 z"/></marker>`)
+	fmt.Fprintln(bw, `<marker id="cross" viewBox="0 0 10 10" refX="5" refY="5" markerWidth="10" markerHeight="10"><path d="M0,0 L10,10 M10,0 L0,10" stroke="black" stroke-width="2"/></marker>`)
+	fmt.Fprintln(bw, `</defs>`)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+
+	for i, p := range d.parts {
+		x := center(i)
+		fmt.Fprintf(bw, `<rect x="%d" y="10" width="%d" height="%d" rx="4" fill="#eef" stroke="#446"/>`+"\n", x-colW/2+8, colW-16, boxH)
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n", x, 10+boxH/2+4, html.EscapeString(p))
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#999" stroke-dasharray="4 4"/>`+"\n", x, 10+boxH, x, lifelineEnd)
+	}
+
+	for k, a := range d.rows {
+		y := top + (k+1)*rowH
+		x1, x2 := center(a.from), center(a.to)
+		style := `stroke="black"`
+		if a.waiting {
+			style += ` stroke-dasharray="3 3"`
+		}
+		marker := "arrow"
+		if a.close {
+			marker = "cross"
+		}
+		fmt.Fprintf(bw, `<text x="8" y="%d" fill="#666">%v</text>`+"\n", y+4, metric.Round(a.at))
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d" %s marker-end="url(#%s)"/>`+"\n", x1, y, x2, y, style, marker)
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n", (x1+x2)/2, y-5, html.EscapeString(a.label))
+	}
+
+	for k, b := range d.blocked {
+		y := lifelineEnd + rowH/2 + k*rowH/2 + 4
+		fmt.Fprintf(bw, `<text x="8" y="%d" fill="#b00">%s</text>`+"\n", y, html.EscapeString(b))
+	}
+	fmt.Fprintln(bw, `</svg>`)
+	return bw.Flush()
+}
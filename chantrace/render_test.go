@@ -0,0 +1,80 @@
+package chantrace
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// timeline returns a Recorder holding a fixed timeline: a buffered send
+// and receive, an unbuffered send that waits for its receiver, a close
+// and a receive still blocked at the end. Events are added directly, so
+// goroutine IDs and times are the same on every run.
+func timeline() *Recorder {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := NewRecorder(WithClock(fake))
+	r.names[1], r.names[2] = "main", "sender"
+	Make[int](r, "buf", 1)
+	Make[string](r, "ch", 0)
+
+	r.record(Event{Goroutine: 1, Chan: "buf", Op: Send, Value: "1"})
+	fake.Advance(time.Millisecond)
+	r.record(Event{Goroutine: 1, Chan: "buf", Op: Recv, Value: "1"})
+	r.record(Event{Goroutine: 2, Chan: "ch", Op: Send, Value: "hi", Waiting: true})
+	fake.Advance(2 * time.Millisecond)
+	r.record(Event{Goroutine: 1, Chan: "ch", Op: Recv, Value: "hi"})
+	r.record(Event{Goroutine: 2, Chan: "ch", Op: Send, Value: "hi", Waited: 2 * time.Millisecond})
+	fake.Advance(time.Millisecond)
+	r.record(Event{Goroutine: 2, Chan: "buf", Op: Close})
+	r.record(Event{Goroutine: 1, Chan: "buf", Op: Recv, Closed: true})
+	fake.Advance(1500 * time.Microsecond)
+	r.record(Event{Goroutine: 3, Chan: "ch", Op: Recv, Waiting: true})
+	return r
+}
+
+func TestRenderGolden(t *testing.T) {
+	r := timeline()
+	for name, write := range map[string]func(io.Writer) error{
+		"timeline.txt": r.WriteText,
+		"timeline.mmd": r.WriteMermaid,
+		"timeline.svg": r.WriteSVG,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := write(&got); err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", name)
+			if *update {
+				if err := os.WriteFile(golden, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("output differs from %s; rerun with -update if the change is intended:\n%s", golden, got.String())
+			}
+		})
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	var got bytes.Buffer
+	if err := NewRecorder().WriteText(&got); err != nil {
+		t.Fatal(err)
+	}
+	if want := "(no channel operations recorded)\n"; got.String() != want {
+		t.Errorf("WriteText() of no events = %q, want %q", got.String(), want)
+	}
+}
@@ -0,0 +1,245 @@
+// Package chanx provides generic, context-aware building blocks for channel
+// pipelines: generators, fan-in, fan-out, tee, bridge and friends.
+//
+// Every function follows the same ownership rules. The output channels it
+// returns are owned by a goroutine started inside the function, which
+// closes each of them exactly once. That goroutine exits, closing its
+// outputs, as soon as its inputs are exhausted or ctx is done, so
+// cancelling ctx is always enough to release every stage of a pipeline,
+// even when nobody is reading the outputs any more.
+package chanx
+
+import (
+	"context"
+	"sync"
+)
+
+// Generate returns a channel that sends values in order and is then closed.
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for _, v := range values {
+			if !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// GenerateFunc returns a channel that sends the results of repeated calls
+// to fn until ctx is done.
+func GenerateFunc[T any](ctx context.Context, fn func() T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for send(ctx, out, fn()) {
+		}
+	}()
+	return out
+}
+
+// Merge forwards every value from every input to a single channel (fan-in),
+// which is closed once all inputs are closed. Values from one input keep
+// their order; values from different inputs interleave.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func() {
+			defer wg.Done()
+			forward(ctx, out, in)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Split distributes the values from in across n channels (fan-out). Each
+// value goes to exactly one of them, whichever is read first; a slow reader
+// only holds back the value it was handed. Split panics if n < 1.
+func Split[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		panic("chanx: Split needs at least one output")
+	}
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			forward(ctx, out, in)
+		}()
+	}
+	return outs
+}
+
+// FanOut starts workers goroutines that apply fn to values from in and
+// merges their results into one channel, closed once every worker is done.
+// Results arrive in completion order. FanOut panics if workers < 1.
+func FanOut[T, R any](ctx context.Context, in <-chan T, workers int, fn func(T) R) <-chan R {
+	if workers < 1 {
+		panic("chanx: FanOut needs at least one worker")
+	}
+	out := make(chan R)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := recv(ctx, in)
+				if !ok || !send(ctx, out, fn(v)) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Tee sends every value from in to both returned channels. A value is only
+// read from in once both copies have been delivered, so the slower reader
+// sets the pace for both.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	out1, out2 := make(chan T), make(chan T)
+	go func() {
+		defer close(out1)
+		defer close(out2)
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			// Deliver to whichever side is ready first, then the other;
+			// a nil channel drops out of the select once served
+			o1, o2 := out1, out2
+			for o1 != nil || o2 != nil {
+				select {
+				case o1 <- v:
+					o1 = nil
+				case o2 <- v:
+					o2 = nil
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out1, out2
+}
+
+// Bridge flattens a channel of channels: it forwards every value from each
+// inner channel in turn, moving to the next once the current one is closed.
+func Bridge[T any](ctx context.Context, chans <-chan <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			in, ok := recv(ctx, chans)
+			if !ok {
+				return
+			}
+			if !forward(ctx, out, in) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// OrDone forwards values from in until in is closed or ctx is done, so a
+// plain range over the result never outlives ctx.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		forward(ctx, out, in)
+	}()
+	return out
+}
+
+// Take forwards at most the first n values from in. It stops reading after
+// the n-th, leaving the rest of in for whoever cancels its producer.
+func Take[T any](ctx context.Context, in <-chan T, n int) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for range n {
+			v, ok := recv(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Buffer forwards values from in through a channel with capacity size, so
+// a producer can run up to size values ahead of a slow consumer.
+func Buffer[T any](ctx context.Context, in <-chan T, size int) <-chan T {
+	out := make(chan T, size)
+	go func() {
+		defer close(out)
+		forward(ctx, out, in)
+	}()
+	return out
+}
+
+// Drain receives and discards values from in until it is closed or ctx is
+// done, and returns how many it discarded. Draining lets the producer of
+// an abandoned channel finish instead of blocking forever.
+func Drain[T any](ctx context.Context, in <-chan T) int {
+	n := 0
+	for {
+		if _, ok := recv(ctx, in); !ok {
+			return n
+		}
+		n++
+	}
+}
+
+// send sends v on out and reports whether it was delivered before ctx was
+// done.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recv receives from in. It reports false once in is closed or ctx is done.
+func recv[T any](ctx context.Context, in <-chan T) (T, bool) {
+	select {
+	case v, ok := <-in:
+		return v, ok
+	case <-ctx.Done():
+		var zero T
+		return zero, false
+	}
+}
+
+// forward copies values from in to out until in is closed or ctx is done,
+// and reports whether in was exhausted.
+func forward[T any](ctx context.Context, out chan<- T, in <-chan T) bool {
+	for {
+		v, ok := recv(ctx, in)
+		if !ok {
+			return ctx.Err() == nil
+		}
+		if !send(ctx, out, v) {
+			return false
+		}
+	}
+}
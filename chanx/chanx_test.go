@@ -0,0 +1,139 @@
+package chanx_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go-concurrency/chanx"
+	"go-concurrency/leakcheck/leaktest"
+)
+
+// collect receives every value from in until it is closed.
+func collect[T any](in <-chan T) []T {
+	var out []T
+	for v := range in {
+		out = append(out, v)
+	}
+	return out
+}
+
+func TestStages(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		// Take leaves the rest of its input to whoever cancels the producer
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		want := []int{1, 2, 3, 4, 5}
+
+		if got := collect(chanx.Generate(ctx, want...)); !slices.Equal(got, want) {
+			t.Errorf("Generate = %v, want %v", got, want)
+		}
+		if got := collect(chanx.Take(ctx, chanx.Generate(ctx, want...), 3)); !slices.Equal(got, want[:3]) {
+			t.Errorf("Take 3 = %v, want %v", got, want[:3])
+		}
+		if got := collect(chanx.Buffer(ctx, chanx.Generate(ctx, want...), 2)); !slices.Equal(got, want) {
+			t.Errorf("Buffer = %v, want %v", got, want)
+		}
+		if got := collect(chanx.OrDone(ctx, chanx.Generate(ctx, want...))); !slices.Equal(got, want) {
+			t.Errorf("OrDone = %v, want %v", got, want)
+		}
+
+		got := collect(chanx.Merge(ctx, chanx.Generate(ctx, 1, 2), chanx.Generate(ctx, 3, 4, 5)))
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("Merge = %v sorted, want %v", got, want)
+		}
+
+		squares := collect(chanx.FanOut(ctx, chanx.Generate(ctx, want...), 3, func(n int) int { return n * n }))
+		slices.Sort(squares)
+		if w := []int{1, 4, 9, 16, 25}; !slices.Equal(squares, w) {
+			t.Errorf("FanOut squares = %v sorted, want %v", squares, w)
+		}
+
+		got = nil
+		for _, out := range chanx.Split(ctx, chanx.Generate(ctx, want...), 3) {
+			got = append(got, collect(out)...)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("Split = %v sorted, want %v", got, want)
+		}
+
+		a, b := chanx.Tee(ctx, chanx.Generate(ctx, want...))
+		gotB := make(chan []int)
+		go func() { gotB <- collect(b) }()
+		if got := collect(a); !slices.Equal(got, want) {
+			t.Errorf("Tee first = %v, want %v", got, want)
+		}
+		if got := <-gotB; !slices.Equal(got, want) {
+			t.Errorf("Tee second = %v, want %v", got, want)
+		}
+
+		chans := make(chan (<-chan int), 2)
+		chans <- chanx.Generate(ctx, 1, 2)
+		chans <- chanx.Generate(ctx, 3, 4, 5)
+		close(chans)
+		if got := collect(chanx.Bridge(ctx, chans)); !slices.Equal(got, want) {
+			t.Errorf("Bridge = %v, want %v", got, want)
+		}
+
+		if n := chanx.Drain(ctx, chanx.Generate(ctx, want...)); n != len(want) {
+			t.Errorf("Drain = %d, want %d", n, len(want))
+		}
+	})
+}
+
+// Cancelling the context halfway through a pipeline releases every stage,
+// even those whose outputs nobody reads any more.
+func TestCancelMidPipeline(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		n := 0
+		source := chanx.GenerateFunc(ctx, func() int { n++; return n })
+		left, right := chanx.Tee(ctx, source)
+		parts := chanx.Split(ctx, left, 2)
+		squares := chanx.FanOut(ctx, chanx.Merge(ctx, parts...), 3, func(n int) int { return n * n })
+		chans := make(chan (<-chan int), 1)
+		chans <- chanx.Buffer(ctx, right, 4)
+		out := chanx.OrDone(ctx, chanx.Merge(ctx, squares, chanx.Bridge(ctx, chans)))
+
+		for range 10 {
+			if _, ok := <-out; !ok {
+				t.Fatal("pipeline closed before it was cancelled")
+			}
+		}
+		cancel()
+		// out is never read again; every stage must still exit
+	})
+}
+
+// A stage blocked on a send to a reader that went away exits on cancel.
+func TestCancelReleasesBlockedSend(t *testing.T) {
+	stages := map[string]func(ctx context.Context, in <-chan int) <-chan int{
+		"Take":   func(ctx context.Context, in <-chan int) <-chan int { return chanx.Take(ctx, in, 100) },
+		"OrDone": chanx.OrDone[int],
+		"Buffer": func(ctx context.Context, in <-chan int) <-chan int { return chanx.Buffer(ctx, in, 1) },
+		"FanOut": func(ctx context.Context, in <-chan int) <-chan int {
+			return chanx.FanOut(ctx, in, 2, func(n int) int { return n })
+		},
+	}
+	for name, stage := range stages {
+		t.Run(name, func(t *testing.T) {
+			leaktest.VerifyNone(t, func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				out := stage(ctx, chanx.GenerateFunc(ctx, func() int { return 1 }))
+				<-out
+				cancel()
+			})
+		})
+	}
+}
+
+func TestSplitPanicsWithoutOutputs(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Split with no outputs did not panic")
+		}
+	}()
+	chanx.Split(context.Background(), make(chan int), 0)
+}
@@ -0,0 +1,109 @@
+// Package clock abstracts the passage of time so code that sleeps, waits on
+// timers or sets context deadlines can be driven deterministically.
+//
+// Production code takes a Clock and is given Real(). Tests give it a *Fake,
+// whose time only moves when the test calls Advance or, through Drive,
+// whenever the code under test is blocked, so a demo that "sleeps" for
+// seconds finishes instantly and always in the same order.
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and creates timers. Both implementations are safe
+// for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// Sleep blocks for at least d.
+	Sleep(d time.Duration)
+	// After waits for d and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// Tick is like After but sends repeatedly, every d. The ticker cannot
+	// be stopped, but since Go 1.23 the real one is garbage collected once
+	// the channel is unreachable; use NewTicker to stop it sooner, or to
+	// stop a Fake's, which stays pending on the clock.
+	Tick(d time.Duration) <-chan time.Time
+	// NewTimer creates a Timer that sends the current time after d.
+	NewTimer(d time.Duration) Timer
+	// AfterFunc waits for d and then calls f in its own goroutine.
+	AfterFunc(d time.Duration, f func()) Timer
+	// NewTicker creates a Ticker that sends the current time every d.
+	NewTicker(d time.Duration) Ticker
+	// WithTimeout is context.WithTimeout measured on this clock.
+	WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc)
+	// WithDeadline is context.WithDeadline measured on this clock.
+	WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc)
+}
+
+// Timer is the Clock counterpart of *time.Timer.
+type Timer interface {
+	// C returns the channel the time is sent on. It is nil for timers
+	// created by AfterFunc.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It reports whether the call
+	// stopped the timer.
+	Stop() bool
+	// Reset changes the timer to fire after d. It reports whether the
+	// timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Ticker is the Clock counterpart of *time.Ticker.
+type Ticker interface {
+	// C returns the channel ticks are sent on.
+	C() <-chan time.Time
+	// Stop turns off the ticker.
+	Stop()
+	// Reset stops the ticker and restarts it with period d.
+	Reset(d time.Duration)
+}
+
+// Real returns the Clock backed by the time package.
+func Real() Clock {
+	return realClock{}
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Tick(d time.Duration) <-chan time.Time  { return time.Tick(d) }
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+func (realClock) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, d)
+}
+
+func (realClock) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	return context.WithDeadline(parent, deadline)
+}
+
+type realTimer struct{ t *time.Timer }
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time   { return t.t.C }
+func (t realTicker) Stop()                 { t.t.Stop() }
+func (t realTicker) Reset(d time.Duration) { t.t.Reset(d) }
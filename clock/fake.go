@@ -0,0 +1,422 @@
+package clock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go-concurrency/leakcheck"
+)
+
+// Fake is a manually advanced Clock. Its time stands still until Advance
+// or Set is called, at which point every timer, ticker, sleeper and
+// context deadline that falls due fires in chronological order. Drive
+// advances it automatically whenever the code under test is blocked.
+type Fake struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	seq     uint64
+	waiters []*waiter
+}
+
+// waiter is one pending wake-up: a timer or ticker channel, an AfterFunc
+// callback, or a sleeper.
+type waiter struct {
+	when   time.Time
+	seq    uint64
+	period time.Duration // non-zero for tickers
+	ch     chan time.Time
+	fn     func()
+}
+
+// NewFake returns a Fake clock reading start.
+func NewFake(start time.Time) *Fake {
+	f := &Fake{now: start}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Sleep blocks until the fake time has moved forward by d.
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+// After returns a channel that receives the fake time once it has moved
+// forward by d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// Tick returns a channel that receives the fake time every d.
+func (f *Fake) Tick(d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		return nil
+	}
+	return f.NewTicker(d).C()
+}
+
+// NewTimer creates a Timer that fires once the fake time has moved
+// forward by d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{f: f, w: &waiter{ch: make(chan time.Time, 1)}}
+	f.schedule(t.w, d)
+	return t
+}
+
+// AfterFunc calls fn in its own goroutine once the fake time has moved
+// forward by d.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{f: f, w: &waiter{fn: fn}}
+	f.schedule(t.w, d)
+	return t
+}
+
+// NewTicker creates a Ticker that fires every d of fake time. Like
+// time.NewTicker it panics if d is not positive.
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	t := &fakeTicker{f: f, w: &waiter{ch: make(chan time.Time, 1), period: d}}
+	f.schedule(t.w, d)
+	return t
+}
+
+// WithTimeout returns a context that is done once the fake time has moved
+// forward by d, or when parent is done, whichever happens first.
+func (f *Fake) WithTimeout(parent context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	return f.WithDeadline(parent, f.Now().Add(d))
+}
+
+// WithDeadline returns a context that is done once the fake time reaches
+// deadline, or when parent is done, whichever happens first.
+func (f *Fake) WithDeadline(parent context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if cur, ok := parent.Deadline(); ok && cur.Before(deadline) {
+		// The parent expires first; its deadline governs.
+		return context.WithCancel(parent)
+	}
+	inner, cancelCause := context.WithCancelCause(parent)
+	ctx := &fakeContext{Context: inner, deadline: deadline, done: make(chan struct{}), cancelCause: cancelCause}
+	stopParent := context.AfterFunc(inner, func() { ctx.cancel(parent.Err()) })
+	d := deadline.Sub(f.Now())
+	if d <= 0 {
+		ctx.cancel(context.DeadlineExceeded)
+		return ctx, func() { stopParent(); ctx.cancel(context.Canceled) }
+	}
+	timer := f.AfterFunc(d, func() { ctx.cancel(context.DeadlineExceeded) })
+	return ctx, func() {
+		stopParent()
+		timer.Stop()
+		ctx.cancel(context.Canceled)
+	}
+}
+
+// Advance moves the fake time forward by d, firing everything that falls
+// due on the way. Channel sends never block: like the time package, a
+// ticker whose previous tick has not been received drops the new one.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	target := f.now.Add(d)
+	f.mu.Unlock()
+	f.Set(target)
+}
+
+// Set moves the fake time to t, firing everything that falls due on the
+// way. Setting a time in the past only changes what Now reports.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	for len(f.waiters) > 0 && !f.waiters[0].when.After(t) {
+		f.fireFirst()
+	}
+	f.now = t
+	f.cond.Broadcast()
+	f.mu.Unlock()
+}
+
+// Drive calls fn in a new goroutine and advances the clock for it until fn
+// returns. Whenever every goroutine started since Drive was called is
+// blocked, Drive moves the time to the earliest pending timer, ticker,
+// sleeper or deadline and fires that one alone, so goroutines due at the
+// same instant wake one at a time in the order they started waiting. Code
+// that sleeps for seconds thus finishes at once and, as long as its
+// goroutines only wait on each other and on the clock, in the same order
+// every run.
+//
+// A panic in fn is re-raised by Drive. Drive panics if fn's goroutines are
+// all blocked with nothing pending on the clock, which would otherwise
+// hang forever.
+func (f *Fake) Drive(fn func()) {
+	before := make(map[uint64]bool)
+	for _, g := range leakcheck.Snapshot() {
+		before[g.ID] = true
+	}
+
+	done := make(chan struct{})
+	var panicked any
+	go func() {
+		defer close(done)
+		defer func() { panicked = recover() }()
+		fn()
+	}()
+
+	for {
+		select {
+		case <-done:
+			if panicked != nil {
+				panic(panicked)
+			}
+			return
+		case <-time.After(drivePoll):
+		}
+		if !blocked(before) || f.step() {
+			continue
+		}
+		select {
+		case <-done:
+			// fn returned between the two checks.
+		default:
+			panic(fmt.Sprintf("clock: Drive: all goroutines are blocked and no timer is pending at %v", f.Now()))
+		}
+	}
+}
+
+// drivePoll is how often Drive checks whether the goroutines it drives
+// are blocked.
+const drivePoll = 100 * time.Microsecond
+
+// blocked reports whether every goroutine not in before is waiting for
+// another goroutine, a fake timer or I/O. Goroutines running, runnable,
+// sleeping on the real clock or held up by the runtime itself, e.g. in
+// "semacquire" while the garbage collector starts, are not.
+func blocked(before map[uint64]bool) bool {
+	for _, g := range leakcheck.Snapshot() {
+		if !before[g.ID] && !blockedStates[g.State] {
+			return false
+		}
+	}
+	return true
+}
+
+// blockedStates are the scheduler states of a goroutine that only another
+// goroutine, a channel timer or I/O can wake.
+var blockedStates = map[string]bool{
+	"chan receive":            true,
+	"chan receive (nil chan)": true,
+	"chan send":               true,
+	"chan send (nil chan)":    true,
+	"select":                  true,
+	"select (no cases)":       true,
+	"sync.Cond.Wait":          true,
+	"sync.Mutex.Lock":         true,
+	"sync.RWMutex.Lock":       true,
+	"sync.RWMutex.RLock":      true,
+	"sync.WaitGroup.Wait":     true,
+	"IO wait":                 true,
+}
+
+// step fires the earliest pending waiter alone and reports whether there
+// was one.
+func (f *Fake) step() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.waiters) == 0 {
+		return false
+	}
+	f.fireFirst()
+	f.cond.Broadcast()
+	return true
+}
+
+// fireFirst fires the earliest waiter, moving the time forward to it and
+// rescheduling tickers. Callers hold f.mu.
+func (f *Fake) fireFirst() {
+	w := f.waiters[0]
+	f.waiters = f.waiters[1:]
+	if w.when.After(f.now) {
+		f.now = w.when
+	}
+	if w.period > 0 {
+		f.insert(w, f.now.Add(w.period))
+	}
+	f.fire(w)
+}
+
+// Waiters reports how many timers, tickers, sleepers and deadlines are
+// pending on the clock.
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// BlockUntil waits until at least n timers, tickers, sleepers or
+// deadlines are pending on the clock. Call it before Advance so the
+// goroutines under test have reached their Sleep or select first.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// fire delivers w. Callers hold f.mu.
+func (f *Fake) fire(w *waiter) {
+	if w.fn != nil {
+		go w.fn()
+		return
+	}
+	select {
+	case w.ch <- f.now:
+	default:
+	}
+}
+
+// schedule arms w to fire after d, firing it at once if d is not positive.
+func (f *Fake) schedule(w *waiter, d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if d <= 0 && w.period == 0 {
+		f.fire(w)
+		return
+	}
+	f.insert(w, f.now.Add(d))
+	f.cond.Broadcast()
+}
+
+// insert adds w in (when, seq) order so waiters due at the same instant
+// fire in the order they were scheduled. Callers hold f.mu.
+func (f *Fake) insert(w *waiter, when time.Time) {
+	f.seq++
+	w.when, w.seq = when, f.seq
+	i := sort.Search(len(f.waiters), func(i int) bool {
+		o := f.waiters[i]
+		return o.when.After(when) || (o.when.Equal(when) && o.seq > w.seq)
+	})
+	f.waiters = append(f.waiters, nil)
+	copy(f.waiters[i+1:], f.waiters[i:])
+	f.waiters[i] = w
+}
+
+// drain discards a value fired on w's channel but not yet received, so
+// that, as with the time package since Go 1.23, no stale value arrives
+// after Stop or Reset. It reports whether there was one.
+func (w *waiter) drain() bool {
+	select {
+	case <-w.ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// remove unschedules w and reports whether it was pending. Callers hold f.mu.
+func (f *Fake) remove(w *waiter) bool {
+	for i, o := range f.waiters {
+		if o == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	f *Fake
+	w *waiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.w.ch }
+
+// Stop and Reset count a fired but unreceived value as still pending,
+// like a Go 1.23 timer whose channel is unbuffered.
+func (t *fakeTimer) Stop() bool {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	removed := t.f.remove(t.w)
+	drained := t.w.drain()
+	return removed || drained
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	active := t.Stop()
+	t.f.schedule(t.w, d)
+	return active
+}
+
+type fakeTicker struct {
+	f *Fake
+	w *waiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.w.ch }
+
+func (t *fakeTicker) Stop() {
+	t.f.mu.Lock()
+	defer t.f.mu.Unlock()
+	t.f.remove(t.w)
+	t.w.drain()
+}
+
+func (t *fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("clock: non-positive interval for Ticker.Reset")
+	}
+	t.f.mu.Lock()
+	t.f.remove(t.w)
+	t.w.drain()
+	t.w.period = d
+	t.f.mu.Unlock()
+	t.f.schedule(t.w, d)
+}
+
+// fakeContext is a context whose deadline is measured on a Fake clock.
+//
+// It wraps a context from context.WithCancelCause so that context.Cause
+// reports why it ended, but keeps its own Done channel: contexts derived
+// from it then copy its Err, telling a missed deadline apart from a
+// cancel, instead of the wrapped context's plain context.Canceled.
+type fakeContext struct {
+	context.Context
+	deadline    time.Time
+	done        chan struct{}
+	cancelCause context.CancelCauseFunc
+
+	mu  sync.Mutex
+	err error
+}
+
+func (c *fakeContext) Deadline() (time.Time, bool) { return c.deadline, true }
+func (c *fakeContext) Done() <-chan struct{}       { return c.done }
+
+func (c *fakeContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *fakeContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	c.cancelCause(err)
+	close(c.done)
+}
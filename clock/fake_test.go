@@ -0,0 +1,263 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// received returns the value waiting on ch, failing t if there is none.
+func received(t *testing.T, ch <-chan time.Time) time.Time {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	default:
+		t.Fatal("no value on the channel")
+		return time.Time{}
+	}
+}
+
+// empty fails t if a value is waiting on ch.
+func empty(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case v := <-ch:
+		t.Fatalf("unexpected value %v on the channel", v)
+	default:
+	}
+}
+
+func TestAdvanceFiresDueWaiters(t *testing.T) {
+	f := NewFake(start)
+	t3, t1, t2 := f.NewTimer(3*time.Second), f.NewTimer(time.Second), f.NewTimer(2*time.Second)
+
+	f.Advance(2 * time.Second)
+	if got := received(t, t1.C()); !got.Equal(start.Add(time.Second)) {
+		t.Errorf("1s timer fired at %v, want %v", got, start.Add(time.Second))
+	}
+	if got := received(t, t2.C()); !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("2s timer fired at %v, want %v", got, start.Add(2*time.Second))
+	}
+	empty(t, t3.C())
+	if n := f.Waiters(); n != 1 {
+		t.Errorf("Waiters() = %d, want 1", n)
+	}
+	if got := f.Now(); !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(2*time.Second))
+	}
+}
+
+func TestTickerDropsUnreceivedTicks(t *testing.T) {
+	f := NewFake(start)
+	tk := f.NewTicker(time.Second)
+	defer tk.Stop()
+
+	f.Advance(3 * time.Second)
+	if got := received(t, tk.C()); !got.Equal(start.Add(time.Second)) {
+		t.Errorf("tick at %v, want the first one at %v", got, start.Add(time.Second))
+	}
+	empty(t, tk.C())
+
+	f.Advance(time.Second)
+	if got := received(t, tk.C()); !got.Equal(start.Add(4 * time.Second)) {
+		t.Errorf("tick at %v, want %v", got, start.Add(4*time.Second))
+	}
+}
+
+func TestTimerStopDrainsFiredValue(t *testing.T) {
+	f := NewFake(start)
+	tm := f.NewTimer(time.Second)
+	f.Advance(time.Second)
+
+	if !tm.Stop() {
+		t.Error("Stop() = false for a timer whose value was never received")
+	}
+	empty(t, tm.C())
+	if tm.Stop() {
+		t.Error("second Stop() = true")
+	}
+}
+
+func TestTimerResetDrainsFiredValue(t *testing.T) {
+	f := NewFake(start)
+	tm := f.NewTimer(time.Second)
+	f.Advance(time.Second)
+
+	if !tm.Reset(time.Second) {
+		t.Error("Reset() = false for a timer whose value was never received")
+	}
+	empty(t, tm.C())
+	f.Advance(time.Second)
+	if got := received(t, tm.C()); !got.Equal(start.Add(2 * time.Second)) {
+		t.Errorf("reset timer fired at %v, want %v", got, start.Add(2*time.Second))
+	}
+}
+
+func TestTickerResetDrainsFiredValue(t *testing.T) {
+	f := NewFake(start)
+	tk := f.NewTicker(time.Second)
+	defer tk.Stop()
+	f.Advance(time.Second)
+
+	tk.Reset(2 * time.Second)
+	empty(t, tk.C())
+	f.Advance(time.Second)
+	empty(t, tk.C())
+	f.Advance(time.Second)
+	if got := received(t, tk.C()); !got.Equal(start.Add(3 * time.Second)) {
+		t.Errorf("tick at %v, want %v", got, start.Add(3*time.Second))
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	f := NewFake(start)
+	ctx, cancel := f.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+
+	if d, ok := ctx.Deadline(); !ok || !d.Equal(start.Add(time.Second)) {
+		t.Errorf("Deadline() = %v, %v; want %v, true", d, ok, start.Add(time.Second))
+	}
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("Err() = %v before the deadline", err)
+	}
+
+	f.Advance(time.Second)
+	<-ctx.Done()
+	<-child.Done()
+	if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := context.Cause(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Cause() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := child.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("derived context Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestContextPastDeadline(t *testing.T) {
+	f := NewFake(start)
+	ctx, cancel := f.WithDeadline(context.Background(), start.Add(-time.Second))
+	defer cancel()
+
+	<-ctx.Done()
+	if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Err() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	f := NewFake(start)
+	ctx, cancel := f.WithTimeout(context.Background(), time.Second)
+	cancel()
+
+	<-ctx.Done()
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+	if err := context.Cause(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Cause() = %v, want %v", err, context.Canceled)
+	}
+	if n := f.Waiters(); n != 0 {
+		t.Errorf("Waiters() = %d after cancel, want 0", n)
+	}
+	f.Advance(time.Second)
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v after the deadline passed, want it to stay %v", err, context.Canceled)
+	}
+}
+
+func TestContextParentCause(t *testing.T) {
+	f := NewFake(start)
+	errShutdown := errors.New("shutting down")
+	parent, cancelParent := context.WithCancelCause(context.Background())
+	ctx, cancel := f.WithTimeout(parent, time.Second)
+	defer cancel()
+
+	cancelParent(errShutdown)
+	<-ctx.Done()
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Err() = %v, want %v", err, context.Canceled)
+	}
+	if err := context.Cause(ctx); !errors.Is(err, errShutdown) {
+		t.Errorf("Cause() = %v, want the parent's cause %v", err, errShutdown)
+	}
+}
+
+func TestDriveRunsSleepsInstantly(t *testing.T) {
+	f := NewFake(start)
+	began := time.Now()
+	f.Drive(func() {
+		f.Sleep(time.Hour)
+		<-f.After(time.Hour)
+	})
+
+	if got := f.Now(); !got.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("Now() = %v, want %v", got, start.Add(2*time.Hour))
+	}
+	if elapsed := time.Since(began); elapsed > 5*time.Second {
+		t.Errorf("two fake hours took %v", elapsed)
+	}
+}
+
+// Goroutines due at the same instant wake in the order their timers were
+// created, one at a time, however the scheduler orders the goroutines.
+func TestDriveWakesSameInstantInOrder(t *testing.T) {
+	for range 20 {
+		f := NewFake(start)
+		var (
+			mu    sync.Mutex
+			order []int
+		)
+		f.Drive(func() {
+			var wg sync.WaitGroup
+			for i := range 5 {
+				tm := f.NewTimer(time.Second)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					<-tm.C()
+					mu.Lock()
+					order = append(order, i)
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+		})
+		if want := []int{0, 1, 2, 3, 4}; !slices.Equal(order, want) {
+			t.Fatalf("woke in order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestDriveRepanics(t *testing.T) {
+	f := NewFake(start)
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("recovered %v, want boom", r)
+		}
+	}()
+	f.Drive(func() { panic("boom") })
+}
+
+func TestDriveReportsDeadlock(t *testing.T) {
+	f := NewFake(start)
+	release := make(chan struct{})
+	defer close(release)
+	defer func() {
+		r, _ := recover().(string)
+		if !strings.Contains(r, "no timer is pending") {
+			t.Errorf("recovered %q, want a deadlock report", r)
+		}
+	}()
+	f.Drive(func() { <-release })
+}
@@ -0,0 +1,25 @@
+// Command 1-basic-goroutine runs the 1-basic-goroutine/ learning module.
+package main
+
+import (
+	"flag"
+	"os"
+
+	basicgoroutine "go-concurrency/1-basic-goroutine"
+	"go-concurrency/clock"
+	"go-concurrency/lifecycle"
+)
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "log goroutine lifecycle events as JSON")
+	flag.Parse()
+
+	// Goroutines report what they do through lifecycle events instead of
+	// printing, so every line carries its goroutine label and timestamp
+	output := lifecycle.WithText(os.Stdout)
+	if *jsonOutput {
+		output = lifecycle.WithJSON(os.Stdout)
+	}
+
+	basicgoroutine.Run(os.Stdout, clock.Real(), output)
+}
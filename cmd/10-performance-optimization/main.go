@@ -0,0 +1,12 @@
+// Command 10-performance-optimization runs the 10-performance-optimization/ learning module.
+package main
+
+import (
+	"os"
+
+	performance "go-concurrency/10-performance-optimization"
+)
+
+func main() {
+	performance.Run(os.Stdout)
+}
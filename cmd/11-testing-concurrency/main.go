@@ -0,0 +1,12 @@
+// Command 11-testing-concurrency runs the 11-testing-concurrency/ learning module.
+package main
+
+import (
+	"os"
+
+	testingconcurrency "go-concurrency/11-testing-concurrency"
+)
+
+func main() {
+	testingconcurrency.Run(os.Stdout)
+}
@@ -0,0 +1,12 @@
+// Command 12-lock-free-programming runs the 12-lock-free-programming/ learning module.
+package main
+
+import (
+	"os"
+
+	lockfree "go-concurrency/12-lock-free-programming"
+)
+
+func main() {
+	lockfree.Run(os.Stdout)
+}
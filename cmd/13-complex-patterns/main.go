@@ -0,0 +1,12 @@
+// Command 13-complex-patterns runs the 13-complex-patterns/ learning module.
+package main
+
+import (
+	"os"
+
+	complexpatterns "go-concurrency/13-complex-patterns"
+)
+
+func main() {
+	complexpatterns.Run(os.Stdout)
+}
@@ -0,0 +1,12 @@
+// Command 14-system-design runs the 14-system-design/ learning module.
+package main
+
+import (
+	"os"
+
+	systemdesign "go-concurrency/14-system-design"
+)
+
+func main() {
+	systemdesign.Run(os.Stdout)
+}
@@ -0,0 +1,12 @@
+// Command 15-advanced-topics runs the 15-advanced-topics/ learning module.
+package main
+
+import (
+	"os"
+
+	advancedtopics "go-concurrency/15-advanced-topics"
+)
+
+func main() {
+	advancedtopics.Run(os.Stdout)
+}
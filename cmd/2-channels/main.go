@@ -0,0 +1,82 @@
+// Command 2-channels runs the 2-channels/ learning module.
+//
+// With -trace dir it runs each example on its own instead, records the
+// operations on the channels the example creates, prints them as a text
+// sequence diagram after the example's output, and writes the diagram to
+// dir as <n>.txt, <n>.mmd (Mermaid) and <n>.svg, where n is the example's
+// number.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	channels "go-concurrency/2-channels"
+	"go-concurrency/catalog"
+	"go-concurrency/chantrace"
+	"go-concurrency/clock"
+)
+
+func main() {
+	dir := flag.String("trace", "", "write a sequence diagram of each example to this directory")
+	flag.Parse()
+
+	if *dir == "" {
+		channels.Run(os.Stdout, clock.Real())
+		return
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	m, _ := catalog.Find("2-channels")
+	for _, s := range m.Sections {
+		rec := chantrace.NewRecorder()
+		rec.Name("main")
+		traced[s.Number](os.Stdout, rec)
+
+		fmt.Printf("\nSequence diagram of %s:\n", s)
+		rec.WriteText(os.Stdout)
+		base := filepath.Join(*dir, strconv.Itoa(s.Number))
+		for ext, write := range map[string]func(io.Writer) error{
+			".txt": rec.WriteText,
+			".mmd": rec.WriteMermaid,
+			".svg": rec.WriteSVG,
+		} {
+			if err := writeFile(base+ext, write); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	fmt.Println("\nDiagrams written to", *dir)
+}
+
+// traced runs each example recording its channels on a Recorder, by
+// section number.
+var traced = map[int]func(io.Writer, *chantrace.Recorder){
+	1: channels.BasicChannel,
+	2: channels.BufferedChannel,
+	3: func(w io.Writer, rec *chantrace.Recorder) { channels.SelectStatement(w, clock.Real(), rec) },
+	4: channels.ChannelToolkit,
+	5: channels.Broadcast,
+	6: channels.ClosingReasons,
+	7: channels.PrioritySelect,
+	8: func(w io.Writer, rec *chantrace.Recorder) { channels.RequestReply(w, clock.Real(), rec) },
+}
+
+func writeFile(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
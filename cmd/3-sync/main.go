@@ -0,0 +1,13 @@
+// Command 3-sync runs the 3-sync/ learning module.
+package main
+
+import (
+	"os"
+
+	syncbasics "go-concurrency/3-sync"
+	"go-concurrency/clock"
+)
+
+func main() {
+	syncbasics.Run(os.Stdout, clock.Real())
+}
@@ -0,0 +1,13 @@
+// Command 4-context runs the 4-context/ learning module.
+package main
+
+import (
+	"os"
+
+	contexts "go-concurrency/4-context"
+	"go-concurrency/clock"
+)
+
+func main() {
+	contexts.Run(os.Stdout, clock.Real())
+}
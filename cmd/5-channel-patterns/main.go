@@ -0,0 +1,12 @@
+// Command 5-channel-patterns runs the 5-channel-patterns/ learning module.
+package main
+
+import (
+	"os"
+
+	channelpatterns "go-concurrency/5-channel-patterns"
+)
+
+func main() {
+	channelpatterns.Run(os.Stdout)
+}
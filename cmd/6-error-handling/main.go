@@ -0,0 +1,12 @@
+// Command 6-error-handling runs the 6-error-handling/ learning module.
+package main
+
+import (
+	"os"
+
+	errorhandling "go-concurrency/6-error-handling"
+)
+
+func main() {
+	errorhandling.Run(os.Stdout)
+}
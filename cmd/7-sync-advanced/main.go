@@ -0,0 +1,12 @@
+// Command 7-sync-advanced runs the 7-sync-advanced/ learning module.
+package main
+
+import (
+	"os"
+
+	syncadvanced "go-concurrency/7-sync-advanced"
+)
+
+func main() {
+	syncadvanced.Run(os.Stdout)
+}
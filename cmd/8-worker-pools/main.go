@@ -0,0 +1,56 @@
+// Command 8-worker-pools runs the 8-worker-pools/ learning module.
+//
+// With -metrics addr it runs each example on its own instead, serves the
+// instrumented channels of example 3 at http://addr/metrics in the
+// Prometheus text format, and keeps serving after the examples finish
+// until interrupted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+
+	workerpools "go-concurrency/8-worker-pools"
+	"go-concurrency/catalog"
+	"go-concurrency/chanmetrics"
+	"go-concurrency/clock"
+)
+
+func main() {
+	addr := flag.String("metrics", "", "serve channel metrics on this address, e.g. localhost:9090")
+	flag.Parse()
+
+	if *addr == "" {
+		workerpools.Run(os.Stdout, clock.Real())
+		return
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	reg := chanmetrics.NewRegistry()
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", reg)
+	go http.Serve(ln, mux)
+	fmt.Printf("Serving channel metrics on http://%s/metrics\n", ln.Addr())
+
+	m, _ := catalog.Find("8-worker-pools")
+	for _, s := range m.Sections {
+		if s.Number == 3 {
+			workerpools.InstrumentedWorkerPool(os.Stdout, clock.Real(), reg)
+		} else {
+			s.Run(os.Stdout)
+		}
+	}
+
+	fmt.Println("Examples finished; still serving metrics, press Ctrl-C to exit")
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+	<-stop
+}
@@ -0,0 +1,12 @@
+// Command 9-pipeline-patterns runs the 9-pipeline-patterns/ learning module.
+package main
+
+import (
+	"os"
+
+	pipelines "go-concurrency/9-pipeline-patterns"
+)
+
+func main() {
+	pipelines.Run(os.Stdout)
+}
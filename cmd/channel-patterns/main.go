@@ -0,0 +1,12 @@
+// Command channel-patterns runs the channel-patterns/ learning module.
+package main
+
+import (
+	"os"
+
+	channelpatterns "go-concurrency/channel-patterns"
+)
+
+func main() {
+	channelpatterns.Run(os.Stdout)
+}
@@ -0,0 +1,21 @@
+// Command chanowner reports channels closed by functions that do not own
+// them, closed inside loops, sent on after close, or declared with a wider
+// direction than their use.
+//
+// Usage:
+//
+//	go build -o chanowner ./cmd/chanowner
+//	go vet -vettool=$(pwd)/chanowner ./...
+//
+// or run it directly with go run ./cmd/chanowner ./... , which runs go vet as
+// above with the command itself as the tool.
+package main
+
+import (
+	"go-concurrency/analysis/chanowner"
+	"go-concurrency/internal/vettool"
+)
+
+func main() {
+	vettool.Main(chanowner.Analyzer)
+}
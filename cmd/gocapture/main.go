@@ -0,0 +1,20 @@
+// Command gocapture reports goroutines that capture variables the enclosing
+// function mutates after starting them.
+//
+// Usage:
+//
+//	go build -o gocapture ./cmd/gocapture
+//	go vet -vettool=$(pwd)/gocapture ./...
+//
+// or run it directly with go run ./cmd/gocapture ./... , which runs go vet as
+// above with the command itself as the tool.
+package main
+
+import (
+	"go-concurrency/analysis/gocapture"
+	"go-concurrency/internal/vettool"
+)
+
+func main() {
+	vettool.Main(gocapture.Analyzer)
+}
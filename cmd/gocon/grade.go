@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go-concurrency/catalog"
+	"go-concurrency/grading"
+)
+
+// raceExitCode is the exit status a race-enabled child uses when the race
+// detector fires, so the parent can tell races from other failures.
+const raceExitCode = 66
+
+func grade(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("grade", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 5*time.Second, "maximum time each check may run")
+	race := fs.Bool("race", false, "grade every item in a separate process built with the race detector")
+	item := fs.Int("item", -1, "grade only the item at this index and print it as JSON (used by -race)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("grade needs a module")
+	}
+
+	m, ok := catalog.Find(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown module %q", fs.Arg(0))
+	}
+	items, ok := grading.Items(m.Dir)
+	if !ok {
+		return fmt.Errorf("module %s has no exercises to grade", m.Dir)
+	}
+	opts := grading.Options{Timeout: *timeout}
+
+	if *item >= 0 {
+		if *item >= len(items) {
+			return fmt.Errorf("module %s has no item %d", m.Dir, *item)
+		}
+		return json.NewEncoder(w).Encode(grading.Grade(items[*item], opts))
+	}
+
+	var results []grading.ItemResult
+	if *race && !raceEnabled {
+		var err error
+		if results, err = gradeWithRace(m.Dir, items, *timeout); err != nil {
+			return err
+		}
+	} else {
+		for _, it := range items {
+			results = append(results, grading.Grade(it, opts))
+		}
+	}
+	return scorecard(w, m.Dir, results, *race)
+}
+
+// gradeWithRace builds a race-enabled gocon once and grades each item in
+// its own child process, so a race report is attributed to the item that
+// triggered it.
+func gradeWithRace(dir string, items []grading.Item, timeout time.Duration) ([]grading.ItemResult, error) {
+	tmp, err := os.MkdirTemp("", "gocon-race")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	bin, err := buildWithRace(tmp)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []grading.ItemResult
+	for i, it := range items {
+		if len(it.Checks) == 0 {
+			results = append(results, grading.Grade(it, grading.Options{}))
+			continue
+		}
+
+		var out bytes.Buffer
+		child := exec.Command(bin, "grade", "-item", strconv.Itoa(i), "-timeout", timeout.String(), dir)
+		child.Env = append(os.Environ(), fmt.Sprintf("GORACE=halt_on_error=1 exitcode=%d", raceExitCode))
+		child.Stdout, child.Stderr = &out, os.Stderr
+
+		err := child.Run()
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == raceExitCode {
+			results = append(results, grading.ItemResult{Title: it.Title, Status: grading.Fail, Race: true})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("grading %q: %w", it.Title, err)
+		}
+
+		var res grading.ItemResult
+		if err := json.Unmarshal(out.Bytes(), &res); err != nil {
+			return nil, fmt.Errorf("grading %q: %w", it.Title, err)
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func scorecard(w io.Writer, dir string, results []grading.ItemResult, raceChecked bool) error {
+	fmt.Fprintf(w, "=== %s scorecard ===\n", dir)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ITEM\tSTATUS\tCHECKS\tLEAKS\tRACE")
+
+	var graded, passed, failed int
+	for _, r := range results {
+		checks, leaks, race := "-", "-", "-"
+		if r.Status != grading.Manual {
+			graded++
+		}
+		if r.Status != grading.Manual && !r.Race {
+			ok, implemented, leaked := 0, 0, 0
+			for _, c := range r.Checks {
+				if c.Passed() {
+					ok++
+				}
+				if c.Implemented {
+					implemented++
+				}
+				leaked += c.Leaked
+			}
+			checks = fmt.Sprintf("%d/%d", ok, len(r.Checks))
+			if implemented > 0 {
+				leaks = strconv.Itoa(leaked)
+				if raceChecked {
+					race = "clean"
+				}
+			}
+		}
+		if r.Race {
+			race = "RACE"
+		}
+		switch r.Status {
+		case grading.Pass:
+			passed++
+		case grading.Fail:
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", r.Title, r.Status, checks, leaks, race)
+	}
+	tw.Flush()
+
+	for _, r := range results {
+		if r.Race {
+			fmt.Fprintf(w, "  %s: data race detected, see the report above\n", r.Title)
+		}
+		for _, c := range r.Checks {
+			if !c.Implemented || c.Passed() {
+				continue
+			}
+			reason := c.Err
+			if c.Leaked > 0 {
+				if reason != "" {
+					reason += "; "
+				}
+				reason += fmt.Sprintf("leaked %d goroutine(s)", c.Leaked)
+			}
+			fmt.Fprintf(w, "  %s: %s: %s\n", r.Title, c.Name, reason)
+		}
+	}
+
+	fmt.Fprintf(w, "Score: %d of %d graded items pass (%d for manual review)\n", passed, graded, len(results)-graded)
+	if failed > 0 {
+		return fmt.Errorf("%d item(s) failing", failed)
+	}
+	return nil
+}
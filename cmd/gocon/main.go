@@ -0,0 +1,292 @@
+// Command gocon lists and runs the learning modules.
+//
+// Usage:
+//
+//	gocon list
+//	gocon run [-timeout d] [-race] <module> [section]
+//	gocon grade [-timeout d] [-race] <module>
+//
+// A module is named by its number or directory ("2" or "2-channels"). Each
+// section runs in a process of its own, which is killed if the section
+// outlasts its timeout, and is checked for goroutines it leaves behind.
+// With -race, gocon builds a copy of itself with the race detector to run
+// the sections in.
+//
+// grade checks the solutions assigned to a module's Exercises variable and
+// prints a scorecard per TODO item.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"go-concurrency/catalog"
+	"go-concurrency/leakcheck"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "list":
+		err = list(os.Stdout, os.Args[2:])
+	case "run":
+		err = run(os.Stdout, os.Args[2:])
+	case "grade":
+		err = grade(os.Stdout, os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", os.Args[1])
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		os.Exit(exitErr.ExitCode())
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "gocon:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage:
+  gocon list [module]                                list modules, or the sections of one module
+  gocon run [-timeout d] [-race] <module> [section]  run a module or one of its sections
+  gocon grade [-timeout d] [-race] <module>          grade your solutions to a module's exercises`)
+}
+
+func list(w io.Writer, args []string) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	if len(args) > 0 {
+		m, ok := catalog.Find(args[0])
+		if !ok {
+			return fmt.Errorf("unknown module %q", args[0])
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", m.Dir, m.Title)
+		if len(m.Sections) == 0 {
+			fmt.Fprintln(tw, "  (exercises only, no runnable sections)")
+		}
+		for _, s := range m.Sections {
+			fmt.Fprintf(tw, "  %s\n", s)
+		}
+		return nil
+	}
+
+	for _, m := range catalog.Modules {
+		fmt.Fprintf(tw, "%s\t%s\t%d sections\n", m.Dir, m.Title, len(m.Sections))
+	}
+	return nil
+}
+
+// result is the outcome of one section.
+type result struct {
+	name     string
+	elapsed  time.Duration
+	timedOut bool
+	race     bool
+	err      error // the section's process failed
+	leaks    *leakcheck.Report
+}
+
+func run(w io.Writer, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 30*time.Second, "maximum time each section may run")
+	race := fs.Bool("race", false, "run the sections in a copy of gocon built with the race detector")
+	grace := fs.Duration("leak-grace", 500*time.Millisecond, "time goroutines get to exit after a section returns")
+	report := fs.String("report", "", "run the one section given in this process and write its leak report as JSON to this file (used to run each section in its own process)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return errors.New("run needs a module and optionally a section")
+	}
+
+	m, ok := catalog.Find(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("unknown module %q", fs.Arg(0))
+	}
+
+	sections := m.Sections
+	if fs.NArg() == 2 {
+		n, err := strconv.Atoi(fs.Arg(1))
+		if err != nil {
+			return fmt.Errorf("invalid section %q", fs.Arg(1))
+		}
+		s, ok := m.Section(n)
+		if !ok {
+			return fmt.Errorf("module %s has no section %d", m.Dir, n)
+		}
+		sections = []catalog.Section{s}
+	}
+	if len(sections) == 0 {
+		// Exercise-only modules have nothing to time or check.
+		m.Run(w)
+		return nil
+	}
+	if *report != "" {
+		if fs.NArg() != 2 {
+			return errors.New("run -report needs a section")
+		}
+		return runChild(w, sections[0], *grace, *report)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if *race && !raceEnabled {
+		tmp, err := os.MkdirTemp("", "gocon-race")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+		if exe, err = buildWithRace(tmp); err != nil {
+			return err
+		}
+	}
+
+	var results []result
+	for _, s := range sections {
+		results = append(results, runSection(w, exe, m.Dir, s, *timeout, *grace))
+	}
+	return summarize(w, results)
+}
+
+// runSection runs s in a child process, exe run -report, and kills it
+// after timeout. A section that times out thus stops with its process, and
+// the goroutines of one section can never be blamed on another.
+func runSection(w io.Writer, exe, dir string, s catalog.Section, timeout, grace time.Duration) result {
+	name := dir + " " + s.String()
+	fmt.Fprintf(w, "\n--- %s ---\n", name)
+
+	f, err := os.CreateTemp("", "gocon-leaks")
+	if err != nil {
+		return result{name: name, err: err}
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	child := exec.CommandContext(ctx, exe, "run", "-leak-grace", grace.String(), "-report", f.Name(), dir, strconv.Itoa(s.Number))
+	// The race detector otherwise sleeps a second before exiting, which
+	// would count towards the section's time
+	child.Env = append(os.Environ(), fmt.Sprintf("GORACE=halt_on_error=1 exitcode=%d atexit_sleep_ms=0", raceExitCode))
+	child.Stdout, child.Stderr = w, os.Stderr
+	// Do not wait on goroutines copying output from anything the killed
+	// child may have started
+	child.WaitDelay = time.Second
+
+	start := time.Now()
+	err = child.Run()
+	r := result{name: name, elapsed: time.Since(start)}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		r.timedOut = true
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == raceExitCode:
+		r.race = true
+	case err != nil:
+		r.err = err
+	default:
+		r.leaks = new(leakcheck.Report)
+		data, err := os.ReadFile(f.Name())
+		if err == nil {
+			err = json.Unmarshal(data, r.leaks)
+		}
+		if err != nil {
+			r.err = fmt.Errorf("reading the leak report: %w", err)
+		}
+	}
+	return r
+}
+
+// runChild runs s in this process, as the child started by runSection,
+// and writes what leakcheck found to path.
+func runChild(w io.Writer, s catalog.Section, grace time.Duration, path string) error {
+	report := leakcheck.Check(func() { s.Run(w) }, leakcheck.WithTimeout(grace))
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func summarize(w io.Writer, results []result) error {
+	fmt.Fprintln(w, "\n=== Summary ===")
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	failed := 0
+	for _, r := range results {
+		status := "ok"
+		switch {
+		case r.timedOut:
+			status = "TIMEOUT"
+		case r.race:
+			status = "RACE"
+		case r.err != nil:
+			status = "FAILED: " + r.err.Error()
+		case !r.leaks.OK():
+			status = fmt.Sprintf("LEAKED %d goroutine(s)", len(r.leaks.Leaked))
+		}
+		if status != "ok" {
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%v\t%s\n", r.name, r.elapsed.Round(time.Millisecond), status)
+	}
+	tw.Flush()
+
+	for _, r := range results {
+		if r.race {
+			fmt.Fprintf(w, "\n%s: data race detected, see the report above\n", r.name)
+		}
+		if r.leaks != nil && !r.leaks.OK() {
+			fmt.Fprintf(w, "\n%s: %s\n", r.name, r.leaks)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d section(s) failed", failed, len(results))
+	}
+	return nil
+}
+
+// buildWithRace builds gocon with the race detector into dir and returns
+// the binary's path. It builds from the source this gocon was built from,
+// found through its file names, so it works from any directory as long as
+// that source is still there.
+func buildWithRace(dir string) (string, error) {
+	_, file, _, ok := runtime.Caller(0)
+	if _, err := os.Stat(file); !ok || err != nil {
+		return "", errors.New("cannot find the gocon source to build it with -race; run go run -race ./cmd/gocon from the go-concurrency module instead")
+	}
+
+	bin := filepath.Join(dir, "gocon")
+	build := exec.Command("go", "build", "-race", "-o", bin, ".")
+	build.Dir = filepath.Dir(file)
+	build.Stdout, build.Stderr = os.Stderr, os.Stderr
+	if err := build.Run(); err != nil {
+		return "", fmt.Errorf("building gocon with -race: %w", err)
+	}
+	return bin, nil
+}
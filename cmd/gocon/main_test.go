@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/catalog"
+)
+
+// TestMain adds a module whose sections misbehave on purpose, and lets the
+// test binary stand in for gocon when runSection starts it as a child.
+func TestMain(m *testing.M) {
+	catalog.Modules = append(catalog.Modules, catalog.Module{
+		Dir:   "test-sections",
+		Title: "Sections that misbehave",
+		Run:   func(w io.Writer) {},
+		Sections: []catalog.Section{
+			{Number: 1, Title: "Prints", Run: func(w io.Writer) { fmt.Fprintln(w, "hello from section 1") }},
+			{Number: 2, Title: "Leaks", Run: func(w io.Writer) {
+				go func() { <-make(chan int) }()
+			}},
+			{Number: 3, Title: "Hangs", Run: func(w io.Writer) {
+				fmt.Fprintln(w, "hanging")
+				time.Sleep(time.Hour)
+			}},
+			{Number: 4, Title: "Panics", Run: func(w io.Writer) { panic("section 4") }},
+		},
+	})
+	if os.Getenv("GOCON_TEST_CHILD") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Setenv("GOCON_TEST_CHILD", "1")
+	os.Exit(m.Run())
+}
+
+func TestList(t *testing.T) {
+	var b strings.Builder
+	if err := list(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if out := b.String(); !regexp.MustCompile(`\n2-channels +Channel Fundamentals +8 sections\n(.*\n)*test-sections +Sections that misbehave +4 sections\n$`).MatchString(out) {
+		t.Errorf("list output lacks the modules:\n%s", out)
+	}
+
+	b.Reset()
+	if err := list(&b, []string{"test-sections"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "test-sections  Sections that misbehave\n  1. Prints\n  2. Leaks\n  3. Hangs\n  4. Panics\n"
+	if got := b.String(); got != want {
+		t.Errorf("list test-sections =\n%s\nwant\n%s", got, want)
+	}
+
+	if err := list(io.Discard, []string{"nope"}); err == nil || !strings.Contains(err.Error(), `unknown module "nope"`) {
+		t.Errorf("list nope: %v, want an unknown module error", err)
+	}
+}
+
+func TestRunArguments(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{nil, "run needs a module"},
+		{[]string{"test-sections", "1", "2"}, "run needs a module"},
+		{[]string{"nope"}, `unknown module "nope"`},
+		{[]string{"test-sections", "one"}, `invalid section "one"`},
+		{[]string{"test-sections", "9"}, "module test-sections has no section 9"},
+		{[]string{"-report", os.DevNull, "test-sections"}, "run -report needs a section"},
+	} {
+		if err := run(io.Discard, tt.args); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run %q: %v, want an error containing %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+// Each section runs in its own process: the hanging one is killed at the
+// timeout, and the goroutine one section leaks is blamed on it alone.
+func TestRunSections(t *testing.T) {
+	var b strings.Builder
+	err := run(&b, []string{"-timeout", "1s", "-leak-grace", "100ms", "test-sections"})
+	out := b.String()
+	if err == nil || err.Error() != "3 of 4 section(s) failed" {
+		t.Errorf("run test-sections: %v, want 3 of 4 section(s) failed", err)
+	}
+
+	for _, want := range []string{
+		"\n--- test-sections 1. Prints ---\nhello from section 1\n",
+		"\n--- test-sections 3. Hangs ---\nhanging\n",
+		"\n=== Summary ===\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	summary := out[strings.Index(out, "=== Summary ==="):]
+	for _, want := range []string{
+		`test-sections 1\. Prints +\d+ms +ok\n`,
+		`test-sections 2\. Leaks +\d+ms +LEAKED 1 goroutine\(s\)\n`,
+		`test-sections 3\. Hangs +1\.\d+s +TIMEOUT\n`,
+		`test-sections 4\. Panics +\d+ms +FAILED: exit status 2\n`,
+		`test-sections 2\. Leaks: 1 leaked goroutine\(s\):\n  goroutine \d+ \[chan receive\] in go-concurrency/cmd/gocon\.TestMain\.func\d+\.1, created by go-concurrency/cmd/gocon\.TestMain\.func\d+ `,
+	} {
+		if !regexp.MustCompile(want).MatchString(summary) {
+			t.Errorf("summary does not match %q:\n%s", want, summary)
+		}
+	}
+}
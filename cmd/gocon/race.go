@@ -0,0 +1,6 @@
+//go:build race
+
+package main
+
+// raceEnabled reports whether gocon was built with the race detector.
+const raceEnabled = true
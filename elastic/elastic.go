@@ -0,0 +1,219 @@
+// Package elastic provides an unbounded channel: sends on In never wait for
+// a receiver, because values are held in a ring buffer that grows as
+// needed and shrinks again once it drains.
+//
+// A forwarding goroutine owned by the Chan moves values from In to Out.
+// Producers close In when they are done; Out is closed once every buffered
+// value has been received. Memory is the only limit, so ingestors that
+// cannot trust their consumers should set a soft cap with WithSoftCap.
+package elastic
+
+import "sync/atomic"
+
+// Policy decides what a Chan does with a send once its soft cap is reached.
+type Policy int
+
+const (
+	// Block stops accepting sends on In until a value is received from Out,
+	// so In behaves like a full buffered channel.
+	Block Policy = iota
+	// DropNewest accepts and discards the new value.
+	DropNewest
+	// DropOldest discards the oldest buffered value to make room.
+	DropOldest
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Block:
+		return "block"
+	case DropNewest:
+		return "drop-newest"
+	case DropOldest:
+		return "drop-oldest"
+	}
+	return "unknown"
+}
+
+// Chan is an unbounded channel of T values. Create one with New.
+type Chan[T any] struct {
+	in   chan T
+	out  chan T
+	opts options
+
+	len     atomic.Int64
+	dropped atomic.Int64
+	peak    atomic.Int64
+}
+
+// Option configures a Chan.
+type Option func(*options)
+
+type options struct {
+	initial   int
+	softCap   int // 0 means unbounded
+	policy    Policy
+	highWater int
+	onHigh    func(n int)
+}
+
+// WithInitialCapacity sets the starting size of the ring buffer, and the
+// size it shrinks back to. The default is 16.
+func WithInitialCapacity(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.initial = n
+		}
+	}
+}
+
+// WithHighWater calls fn with the buffered length each time it rises to
+// mark. A DropOldest send at a soft cap equal to mark keeps the length
+// where it was, so it does not call fn again. fn runs on the forwarding goroutine, so it must return promptly
+// and must not send on or receive from the Chan.
+func WithHighWater(mark int, fn func(n int)) Option {
+	return func(o *options) {
+		o.highWater, o.onHigh = mark, fn
+	}
+}
+
+// WithSoftCap limits the buffer to n values; once it holds n, sends are
+// handled according to policy. A cap of zero or less means unbounded.
+func WithSoftCap(n int, policy Policy) Option {
+	return func(o *options) {
+		o.softCap, o.policy = max(n, 0), policy
+	}
+}
+
+// New returns a Chan and starts its forwarding goroutine, which exits once
+// In is closed and the buffer has drained.
+func New[T any](opts ...Option) *Chan[T] {
+	o := options{initial: 16}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c := &Chan[T]{in: make(chan T), out: make(chan T), opts: o}
+	go c.forward()
+	return c
+}
+
+// In returns the channel to send on. Close it when there is nothing more
+// to send.
+func (c *Chan[T]) In() chan<- T { return c.in }
+
+// Out returns the channel to receive from. It is closed once In is closed
+// and every buffered value has been received.
+func (c *Chan[T]) Out() <-chan T { return c.out }
+
+// Len returns the number of values sent on In and not yet received from Out.
+func (c *Chan[T]) Len() int { return int(c.len.Load()) }
+
+// Peak returns the largest Len seen so far.
+func (c *Chan[T]) Peak() int { return int(c.peak.Load()) }
+
+// Dropped returns how many values the soft cap policy has discarded.
+func (c *Chan[T]) Dropped() int64 { return c.dropped.Load() }
+
+func (c *Chan[T]) forward() {
+	defer close(c.out)
+	buf := newRing[T](c.opts.initial)
+	in := c.in
+
+	for in != nil || buf.len() > 0 {
+		// Only offer on Out when there is something to offer; a nil
+		// channel drops out of the select
+		var out chan T
+		var next T
+		if buf.len() > 0 {
+			out, next = c.out, buf.peek()
+		}
+		recv := in
+		if c.opts.softCap > 0 && buf.len() >= c.opts.softCap && c.opts.policy == Block {
+			recv = nil
+		}
+
+		select {
+		case v, ok := <-recv:
+			if !ok {
+				in = nil
+				continue
+			}
+			c.push(buf, v)
+		case out <- next:
+			buf.pop()
+			if buf.len() < buf.cap()/4 && buf.cap() > c.opts.initial {
+				buf.resize(max(buf.cap()/2, c.opts.initial))
+			}
+		}
+		c.len.Store(int64(buf.len()))
+	}
+}
+
+// push buffers v, applying the soft cap policy.
+func (c *Chan[T]) push(buf *ring[T], v T) {
+	before := buf.len()
+	if c.opts.softCap > 0 && before >= c.opts.softCap {
+		switch c.opts.policy {
+		case DropNewest:
+			c.dropped.Add(1)
+			return
+		case DropOldest:
+			buf.pop()
+			c.dropped.Add(1)
+		}
+	}
+	buf.push(v)
+
+	n := buf.len()
+	if int64(n) > c.peak.Load() {
+		c.peak.Store(int64(n))
+	}
+	if c.opts.onHigh != nil && n == c.opts.highWater && before < n {
+		c.len.Store(int64(n))
+		c.opts.onHigh(n)
+	}
+}
+
+// ring is a growable FIFO ring buffer. It is owned by one goroutine.
+type ring[T any] struct {
+	buf  []T
+	head int // index of the oldest value
+	n    int
+}
+
+func newRing[T any](size int) *ring[T] {
+	return &ring[T]{buf: make([]T, size)}
+}
+
+func (r *ring[T]) len() int { return r.n }
+func (r *ring[T]) cap() int { return len(r.buf) }
+func (r *ring[T]) peek() T  { return r.buf[r.head] }
+
+func (r *ring[T]) push(v T) {
+	if r.n == len(r.buf) {
+		r.resize(2 * len(r.buf))
+	}
+	r.buf[(r.head+r.n)%len(r.buf)] = v
+	r.n++
+}
+
+func (r *ring[T]) pop() T {
+	var zero T
+	v := r.buf[r.head]
+	r.buf[r.head] = zero // let the GC reclaim what v points to
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	return v
+}
+
+// resize moves the values into a new slice of the given size, oldest first.
+func (r *ring[T]) resize(size int) {
+	buf := make([]T, size)
+	if r.head+r.n <= len(r.buf) {
+		copy(buf, r.buf[r.head:r.head+r.n])
+	} else {
+		k := copy(buf, r.buf[r.head:])
+		copy(buf[k:], r.buf[:r.n-k])
+	}
+	r.buf, r.head = buf, 0
+}
@@ -0,0 +1,220 @@
+package elastic_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go-concurrency/elastic"
+	"go-concurrency/leakcheck/leaktest"
+)
+
+// eventually fails t if cond does not hold within a second. Len, Peak and
+// Dropped are updated by the forwarding goroutine just after a send
+// completes.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s never held", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// closeAndCollect closes In and returns everything left on Out.
+func closeAndCollect(c *elastic.Chan[int]) []int {
+	close(c.In())
+	var got []int
+	for v := range c.Out() {
+		got = append(got, v)
+	}
+	return got
+}
+
+// Values come out in the order they went in while the ring wraps around,
+// grows with wrapped contents and shrinks back to its initial size.
+func TestFIFOThroughWrapGrowAndShrink(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		c := elastic.New[int](elastic.WithInitialCapacity(4))
+		sent, received := 0, 0
+		send := func(n int) {
+			for range n {
+				c.In() <- sent
+				sent++
+			}
+		}
+		recv := func(n int) {
+			for range n {
+				if v := <-c.Out(); v != received {
+					t.Fatalf("received %d, want %d", v, received)
+				}
+				received++
+			}
+		}
+
+		send(3)
+		recv(2)  // the oldest value is now in the middle of the ring
+		send(5)  // wraps around the end, then grows with the ring wrapped
+		recv(5)  // shrinks back to 4 with the head past the start
+		send(40) // grows several times
+		recv(39) // shrinks several times
+		send(3)
+		recv(3)
+		close(c.In())
+		recv(sent - received) // after In is closed
+		if _, ok := <-c.Out(); ok {
+			t.Error("Out still open once drained")
+		}
+	})
+}
+
+func TestSoftCapPolicies(t *testing.T) {
+	for _, tt := range []struct {
+		policy  elastic.Policy
+		want    []int
+		dropped int64
+	}{
+		{elastic.DropNewest, []int{0, 1, 2}, 3},
+		{elastic.DropOldest, []int{3, 4, 5}, 3},
+	} {
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			c := elastic.New[int](elastic.WithSoftCap(3, tt.policy))
+			for v := range 6 {
+				c.In() <- v // never blocks
+			}
+			eventually(t, "Dropped() == 3", func() bool { return c.Dropped() == tt.dropped })
+			if n := c.Len(); n != 3 {
+				t.Errorf("Len() = %d at the cap, want 3", n)
+			}
+			if got := closeAndCollect(c); !slices.Equal(got, tt.want) {
+				t.Errorf("received %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run(elastic.Block.String(), func(t *testing.T) {
+		c := elastic.New[int](elastic.WithSoftCap(2, elastic.Block))
+		c.In() <- 0
+		c.In() <- 1
+		select {
+		case c.In() <- 2:
+			t.Fatal("send past the cap did not block")
+		case <-time.After(20 * time.Millisecond):
+		}
+		<-c.Out()
+		c.In() <- 2 // room again
+		if got := closeAndCollect(c); !slices.Equal(got, []int{1, 2}) || c.Dropped() != 0 {
+			t.Errorf("received %v with %d dropped, want [1 2] and none", got, c.Dropped())
+		}
+	})
+}
+
+func TestHighWater(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []int
+	)
+	c := elastic.New[int](
+		elastic.WithSoftCap(3, elastic.DropOldest),
+		elastic.WithHighWater(3, func(n int) {
+			mu.Lock()
+			defer mu.Unlock()
+			calls = append(calls, n)
+		}),
+	)
+	called := func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(calls)
+	}
+
+	for v := range 6 {
+		c.In() <- v // the last three replace the oldest at the cap
+	}
+	eventually(t, "Dropped() == 3", func() bool { return c.Dropped() == 3 })
+	if got := called(); !slices.Equal(got, []int{3}) {
+		t.Errorf("high water called with %v while the length stayed at the cap, want [3]", got)
+	}
+
+	<-c.Out()
+	c.In() <- 6 // rises to the mark again
+	eventually(t, "a second high water call", func() bool { return len(called()) == 2 })
+	closeAndCollect(c)
+}
+
+func TestLenPeakDropped(t *testing.T) {
+	c := elastic.New[int](elastic.WithSoftCap(10, elastic.DropNewest))
+	if c.Len() != 0 || c.Peak() != 0 || c.Dropped() != 0 {
+		t.Fatalf("new Chan: Len %d, Peak %d, Dropped %d; want all 0", c.Len(), c.Peak(), c.Dropped())
+	}
+	for v := range 12 {
+		c.In() <- v
+	}
+	eventually(t, "Dropped() == 2", func() bool { return c.Dropped() == 2 })
+	for range 7 {
+		<-c.Out()
+	}
+	eventually(t, "Len() == 3", func() bool { return c.Len() == 3 })
+	if p := c.Peak(); p != 10 {
+		t.Errorf("Peak() = %d, want 10", p)
+	}
+	closeAndCollect(c)
+	if c.Len() != 0 || c.Peak() != 10 || c.Dropped() != 2 {
+		t.Errorf("drained Chan: Len %d, Peak %d, Dropped %d; want 0, 10, 2", c.Len(), c.Peak(), c.Dropped())
+	}
+}
+
+// BenchmarkStream sends values from one goroutine while another receives,
+// a steady stream a consumer keeps up with.
+func BenchmarkStream(b *testing.B) {
+	b.Run("chan-1024", func(b *testing.B) {
+		ch := make(chan int, 1024)
+		streamBench(b, ch, ch)
+	})
+	b.Run("elastic", func(b *testing.B) {
+		c := elastic.New[int]()
+		streamBench(b, c.In(), c.Out())
+	})
+}
+
+// BenchmarkBurst sends every value before the consumer starts, which a
+// buffered channel only survives when sized for the whole burst.
+func BenchmarkBurst(b *testing.B) {
+	b.Run("chan-presized", func(b *testing.B) {
+		ch := make(chan int, b.N)
+		burstBench(b, ch, ch)
+	})
+	b.Run("elastic", func(b *testing.B) {
+		c := elastic.New[int]()
+		burstBench(b, c.In(), c.Out())
+	})
+}
+
+// streamBench sends b.N values from one goroutine while another receives.
+func streamBench(b *testing.B, in chan<- int, out <-chan int) {
+	b.ReportAllocs()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range out {
+		}
+	}()
+	for i := range b.N {
+		in <- i
+	}
+	close(in)
+	<-done
+}
+
+// burstBench sends b.N values and only then receives them.
+func burstBench(b *testing.B, in chan<- int, out <-chan int) {
+	b.ReportAllocs()
+	for i := range b.N {
+		in <- i
+	}
+	close(in)
+	for range out {
+	}
+}
@@ -0,0 +1,104 @@
+package grading
+
+import (
+	"context"
+
+	channelpatterns "go-concurrency/5-channel-patterns"
+)
+
+func init() {
+	register("5-channel-patterns", func() []Item {
+		ex := channelpatterns.Exercises
+		items := manual(
+			"1. Select Statement Pattern",
+			"2. Channel Closing Pattern",
+			"3. Pipeline Pattern",
+			"4. Fan-in Pattern",
+			"5. Fan-out Pattern",
+			"6. Generator Pattern",
+		)
+		items[2].Checks = []Check{
+			{"Generate/values", ex.Generate != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Generate(ctx, 3, 1, 4, 1, 5))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, []int{3, 1, 4, 1, 5})
+			}},
+			{"Square/values", ex.Square != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Square(ctx, source(ctx, ints(10)...)))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, squares(ints(10)))
+			}},
+			{"Square/cancel", ex.Square != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Square(ctx, endless(ctx)) })
+			}},
+			{"Generate+Square", ex.Generate != nil && ex.Square != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Square(ctx, ex.Generate(ctx, 2, 3, 4)))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, []int{4, 9, 16})
+			}},
+		}
+		items[3].Checks = []Check{
+			{"Merge/values", ex.Merge != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Merge(ctx, source(ctx, 1, 2), source(ctx, 3), source(ctx, 4, 5, 6)))
+				if err != nil {
+					return err
+				}
+				return sameElements(got, []int{1, 2, 3, 4, 5, 6})
+			}},
+			{"Merge/no inputs", ex.Merge != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Merge(ctx))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, nil)
+			}},
+			{"Merge/cancel", ex.Merge != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Merge(ctx, endless(ctx), endless(ctx)) })
+			}},
+		}
+		items[4].Checks = []Check{
+			{"FanOut/values", ex.FanOut != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.FanOut(ctx, source(ctx, ints(50)...), 5, square))
+				if err != nil {
+					return err
+				}
+				return sameElements(got, squares(ints(50)))
+			}},
+			{"FanOut/cancel", ex.FanOut != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.FanOut(ctx, endless(ctx), 5, square) })
+			}},
+		}
+		items[5].Checks = []Check{
+			{"Counter/values", ex.Counter != nil, func(ctx context.Context) error {
+				counterCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				out := ex.Counter(counterCtx)
+				var got []int
+				for len(got) < 5 {
+					select {
+					case v := <-out:
+						got = append(got, v)
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				cancel()
+				if _, err := collect(ctx, out); err != nil {
+					return err
+				}
+				return sameOrder(got, ints(5))
+			}},
+			{"Counter/cancel", ex.Counter != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, ex.Counter)
+			}},
+		}
+		return items
+	})
+}
@@ -0,0 +1,54 @@
+package grading
+
+import (
+	"context"
+
+	channels "go-concurrency/2-channels"
+)
+
+func init() {
+	register("2-channels", func() []Item {
+		ex := channels.Exercises
+		items := manual(
+			"1. Basic Channel Operations",
+			"2. Buffered vs Unbuffered Channels",
+			"3. Channel Closing",
+			"4. Select Statement",
+			"5. Channel Patterns",
+			"6. Channel Best Practices",
+		)
+		items[4].Checks = []Check{
+			{"Generator/values", ex.Generator != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Generator(ctx, 10))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, ints(10))
+			}},
+			{"Generator/cancel", ex.Generator != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Generator(ctx, 1<<30) })
+			}},
+			{"FanIn/values", ex.FanIn != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.FanIn(ctx, source(ctx, 1, 2, 3), source(ctx, 4, 5), source(ctx)))
+				if err != nil {
+					return err
+				}
+				return sameElements(got, []int{1, 2, 3, 4, 5})
+			}},
+			{"FanIn/cancel", ex.FanIn != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.FanIn(ctx, endless(ctx), endless(ctx)) })
+			}},
+			{"FanOut/values", ex.FanOut != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.FanOut(ctx, source(ctx, ints(20)...), 4, square))
+				if err != nil {
+					return err
+				}
+				return sameElements(got, squares(ints(20)))
+			}},
+			{"FanOut/cancel", ex.FanOut != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.FanOut(ctx, endless(ctx), 3, square) })
+			}},
+		}
+		return items
+	})
+}
@@ -0,0 +1,64 @@
+package grading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	contexts "go-concurrency/4-context"
+)
+
+func init() {
+	register("4-context", func() []Item {
+		ex := contexts.Exercises
+		items := manual(
+			"1. Basic Context Operations",
+			"2. Context with Timeout",
+			"3. Context with Cancellation",
+			"4. Context with Deadline",
+			"5. Context with Values",
+			"6. Context Best Practices",
+		)
+		items[1].Checks = []Check{
+			{"WithTimeout/fast work", ex.WithTimeout != nil, func(ctx context.Context) error {
+				err := ex.WithTimeout(ctx, time.Second, func(ctx context.Context) error { return nil })
+				if err != nil {
+					return fmt.Errorf("fast work returned %v, want nil", err)
+				}
+				return nil
+			}},
+			{"WithTimeout/slow work", ex.WithTimeout != nil, func(ctx context.Context) error {
+				start := time.Now()
+				err := ex.WithTimeout(ctx, 50*time.Millisecond, func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				})
+				if !errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("slow work returned %v, want context.DeadlineExceeded", err)
+				}
+				if elapsed := time.Since(start); elapsed > time.Second {
+					return fmt.Errorf("took %v to time out after 50ms", elapsed)
+				}
+				return nil
+			}},
+		}
+		items[4].Checks = []Check{
+			{"RequestID/round trip", ex.WithRequestID != nil && ex.RequestID != nil, func(ctx context.Context) error {
+				if _, ok := ex.RequestID(ctx); ok {
+					return errors.New("RequestID found an ID in a context without one")
+				}
+				withID := ex.WithRequestID(ctx, "req-42")
+				if id, ok := ex.RequestID(withID); !ok || id != "req-42" {
+					return fmt.Errorf("RequestID = %q, %v; want \"req-42\", true", id, ok)
+				}
+				// A plain string key would collide with this one.
+				if _, ok := ex.RequestID(context.WithValue(ctx, "requestID", "x")); ok {
+					return errors.New("RequestID read a value stored under a string key; use an unexported key type")
+				}
+				return nil
+			}},
+		}
+		return items
+	})
+}
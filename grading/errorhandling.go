@@ -0,0 +1,128 @@
+package grading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	errorhandling "go-concurrency/6-error-handling"
+)
+
+func init() {
+	register("6-error-handling", func() []Item {
+		ex := errorhandling.Exercises
+		items := manual(
+			"1. Error Channel Pattern",
+			"2. Panic Recovery Pattern",
+			"3. Graceful Shutdown Pattern",
+			"4. Error Propagation Pattern",
+			"5. Circuit Breaker Pattern",
+			"6. Timeout and Deadline Pattern",
+		)
+		items[0].Checks = []Check{
+			{"CollectErrors/all errors", ex.CollectErrors != nil, func(ctx context.Context) error {
+				errA, errB := errors.New("a"), errors.New("b")
+				errs := ex.CollectErrors(
+					func() error { return errA },
+					func() error { return nil },
+					func() error { time.Sleep(10 * time.Millisecond); return errB },
+				)
+				if len(errs) != 2 || !(errors.Is(errs[0], errA) || errors.Is(errs[1], errA)) || !(errors.Is(errs[0], errB) || errors.Is(errs[1], errB)) {
+					return fmt.Errorf("got %v, want [a b] in any order", errs)
+				}
+				return nil
+			}},
+		}
+		items[1].Checks = []Check{
+			{"SafeGo/panic", ex.SafeGo != nil, func(ctx context.Context) error {
+				select {
+				case err := <-ex.SafeGo(func() { panic("boom") }):
+					if err == nil {
+						return errors.New("got nil error for a panicking function")
+					}
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}},
+			{"SafeGo/no panic", ex.SafeGo != nil, func(ctx context.Context) error {
+				select {
+				case err := <-ex.SafeGo(func() {}):
+					if err != nil {
+						return fmt.Errorf("got %v for a function that returned normally", err)
+					}
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}},
+		}
+		items[3].Checks = []Check{
+			{"Retry/eventual success", ex.Retry != nil, func(ctx context.Context) error {
+				var calls atomic.Int32
+				err := ex.Retry(ctx, 5, func() error {
+					if calls.Add(1) < 3 {
+						return errors.New("transient")
+					}
+					return nil
+				})
+				if err != nil || calls.Load() != 3 {
+					return fmt.Errorf("got %v after %d calls, want nil after 3", err, calls.Load())
+				}
+				return nil
+			}},
+			{"Retry/gives up", ex.Retry != nil, func(ctx context.Context) error {
+				permanent := errors.New("permanent")
+				var calls atomic.Int32
+				err := ex.Retry(ctx, 4, func() error {
+					calls.Add(1)
+					return permanent
+				})
+				if !errors.Is(err, permanent) || calls.Load() != 4 {
+					return fmt.Errorf("got %v after %d calls, want the last error after 4", err, calls.Load())
+				}
+				return nil
+			}},
+			{"Retry/cancel", ex.Retry != nil, func(ctx context.Context) error {
+				cancelled, cancel := context.WithCancel(ctx)
+				cancel()
+				err := ex.Retry(cancelled, 1000, func() error { return errors.New("fail") })
+				if !errors.Is(err, context.Canceled) {
+					return fmt.Errorf("got %v for a cancelled context, want context.Canceled", err)
+				}
+				return nil
+			}},
+		}
+		items[4].Checks = []Check{
+			{"CircuitBreaker/opens", ex.NewCircuitBreaker != nil, func(ctx context.Context) error {
+				cb := ex.NewCircuitBreaker(3)
+				failure := errors.New("down")
+				for i := range 3 {
+					if err := cb.Call(func() error { return failure }); !errors.Is(err, failure) {
+						return fmt.Errorf("call %d returned %v, want the dependency's error", i+1, err)
+					}
+				}
+				called := false
+				err := cb.Call(func() error { called = true; return nil })
+				if !errors.Is(err, errorhandling.ErrOpen) || called {
+					return fmt.Errorf("after 3 failures got %v (called = %v), want ErrOpen without calling", err, called)
+				}
+				return nil
+			}},
+			{"CircuitBreaker/success resets", ex.NewCircuitBreaker != nil, func(ctx context.Context) error {
+				cb := ex.NewCircuitBreaker(2)
+				failure := errors.New("down")
+				cb.Call(func() error { return failure })
+				cb.Call(func() error { return nil })
+				cb.Call(func() error { return failure })
+				if err := cb.Call(func() error { return nil }); err != nil {
+					return fmt.Errorf("opened after non-consecutive failures: %v", err)
+				}
+				return nil
+			}},
+		}
+		return items
+	})
+}
@@ -0,0 +1,216 @@
+// Package grading checks a learner's solutions to the module exercises.
+//
+// Every module lists its TODO items. An item is graded by the checks bound
+// to the contract fields of the module's Exercises variable: each check
+// runs under a timeout, with panics recovered, and must leave no goroutine
+// running behind it. Items without automated checks are reported for
+// manual review. cmd/gocon grade prints the resulting scorecard and can
+// rerun every item under the race detector.
+package grading
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go-concurrency/leakcheck"
+)
+
+// Check is one automated test of a contract field.
+type Check struct {
+	// Name identifies the check, e.g. "Square/ordering".
+	Name string
+	// Implemented is false while the contract field under test is nil.
+	Implemented bool
+	// Run performs the check, returning an error describing any failure.
+	// It must give up when ctx is done.
+	Run func(ctx context.Context) error
+}
+
+// Item is one TODO item of a module.
+type Item struct {
+	// Title is the TODO heading, e.g. "3. Pipeline Pattern".
+	Title string
+	// Checks grade the item. Items without checks need manual review.
+	Checks []Check
+}
+
+// Status summarizes the grade of an item.
+type Status string
+
+const (
+	Pass    Status = "PASS"    // every check passed
+	Fail    Status = "FAIL"    // at least one implemented check failed
+	Partial Status = "PARTIAL" // implemented checks passed, others are not implemented
+	Todo    Status = "TODO"    // nothing implemented yet
+	Manual  Status = "MANUAL"  // no automated checks for this item
+)
+
+// CheckResult is the outcome of one check.
+type CheckResult struct {
+	Name        string        `json:"name"`
+	Implemented bool          `json:"implemented"`
+	Err         string        `json:"err,omitempty"`
+	TimedOut    bool          `json:"timedOut,omitempty"`
+	Leaked      int           `json:"leaked,omitempty"`
+	Elapsed     time.Duration `json:"elapsed"`
+}
+
+// Passed reports whether the check ran and succeeded.
+func (r CheckResult) Passed() bool {
+	return r.Implemented && r.Err == "" && !r.TimedOut && r.Leaked == 0
+}
+
+// ItemResult is the outcome of grading one item.
+type ItemResult struct {
+	Title  string        `json:"title"`
+	Status Status        `json:"status"`
+	Checks []CheckResult `json:"checks"`
+	// Race is set by cmd/gocon when the race detector fired while the item
+	// was being graded.
+	Race bool `json:"race,omitempty"`
+}
+
+// Options configures Grade.
+type Options struct {
+	// Timeout bounds each check. Zero means five seconds.
+	Timeout time.Duration
+	// LeakGrace is how long goroutines get to exit after a check returns.
+	// Zero means 200ms.
+	LeakGrace time.Duration
+}
+
+// Grade runs every check of item.
+func Grade(item Item, opts Options) ItemResult {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.LeakGrace <= 0 {
+		opts.LeakGrace = 200 * time.Millisecond
+	}
+
+	res := ItemResult{Title: item.Title}
+	for _, c := range item.Checks {
+		res.Checks = append(res.Checks, runCheck(c, opts))
+	}
+	res.Status = status(res.Checks)
+	return res
+}
+
+func status(checks []CheckResult) Status {
+	if len(checks) == 0 {
+		return Manual
+	}
+
+	implemented, passed := 0, 0
+	for _, c := range checks {
+		if c.Implemented {
+			implemented++
+		}
+		if c.Passed() {
+			passed++
+		}
+	}
+	switch {
+	case implemented == 0:
+		return Todo
+	case passed < implemented:
+		return Fail
+	case implemented < len(checks):
+		return Partial
+	default:
+		return Pass
+	}
+}
+
+func runCheck(c Check, opts Options) CheckResult {
+	res := CheckResult{Name: c.Name, Implemented: c.Implemented}
+	if !c.Implemented {
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan struct{})
+	var (
+		err    error
+		report *leakcheck.Report
+	)
+	go func() {
+		defer close(done)
+		report = leakcheck.Check(func() { err = protect(ctx, c.Run) }, leakcheck.WithTimeout(opts.LeakGrace))
+	}()
+
+	// The check gets its own timeout plus the leak grace period; after that
+	// it is abandoned and reported as hung.
+	hung := time.NewTimer(opts.Timeout + opts.LeakGrace + time.Second)
+	defer hung.Stop()
+
+	select {
+	case <-done:
+	case <-hung.C:
+		res.TimedOut = true
+		res.Err = "did not return after its context expired"
+		res.Elapsed = time.Since(start)
+		return res
+	}
+
+	res.Elapsed = time.Since(start)
+	if err != nil {
+		res.Err = err.Error()
+	}
+	if ctx.Err() != nil && err != nil {
+		res.TimedOut = true
+	}
+	res.Leaked = len(report.Leaked)
+	return res
+}
+
+// protect calls run, turning a panic into an error.
+func protect(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return run(ctx)
+}
+
+// modules maps a module directory to the function listing its items.
+var modules = map[string]func() []Item{}
+
+// register records the items of module dir; each module file calls it from init.
+func register(dir string, items func() []Item) {
+	modules[dir] = items
+}
+
+// Items returns the graded TODO items of module dir, e.g. "5-channel-patterns".
+func Items(dir string) ([]Item, bool) {
+	items, ok := modules[dir]
+	if !ok {
+		return nil, false
+	}
+	return items(), true
+}
+
+// Modules returns the directories of all gradable modules, sorted.
+func Modules() []string {
+	dirs := make([]string, 0, len(modules))
+	for dir := range modules {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// manual returns items that have no automated checks.
+func manual(titles ...string) []Item {
+	items := make([]Item, len(titles))
+	for i, t := range titles {
+		items[i] = Item{Title: t}
+	}
+	return items
+}
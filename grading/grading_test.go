@@ -0,0 +1,173 @@
+package grading_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	channels "go-concurrency/2-channels"
+	syncbasics "go-concurrency/3-sync"
+	errorhandling "go-concurrency/6-error-handling"
+	"go-concurrency/grading"
+)
+
+// grade grades every item of module dir.
+func grade(t *testing.T, dir string, opts grading.Options) []grading.ItemResult {
+	t.Helper()
+	items, ok := grading.Items(dir)
+	if !ok {
+		t.Fatalf("module %s is not registered", dir)
+	}
+	var results []grading.ItemResult
+	for _, item := range items {
+		results = append(results, grading.Grade(item, opts))
+	}
+	return results
+}
+
+// result returns the result of the item titled title.
+func result(t *testing.T, results []grading.ItemResult, title string) grading.ItemResult {
+	t.Helper()
+	for _, r := range results {
+		if r.Title == title {
+			return r
+		}
+	}
+	t.Fatalf("no item %q", title)
+	return grading.ItemResult{}
+}
+
+// The graders must accept a correct solution: every automated item of a
+// module passes its reference solution, and modules without a reference
+// have no automated items at all.
+func TestReferenceSolutionsPass(t *testing.T) {
+	for _, dir := range grading.Modules() {
+		t.Run(dir, func(t *testing.T) {
+			install, ok := references[dir]
+			if ok {
+				t.Cleanup(install())
+			}
+			for _, r := range grade(t, dir, grading.Options{}) {
+				switch {
+				case !ok && r.Status != grading.Manual:
+					t.Errorf("%s: status %s without a reference solution to test its checks", r.Title, r.Status)
+				case ok && r.Status != grading.Pass && r.Status != grading.Manual:
+					t.Errorf("%s: status %s, want %s", r.Title, r.Status, grading.Pass)
+					for _, c := range r.Checks {
+						if !c.Passed() {
+							t.Logf("  %s: err=%q timedOut=%v leaked=%d", c.Name, c.Err, c.TimedOut, c.Leaked)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUnsolvedItemsAreTodo(t *testing.T) {
+	for _, dir := range grading.Modules() {
+		for _, r := range grade(t, dir, grading.Options{}) {
+			if len(r.Checks) > 0 && r.Status != grading.Todo {
+				t.Errorf("%s %s: status %s with no solution, want %s", dir, r.Title, r.Status, grading.Todo)
+			}
+		}
+	}
+}
+
+func TestPartialSolution(t *testing.T) {
+	old := channels.Exercises
+	t.Cleanup(func() { channels.Exercises = old })
+	channels.Exercises = channels.Solution{Generator: generator}
+
+	r := result(t, grade(t, "2-channels", grading.Options{}), "5. Channel Patterns")
+	if r.Status != grading.Partial {
+		t.Errorf("status %s with only Generator solved, want %s", r.Status, grading.Partial)
+	}
+}
+
+func TestBrokenSolutionsFail(t *testing.T) {
+	opts := grading.Options{Timeout: 100 * time.Millisecond, LeakGrace: 50 * time.Millisecond}
+
+	t.Run("never closes", func(t *testing.T) {
+		old := channels.Exercises
+		t.Cleanup(func() { channels.Exercises = old })
+		channels.Exercises = channels.Solution{Generator: func(ctx context.Context, n int) <-chan int {
+			out := make(chan int)
+			go func() {
+				for i := range n {
+					select {
+					case out <- i:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+			return out
+		}}
+
+		r := result(t, grade(t, "2-channels", opts), "5. Channel Patterns")
+		if r.Status != grading.Fail || !r.Checks[0].TimedOut {
+			t.Errorf("status %s, first check %+v; want %s with a timeout", r.Status, r.Checks[0], grading.Fail)
+		}
+	})
+
+	t.Run("ignores cancellation", func(t *testing.T) {
+		old := channels.Exercises
+		t.Cleanup(func() { channels.Exercises = old })
+		stop := make(chan struct{})
+		t.Cleanup(func() { close(stop) })
+		channels.Exercises = channels.Solution{Generator: func(ctx context.Context, n int) <-chan int {
+			out := make(chan int)
+			go func() {
+				defer close(out)
+				for i := range n {
+					select {
+					case out <- i:
+					case <-stop:
+						return
+					}
+				}
+			}()
+			return out
+		}}
+
+		r := result(t, grade(t, "2-channels", opts), "5. Channel Patterns")
+		values, cancel := r.Checks[0], r.Checks[1]
+		if !values.Passed() {
+			t.Errorf("Generator/values failed: %+v", values)
+		}
+		if cancel.Passed() || cancel.Leaked == 0 {
+			t.Errorf("Generator/cancel = %+v, want a failure with a leaked goroutine", cancel)
+		}
+	})
+
+	t.Run("panics", func(t *testing.T) {
+		old := syncbasics.Exercises
+		t.Cleanup(func() { syncbasics.Exercises = old })
+		syncbasics.Exercises = syncbasics.Solution{NewCounter: func() syncbasics.Counter { panic("not yet") }}
+
+		r := result(t, grade(t, "3-sync", opts), "1. Mutex and RWMutex")
+		if r.Status != grading.Fail || !strings.Contains(r.Checks[0].Err, "panic: not yet") {
+			t.Errorf("status %s, check %+v; want %s with the panic", r.Status, r.Checks[0], grading.Fail)
+		}
+	})
+
+	t.Run("wrong answer", func(t *testing.T) {
+		old := errorhandling.Exercises
+		t.Cleanup(func() { errorhandling.Exercises = old })
+		errorhandling.Exercises = errorhandling.Solution{NewCircuitBreaker: func(int) errorhandling.CircuitBreaker {
+			return neverOpens{}
+		}}
+
+		r := result(t, grade(t, "6-error-handling", opts), "5. Circuit Breaker Pattern")
+		if r.Status != grading.Fail || r.Checks[0].Passed() || !r.Checks[1].Passed() {
+			t.Errorf("status %s, checks %+v; want only CircuitBreaker/opens to fail", r.Status, r.Checks)
+		}
+	})
+}
+
+// neverOpens is a circuit breaker that always calls through.
+type neverOpens struct{}
+
+func (neverOpens) Call(fn func() error) error { return fn() }
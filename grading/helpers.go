@@ -0,0 +1,124 @@
+package grading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// collect receives from ch until it is closed or ctx is done.
+func collect[T any](ctx context.Context, ch <-chan T) ([]T, error) {
+	if ch == nil {
+		return nil, errors.New("returned a nil channel")
+	}
+
+	var out []T
+	for {
+		select {
+		case v, ok := <-ch:
+			if !ok {
+				return out, nil
+			}
+			out = append(out, v)
+		case <-ctx.Done():
+			return out, fmt.Errorf("output channel was never closed: %w", ctx.Err())
+		}
+	}
+}
+
+// source returns a channel that sends vals and is then closed. It stops
+// early when ctx is done.
+func source(ctx context.Context, vals ...int) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for _, v := range vals {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// endless returns a channel that sends 0, 1, 2, ... until ctx is done.
+func endless(ctx context.Context) <-chan int {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// stopsOnCancel starts a stage with its own context, reads one value,
+// cancels and checks that the stage closes its output.
+func stopsOnCancel[T any](ctx context.Context, start func(ctx context.Context) <-chan T) error {
+	stageCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := start(stageCtx)
+	if out == nil {
+		return errors.New("returned a nil channel")
+	}
+	select {
+	case <-out:
+	case <-ctx.Done():
+		return fmt.Errorf("sent nothing: %w", ctx.Err())
+	}
+
+	cancel()
+	if _, err := collect(ctx, out); err != nil {
+		return fmt.Errorf("after cancellation: %w", err)
+	}
+	return nil
+}
+
+// sameOrder checks that got equals want, element by element.
+func sameOrder(got, want []int) error {
+	if !slices.Equal(got, want) {
+		return fmt.Errorf("got %v, want %v", got, want)
+	}
+	return nil
+}
+
+// sameElements checks that got holds the elements of want in any order.
+func sameElements(got, want []int) error {
+	got, want = slices.Clone(got), slices.Clone(want)
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		return fmt.Errorf("got %v, want the elements of %v in any order", got, want)
+	}
+	return nil
+}
+
+// ints returns 0, 1, ..., n-1.
+func ints(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+// square is a transformation used by pipeline checks.
+func square(v int) int { return v * v }
+
+// squares returns the squares of vals.
+func squares(vals []int) []int {
+	out := make([]int, len(vals))
+	for i, v := range vals {
+		out[i] = square(v)
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package grading
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	lockfree "go-concurrency/12-lock-free-programming"
+)
+
+func init() {
+	register("12-lock-free-programming", func() []Item {
+		ex := lockfree.Exercises
+		items := manual(
+			"1. Atomic Operations Basics",
+			"2. Lock-Free Counter",
+			"3. Lock-Free Stack",
+			"4. Lock-Free Queue",
+			"5. Memory Ordering",
+			"6. Advanced Lock-Free Patterns",
+		)
+		items[1].Checks = []Check{
+			{"Counter/concurrent", ex.NewCounter != nil, func(ctx context.Context) error {
+				c := ex.NewCounter()
+				var wg sync.WaitGroup
+				for g := range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range 1000 {
+							if g%2 == 0 {
+								c.Add(3)
+							} else {
+								c.Add(-1)
+							}
+						}
+					}()
+				}
+				wg.Wait()
+				if got := c.Load(); got != 8000 {
+					return fmt.Errorf("Load() = %d, want 8000", got)
+				}
+				return nil
+			}},
+		}
+		items[2].Checks = []Check{
+			{"Stack/LIFO", ex.NewStack != nil, func(ctx context.Context) error {
+				s := ex.NewStack()
+				for i := range 3 {
+					s.Push(i)
+				}
+				var got []int
+				for {
+					v, ok := s.Pop()
+					if !ok {
+						break
+					}
+					got = append(got, v)
+				}
+				return sameOrder(got, []int{2, 1, 0})
+			}},
+			{"Stack/concurrent", ex.NewStack != nil, func(ctx context.Context) error {
+				s := ex.NewStack()
+				return concurrentContainer(s.Push, s.Pop)
+			}},
+		}
+		items[3].Checks = []Check{
+			{"Queue/FIFO", ex.NewQueue != nil, func(ctx context.Context) error {
+				q := ex.NewQueue()
+				for i := range 3 {
+					q.Enqueue(i)
+				}
+				var got []int
+				for {
+					v, ok := q.Dequeue()
+					if !ok {
+						break
+					}
+					got = append(got, v)
+				}
+				return sameOrder(got, []int{0, 1, 2})
+			}},
+			{"Queue/concurrent", ex.NewQueue != nil, func(ctx context.Context) error {
+				q := ex.NewQueue()
+				return concurrentContainer(q.Enqueue, q.Dequeue)
+			}},
+		}
+		return items
+	})
+}
+
+// concurrentContainer pushes 4000 distinct values from 4 goroutines while 4
+// others pop, then checks every value came out exactly once.
+func concurrentContainer(push func(int), pop func() (int, bool)) error {
+	const producers, perProducer = 4, 1000
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				push(p*perProducer + i)
+			}
+		}()
+	}
+
+	var (
+		mu      sync.Mutex
+		got     []int
+		poppers sync.WaitGroup
+	)
+	stop := make(chan struct{})
+	for range producers {
+		poppers.Add(1)
+		go func() {
+			defer poppers.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				if v, ok := pop(); ok {
+					mu.Lock()
+					got = append(got, v)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(stop)
+	poppers.Wait()
+	for {
+		v, ok := pop()
+		if !ok {
+			break
+		}
+		got = append(got, v)
+	}
+	return sameElements(got, ints(producers*perProducer))
+}
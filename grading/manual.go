@@ -0,0 +1,56 @@
+package grading
+
+// Modules whose exercises are open-ended design work are graded by review:
+// they have no function signatures to check, see the README.
+func init() {
+	register("10-performance-optimization", func() []Item {
+		return manual(
+			"1. Goroutine Pool Management",
+			"2. Memory Optimization",
+			"3. CPU Profiling and Optimization",
+			"4. Lock Contention Optimization",
+			"5. Channel Optimization",
+			"6. Benchmarking and Metrics",
+		)
+	})
+	register("11-testing-concurrency", func() []Item {
+		return manual(
+			"1. Race Condition Testing",
+			"2. Concurrent Unit Testing",
+			"3. Integration Testing",
+			"4. Load Testing",
+			"5. Property-Based Testing",
+			"6. Test Utilities and Helpers",
+		)
+	})
+	register("13-complex-patterns", func() []Item {
+		return manual(
+			"1. Actor Model Pattern",
+			"2. CSP (Communicating Sequential Processes) Pattern",
+			"3. Reactive Streams Pattern",
+			"4. Event Sourcing Pattern",
+			"5. Saga Pattern",
+			"6. CQRS (Command Query Responsibility Segregation) Pattern",
+		)
+	})
+	register("14-system-design", func() []Item {
+		return manual(
+			"1. Microservices Communication",
+			"2. Load Balancing with Workers",
+			"3. Distributed Caching",
+			"4. Message Queue System",
+			"5. Fault Tolerance Patterns",
+			"6. Scalability Patterns",
+		)
+	})
+	register("15-advanced-topics", func() []Item {
+		return manual(
+			"1. Goroutine Scheduling Internals",
+			"2. Memory Model Understanding",
+			"3. Performance Tuning at Scale",
+			"4. Debugging Complex Concurrent Systems",
+			"5. Custom Runtime Integration",
+			"6. Production System Patterns",
+		)
+	})
+}
@@ -0,0 +1,77 @@
+package grading
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	pipelines "go-concurrency/9-pipeline-patterns"
+)
+
+func init() {
+	register("9-pipeline-patterns", func() []Item {
+		ex := pipelines.Exercises
+		items := manual(
+			"1. Simple Pipeline Pattern",
+			"2. Parallel Pipeline Pattern",
+			"3. Streaming Pipeline Pattern",
+			"4. Error Handling Pipeline Pattern",
+			"5. Dynamic Pipeline Pattern",
+			"6. Batch Processing Pipeline Pattern",
+		)
+		even := func(v int) bool { return v%2 == 0 }
+		items[0].Checks = []Check{
+			{"Map/values", ex.Map != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Map(ctx, source(ctx, ints(10)...), square))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, squares(ints(10)))
+			}},
+			{"Map/cancel", ex.Map != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Map(ctx, endless(ctx), square) })
+			}},
+			{"Filter/values", ex.Filter != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Filter(ctx, source(ctx, ints(10)...), even))
+				if err != nil {
+					return err
+				}
+				return sameOrder(got, []int{0, 2, 4, 6, 8})
+			}},
+			{"Filter/cancel", ex.Filter != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Filter(ctx, endless(ctx), even) })
+			}},
+		}
+		items[5].Checks = []Check{
+			{"Batch/sizes", ex.Batch != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Batch(ctx, source(ctx, ints(7)...), 3, time.Second))
+				if err != nil {
+					return err
+				}
+				want := [][]int{{0, 1, 2}, {3, 4, 5}, {6}}
+				if !slices.EqualFunc(got, want, slices.Equal) {
+					return fmt.Errorf("got %v, want %v", got, want)
+				}
+				return nil
+			}},
+			{"Batch/flush after wait", ex.Batch != nil, func(ctx context.Context) error {
+				in := make(chan int)
+				defer close(in)
+				out := ex.Batch(ctx, in, 10, 20*time.Millisecond)
+				select {
+				case in <- 1:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				select {
+				case batch := <-out:
+					return sameOrder(batch, []int{1})
+				case <-time.After(time.Second):
+					return fmt.Errorf("partial batch was not flushed after its 20ms wait")
+				}
+			}},
+		}
+		return items
+	})
+}
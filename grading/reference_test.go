@@ -0,0 +1,521 @@
+package grading_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	lockfree "go-concurrency/12-lock-free-programming"
+	channels "go-concurrency/2-channels"
+	syncbasics "go-concurrency/3-sync"
+	contexts "go-concurrency/4-context"
+	channelpatterns "go-concurrency/5-channel-patterns"
+	errorhandling "go-concurrency/6-error-handling"
+	syncadvanced "go-concurrency/7-sync-advanced"
+	workerpools "go-concurrency/8-worker-pools"
+	pipelines "go-concurrency/9-pipeline-patterns"
+)
+
+// The reference solutions below are the simplest correct answers to each
+// module's exercises. The grader must pass every one of them.
+
+func generator(ctx context.Context, n int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := range n {
+			select {
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func generate(ctx context.Context, nums ...int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for _, v := range nums {
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func counter(ctx context.Context) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for i := 0; ; i++ {
+			select {
+			case out <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func merge(ctx context.Context, ins ...<-chan int) <-chan int {
+	out := make(chan int)
+	var wg sync.WaitGroup
+	for _, in := range ins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range in {
+				select {
+				case out <- v:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+func fanOut(ctx context.Context, in <-chan int, workers int, fn func(int) int) <-chan int {
+	outs := make([]<-chan int, workers)
+	for i := range outs {
+		outs[i] = mapValues(ctx, in, fn)
+	}
+	return merge(ctx, outs...)
+}
+
+func mapValues(ctx context.Context, in <-chan int, fn func(int) int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for v := range in {
+			select {
+			case out <- fn(v):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func filter(ctx context.Context, in <-chan int, keep func(int) bool) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for v := range in {
+			if !keep(v) {
+				continue
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+func batch(ctx context.Context, in <-chan int, size int, wait time.Duration) <-chan []int {
+	out := make(chan []int)
+	go func() {
+		defer close(out)
+		var (
+			pending []int
+			timeout <-chan time.Time
+		)
+		flush := func() bool {
+			select {
+			case out <- pending:
+				pending, timeout = nil, nil
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					if len(pending) > 0 {
+						flush()
+					}
+					return
+				}
+				pending = append(pending, v)
+				if len(pending) == 1 {
+					timeout = time.After(wait)
+				}
+				if len(pending) == size && !flush() {
+					return
+				}
+			case <-timeout:
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+type mutexCounter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (c *mutexCounter) Inc() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n++
+}
+
+func (c *mutexCounter) Value() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.n
+}
+
+type mapCache struct {
+	m sync.Map
+}
+
+func (c *mapCache) Get(key string) (int, bool) {
+	v, ok := c.m.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true
+}
+
+func (c *mapCache) Set(key string, value int) { c.m.Store(key, value) }
+
+type requestIDKey struct{}
+
+type breaker struct {
+	max, failures int
+}
+
+func (b *breaker) Call(fn func() error) error {
+	if b.failures >= b.max {
+		return errorhandling.ErrOpen
+	}
+	if err := fn(); err != nil {
+		b.failures++
+		return err
+	}
+	b.failures = 0
+	return nil
+}
+
+type chanSemaphore chan struct{}
+
+func (s chanSemaphore) Acquire(ctx context.Context) error {
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s chanSemaphore) Release() { <-s }
+
+type barrier struct {
+	mu      sync.Mutex
+	parties int
+	arrived int
+	release chan struct{}
+}
+
+func (b *barrier) Await(ctx context.Context) error {
+	b.mu.Lock()
+	release := b.release
+	b.arrived++
+	if b.arrived == b.parties {
+		close(release)
+		b.arrived, b.release = 0, make(chan struct{})
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type chanMutex chan struct{}
+
+func (m chanMutex) Lock()   { m <- struct{}{} }
+func (m chanMutex) Unlock() { <-m }
+
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	refilled time.Time
+}
+
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.refilled).Seconds()*b.rate)
+		b.refilled = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+type atomicCounter struct {
+	n atomic.Int64
+}
+
+func (c *atomicCounter) Add(delta int64) { c.n.Add(delta) }
+func (c *atomicCounter) Load() int64     { return c.n.Load() }
+
+type node struct {
+	v    int
+	next atomic.Pointer[node]
+}
+
+// treiberStack is a linked stack whose head is swapped with CompareAndSwap.
+type treiberStack struct {
+	head atomic.Pointer[node]
+}
+
+func (s *treiberStack) Push(v int) {
+	n := &node{v: v}
+	for {
+		head := s.head.Load()
+		n.next.Store(head)
+		if s.head.CompareAndSwap(head, n) {
+			return
+		}
+	}
+}
+
+func (s *treiberStack) Pop() (int, bool) {
+	for {
+		head := s.head.Load()
+		if head == nil {
+			return 0, false
+		}
+		if s.head.CompareAndSwap(head, head.next.Load()) {
+			return head.v, true
+		}
+	}
+}
+
+// msQueue is the Michael-Scott queue: a linked list behind a dummy node
+// whose tail may lag one node behind and is helped forward by any caller.
+type msQueue struct {
+	head, tail atomic.Pointer[node]
+}
+
+func newQueue() *msQueue {
+	q := &msQueue{}
+	dummy := &node{}
+	q.head.Store(dummy)
+	q.tail.Store(dummy)
+	return q
+}
+
+func (q *msQueue) Enqueue(v int) {
+	n := &node{v: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if next != nil {
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			return
+		}
+	}
+}
+
+func (q *msQueue) Dequeue() (int, bool) {
+	for {
+		head := q.head.Load()
+		next := head.next.Load()
+		if next == nil {
+			return 0, false
+		}
+		if tail := q.tail.Load(); tail == head {
+			q.tail.CompareAndSwap(tail, next)
+		}
+		if q.head.CompareAndSwap(head, next) {
+			return next.v, true
+		}
+	}
+}
+
+// references installs the reference solution of each graded module and
+// returns a function restoring the previous Exercises.
+var references = map[string]func() (restore func()){
+	"2-channels": func() func() {
+		old := channels.Exercises
+		channels.Exercises = channels.Solution{Generator: generator, FanIn: merge, FanOut: fanOut}
+		return func() { channels.Exercises = old }
+	},
+	"3-sync": func() func() {
+		old := syncbasics.Exercises
+		syncbasics.Exercises = syncbasics.Solution{
+			NewCounter: func() syncbasics.Counter { return &mutexCounter{} },
+			OnceValue:  sync.OnceValue[int],
+			NewCache:   func() syncbasics.Cache { return &mapCache{} },
+		}
+		return func() { syncbasics.Exercises = old }
+	},
+	"4-context": func() func() {
+		old := contexts.Exercises
+		contexts.Exercises = contexts.Solution{
+			WithTimeout: func(ctx context.Context, d time.Duration, work func(ctx context.Context) error) error {
+				ctx, cancel := context.WithTimeout(ctx, d)
+				defer cancel()
+				return work(ctx)
+			},
+			WithRequestID: func(ctx context.Context, id string) context.Context {
+				return context.WithValue(ctx, requestIDKey{}, id)
+			},
+			RequestID: func(ctx context.Context) (string, bool) {
+				id, ok := ctx.Value(requestIDKey{}).(string)
+				return id, ok
+			},
+		}
+		return func() { contexts.Exercises = old }
+	},
+	"5-channel-patterns": func() func() {
+		old := channelpatterns.Exercises
+		channelpatterns.Exercises = channelpatterns.Solution{
+			Generate: generate,
+			Square: func(ctx context.Context, in <-chan int) <-chan int {
+				return mapValues(ctx, in, func(v int) int { return v * v })
+			},
+			Merge:   merge,
+			FanOut:  fanOut,
+			Counter: counter,
+		}
+		return func() { channelpatterns.Exercises = old }
+	},
+	"6-error-handling": func() func() {
+		old := errorhandling.Exercises
+		errorhandling.Exercises = errorhandling.Solution{
+			CollectErrors: func(tasks ...func() error) []error {
+				errs := make(chan error, len(tasks))
+				for _, task := range tasks {
+					go func() { errs <- task() }()
+				}
+				var out []error
+				for range tasks {
+					if err := <-errs; err != nil {
+						out = append(out, err)
+					}
+				}
+				return out
+			},
+			SafeGo: func(fn func()) <-chan error {
+				errc := make(chan error, 1)
+				go func() {
+					defer func() {
+						if r := recover(); r != nil {
+							errc <- fmt.Errorf("panic: %v", r)
+							return
+						}
+						errc <- nil
+					}()
+					fn()
+				}()
+				return errc
+			},
+			Retry: func(ctx context.Context, attempts int, fn func() error) error {
+				var err error
+				for range attempts {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
+					if err = fn(); err == nil {
+						return nil
+					}
+				}
+				return err
+			},
+			NewCircuitBreaker: func(maxFailures int) errorhandling.CircuitBreaker {
+				return &breaker{max: maxFailures}
+			},
+		}
+		return func() { errorhandling.Exercises = old }
+	},
+	"7-sync-advanced": func() func() {
+		old := syncadvanced.Exercises
+		syncadvanced.Exercises = syncadvanced.Solution{
+			NewSemaphore: func(n int) syncadvanced.Semaphore { return make(chanSemaphore, n) },
+			NewBarrier: func(parties int) syncadvanced.Barrier {
+				return &barrier{parties: parties, release: make(chan struct{})}
+			},
+			NewChanMutex: func() sync.Locker { return make(chanMutex, 1) },
+		}
+		return func() { syncadvanced.Exercises = old }
+	},
+	"8-worker-pools": func() func() {
+		old := workerpools.Exercises
+		workerpools.Exercises = workerpools.Solution{
+			Pool: func(ctx context.Context, workers int, jobs <-chan int, fn func(int) int) <-chan int {
+				return fanOut(ctx, jobs, workers, fn)
+			},
+			NewTokenBucket: func(perSecond float64, burst int) workerpools.Limiter {
+				return &tokenBucket{rate: perSecond, burst: float64(burst), tokens: float64(burst), refilled: time.Now()}
+			},
+		}
+		return func() { workerpools.Exercises = old }
+	},
+	"9-pipeline-patterns": func() func() {
+		old := pipelines.Exercises
+		pipelines.Exercises = pipelines.Solution{Map: mapValues, Filter: filter, Batch: batch}
+		return func() { pipelines.Exercises = old }
+	},
+	"12-lock-free-programming": func() func() {
+		old := lockfree.Exercises
+		lockfree.Exercises = lockfree.Solution{
+			NewCounter: func() lockfree.Counter { return &atomicCounter{} },
+			NewStack:   func() lockfree.Stack { return &treiberStack{} },
+			NewQueue:   func() lockfree.Queue { return newQueue() },
+		}
+		return func() { lockfree.Exercises = old }
+	},
+}
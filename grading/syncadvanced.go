@@ -0,0 +1,127 @@
+package grading
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	syncadvanced "go-concurrency/7-sync-advanced"
+)
+
+func init() {
+	register("7-sync-advanced", func() []Item {
+		ex := syncadvanced.Exercises
+		items := manual(
+			"1. sync.Cond Pattern",
+			"2. sync.Map Pattern",
+			"3. sync.Pool Pattern",
+			"4. Custom Synchronization Pattern",
+			"5. Channel-based Synchronization Pattern",
+			"6. Advanced WaitGroup Pattern",
+		)
+		items[3].Checks = []Check{
+			{"Semaphore/limit", ex.NewSemaphore != nil, func(ctx context.Context) error {
+				sem := ex.NewSemaphore(3)
+				var holders, maxHolders atomic.Int32
+				var wg sync.WaitGroup
+				errs := make(chan error, 20)
+				for range 20 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if err := sem.Acquire(ctx); err != nil {
+							errs <- err
+							return
+						}
+						n := holders.Add(1)
+						for {
+							m := maxHolders.Load()
+							if n <= m || maxHolders.CompareAndSwap(m, n) {
+								break
+							}
+						}
+						time.Sleep(time.Millisecond)
+						holders.Add(-1)
+						sem.Release()
+					}()
+				}
+				wg.Wait()
+				close(errs)
+				if err := <-errs; err != nil {
+					return err
+				}
+				if m := maxHolders.Load(); m > 3 {
+					return fmt.Errorf("%d goroutines held a semaphore of 3 at once", m)
+				}
+				return nil
+			}},
+			{"Semaphore/cancel", ex.NewSemaphore != nil, func(ctx context.Context) error {
+				sem := ex.NewSemaphore(1)
+				if err := sem.Acquire(ctx); err != nil {
+					return err
+				}
+				defer sem.Release()
+
+				waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+				defer cancel()
+				if err := sem.Acquire(waitCtx); !errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf("Acquire on a full semaphore returned %v, want the context's error", err)
+				}
+				return nil
+			}},
+			{"Barrier/releases together", ex.NewBarrier != nil, func(ctx context.Context) error {
+				const parties = 5
+				b := ex.NewBarrier(parties)
+				var arrived atomic.Int32
+				errs := make(chan error, parties)
+				for range parties {
+					go func() {
+						arrived.Add(1)
+						if err := b.Await(ctx); err != nil {
+							errs <- err
+							return
+						}
+						if n := arrived.Load(); n != parties {
+							errs <- fmt.Errorf("released after only %d of %d arrived", n, parties)
+							return
+						}
+						errs <- nil
+					}()
+				}
+				for range parties {
+					if err := <-errs; err != nil {
+						return err
+					}
+				}
+				return nil
+			}},
+		}
+		items[4].Checks = []Check{
+			{"ChanMutex/exclusion", ex.NewChanMutex != nil, func(ctx context.Context) error {
+				mu := ex.NewChanMutex()
+				counter := 0
+				var wg sync.WaitGroup
+				for range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range 500 {
+							mu.Lock()
+							counter++
+							mu.Unlock()
+						}
+					}()
+				}
+				wg.Wait()
+				if counter != 4000 {
+					return fmt.Errorf("counter = %d after 4000 locked increments", counter)
+				}
+				return nil
+			}},
+		}
+		return items
+	})
+}
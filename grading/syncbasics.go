@@ -0,0 +1,106 @@
+package grading
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	syncbasics "go-concurrency/3-sync"
+)
+
+func init() {
+	register("3-sync", func() []Item {
+		ex := syncbasics.Exercises
+		items := manual(
+			"1. Mutex and RWMutex",
+			"2. WaitGroup",
+			"3. Once",
+			"4. Cond (Condition Variables)",
+			"5. Map",
+			"6. Pool",
+		)
+		items[0].Checks = []Check{
+			{"NewCounter/concurrent", ex.NewCounter != nil, func(ctx context.Context) error {
+				c := ex.NewCounter()
+				var wg sync.WaitGroup
+				for range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range 1000 {
+							c.Inc()
+						}
+					}()
+				}
+				wg.Wait()
+				if got := c.Value(); got != 8000 {
+					return fmt.Errorf("Value() = %d after 8000 concurrent Inc calls", got)
+				}
+				return nil
+			}},
+		}
+		items[2].Checks = []Check{
+			{"OnceValue/single call", ex.OnceValue != nil, func(ctx context.Context) error {
+				var calls atomic.Int32
+				get := ex.OnceValue(func() int {
+					calls.Add(1)
+					return 42
+				})
+
+				var wg sync.WaitGroup
+				errs := make(chan error, 10)
+				for range 10 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if v := get(); v != 42 {
+							errs <- fmt.Errorf("got %d, want 42", v)
+						}
+					}()
+				}
+				wg.Wait()
+				close(errs)
+				if err := <-errs; err != nil {
+					return err
+				}
+				if n := calls.Load(); n != 1 {
+					return fmt.Errorf("init called %d times, want 1", n)
+				}
+				return nil
+			}},
+		}
+		items[4].Checks = []Check{
+			{"NewCache/concurrent", ex.NewCache != nil, func(ctx context.Context) error {
+				c := ex.NewCache()
+				if _, ok := c.Get("missing"); ok {
+					return fmt.Errorf("Get on an empty cache reported a value")
+				}
+
+				var wg sync.WaitGroup
+				for g := range 4 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for i := range 100 {
+							key := fmt.Sprintf("g%d-%d", g, i)
+							c.Set(key, i)
+							c.Get(key)
+						}
+					}()
+				}
+				wg.Wait()
+				for g := range 4 {
+					for i := range 100 {
+						key := fmt.Sprintf("g%d-%d", g, i)
+						if v, ok := c.Get(key); !ok || v != i {
+							return fmt.Errorf("Get(%q) = %d, %v; want %d, true", key, v, ok, i)
+						}
+					}
+				}
+				return nil
+			}},
+		}
+		return items
+	})
+}
@@ -0,0 +1,87 @@
+package grading
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	workerpools "go-concurrency/8-worker-pools"
+)
+
+func init() {
+	register("8-worker-pools", func() []Item {
+		ex := workerpools.Exercises
+		items := manual(
+			"1. Basic Worker Pool",
+			"2. Dynamic Worker Pool",
+			"3. Priority Worker Pool",
+			"4. Worker Pool with Backpressure",
+			"5. Worker Pool with Rate Limiting",
+			"6. Worker Pool Best Practices",
+		)
+		items[0].Checks = []Check{
+			{"Pool/results", ex.Pool != nil, func(ctx context.Context) error {
+				got, err := collect(ctx, ex.Pool(ctx, 4, source(ctx, ints(40)...), square))
+				if err != nil {
+					return err
+				}
+				return sameElements(got, squares(ints(40)))
+			}},
+			{"Pool/concurrency", ex.Pool != nil, func(ctx context.Context) error {
+				var running, peak atomic.Int32
+				slow := func(v int) int {
+					n := running.Add(1)
+					for {
+						p := peak.Load()
+						if n <= p || peak.CompareAndSwap(p, n) {
+							break
+						}
+					}
+					time.Sleep(5 * time.Millisecond)
+					running.Add(-1)
+					return v
+				}
+				if _, err := collect(ctx, ex.Pool(ctx, 3, source(ctx, ints(30)...), slow)); err != nil {
+					return err
+				}
+				if p := peak.Load(); p < 2 || p > 3 {
+					return fmt.Errorf("%d jobs ran at once with 3 workers", p)
+				}
+				return nil
+			}},
+			{"Pool/cancel", ex.Pool != nil, func(ctx context.Context) error {
+				return stopsOnCancel(ctx, func(ctx context.Context) <-chan int { return ex.Pool(ctx, 3, endless(ctx), square) })
+			}},
+		}
+		items[4].Checks = []Check{
+			{"TokenBucket/rate", ex.NewTokenBucket != nil, func(ctx context.Context) error {
+				limiter := ex.NewTokenBucket(100, 5)
+				start := time.Now()
+				for range 15 {
+					if err := limiter.Wait(ctx); err != nil {
+						return err
+					}
+				}
+				// 5 tokens are available at once, the other 10 take ~100ms.
+				if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+					return fmt.Errorf("15 events at 100/s with burst 5 took only %v", elapsed)
+				}
+				return nil
+			}},
+			{"TokenBucket/cancel", ex.NewTokenBucket != nil, func(ctx context.Context) error {
+				limiter := ex.NewTokenBucket(0.001, 1)
+				if err := limiter.Wait(ctx); err != nil {
+					return err
+				}
+				waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+				defer cancel()
+				if err := limiter.Wait(waitCtx); err == nil {
+					return fmt.Errorf("Wait returned nil with no tokens left")
+				}
+				return nil
+			}},
+		}
+		return items
+	})
+}
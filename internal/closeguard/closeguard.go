@@ -0,0 +1,73 @@
+// Package closeguard lets a channel be closed while other goroutines may
+// still be sending on it.
+//
+// Closing a channel under a blocked sender panics, so the closer cannot
+// just call close. A Guard makes it safe in two steps: Close first closes
+// the Done channel, which every blocking send also waits on, so blocked
+// senders give up; then it takes a lock that senders hold between Enter
+// and Exit, so the channel is closed only once no sender is inside.
+//
+//	if !g.Enter() {
+//		return ErrClosed
+//	}
+//	defer g.Exit()
+//	select {
+//	case ch <- v:
+//	case <-g.Done():
+//		return ErrClosed
+//	}
+package closeguard
+
+import "sync"
+
+// Guard guards the closing of one channel. Create one with New.
+type Guard struct {
+	done   chan struct{}
+	once   sync.Once
+	mu     sync.RWMutex // held for reading by senders, for writing by Close
+	closed bool
+}
+
+// New returns a Guard for an open channel.
+func New() *Guard {
+	return &Guard{done: make(chan struct{})}
+}
+
+// Enter reports whether the channel is still open, and if so holds off
+// Close until Exit is called. A sender that may block must also wait on
+// Done.
+func (g *Guard) Enter() bool {
+	g.mu.RLock()
+	if g.closed {
+		g.mu.RUnlock()
+		return false
+	}
+	return true
+}
+
+// Exit ends a send begun by a successful Enter.
+func (g *Guard) Exit() { g.mu.RUnlock() }
+
+// Done returns a channel closed as soon as Close is called.
+func (g *Guard) Done() <-chan struct{} { return g.done }
+
+// Close closes Done, waits for every sender to Exit and calls fn, which
+// closes the channel and records anything that goes with it. Only the
+// first call has any effect.
+func (g *Guard) Close(fn func()) {
+	g.once.Do(func() {
+		close(g.done)
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.closed = true
+		fn()
+	})
+}
+
+// Closed reports whether Close has run. Once it returns true, whatever
+// Close's fn recorded can be read without further locking.
+func (g *Guard) Closed() bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.closed
+}
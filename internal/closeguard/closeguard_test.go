@@ -0,0 +1,42 @@
+package closeguard_test
+
+import (
+	"sync"
+	"testing"
+
+	"go-concurrency/internal/closeguard"
+)
+
+// Closing while senders are blocked, sending or about to send never sends
+// on the closed channel.
+func TestCloseUnderSenders(t *testing.T) {
+	for range 100 {
+		g := closeguard.New()
+		ch := make(chan int)
+		var wg sync.WaitGroup
+		for i := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for g.Enter() {
+					select {
+					case ch <- i:
+					case <-g.Done():
+					}
+					g.Exit()
+				}
+			}()
+		}
+		<-ch
+		g.Close(func() { close(ch) })
+		g.Close(func() { t.Error("second Close ran its function") })
+		wg.Wait()
+
+		if !g.Closed() {
+			t.Fatal("Closed() = false after Close")
+		}
+		if g.Enter() {
+			t.Fatal("Enter() = true after Close")
+		}
+	}
+}
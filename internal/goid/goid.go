@@ -0,0 +1,24 @@
+// Package goid returns the ID of the running goroutine, which the runtime
+// does not export but prints at the top of every stack trace. Tracing and
+// debugging packages use it to tell goroutines apart; nothing should
+// depend on it for correctness.
+package goid
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+)
+
+// Current returns the ID of the calling goroutine, parsed from the first
+// line of its stack trace, "goroutine 18 [running]:".
+func Current() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,22 @@
+package goid_test
+
+import (
+	"testing"
+
+	"go-concurrency/internal/goid"
+)
+
+func TestCurrent(t *testing.T) {
+	id := goid.Current()
+	if id <= 0 {
+		t.Fatalf("Current() = %d, want a positive ID", id)
+	}
+	if again := goid.Current(); again != id {
+		t.Errorf("Current() = %d then %d in the same goroutine", id, again)
+	}
+	other := make(chan int64)
+	go func() { other <- goid.Current() }()
+	if o := <-other; o == id || o <= 0 {
+		t.Errorf("Current() = %d in another goroutine, want a different positive ID than %d", o, id)
+	}
+}
@@ -0,0 +1,112 @@
+// Package metric holds what the instrumented packages, chanmetrics and
+// lockstat, measure with and report through: a lock-free histogram of
+// durations, a registry of named collectors and a rounding of durations
+// for reports.
+package metric
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Summary summarizes a set of durations.
+type Summary struct {
+	Count   int64         // durations recorded
+	Blocked int64         // of those, the ones that were an actual wait
+	Total   time.Duration // sum of the durations
+	Max     time.Duration // longest duration
+	Buckets []Bucket      // cumulative distribution
+}
+
+// Mean returns the average duration, or zero if there are none.
+func (s Summary) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Bucket counts the durations no longer than UpperBound.
+type Bucket struct {
+	UpperBound time.Duration
+	Count      int64
+}
+
+// bounds are the histogram bucket upper bounds, from a microsecond to ten
+// seconds.
+var bounds = [...]time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// Histogram records durations without locking. The zero value is empty
+// and ready to use.
+type Histogram struct {
+	count   atomic.Int64
+	blocked atomic.Int64
+	total   atomic.Int64 // nanoseconds
+	max     atomic.Int64 // nanoseconds
+	buckets [len(bounds)]atomic.Int64
+}
+
+// Observe records d; blocked says whether it was an actual wait.
+func (h *Histogram) Observe(d time.Duration, blocked bool) {
+	h.count.Add(1)
+	if blocked {
+		h.blocked.Add(1)
+	}
+	if d <= 0 {
+		h.buckets[0].Add(1)
+		return
+	}
+	h.total.Add(int64(d))
+	for {
+		cur := h.max.Load()
+		if int64(d) <= cur || h.max.CompareAndSwap(cur, int64(d)) {
+			break
+		}
+	}
+	for i, b := range bounds {
+		if d <= b {
+			h.buckets[i].Add(1)
+			return
+		}
+	}
+	// Longer than the last bound: only in the implicit +Inf bucket
+}
+
+// Summary returns the durations recorded so far. Observe counts a
+// duration before it buckets it, so Summary loads the count last: however
+// the two interleave, no bucket holds more than Count, as the Prometheus
+// format requires of the +Inf bucket.
+func (h *Histogram) Summary() Summary {
+	s := Summary{Buckets: make([]Bucket, len(bounds))}
+	var cumulative int64
+	for i, b := range bounds {
+		cumulative += h.buckets[i].Load()
+		s.Buckets[i] = Bucket{UpperBound: b, Count: cumulative}
+	}
+	s.Blocked = h.blocked.Load()
+	s.Total = time.Duration(h.total.Load())
+	s.Max = time.Duration(h.max.Load())
+	s.Count = h.count.Load()
+	return s
+}
+
+// Round shortens d to a precision that reads well in a report.
+func Round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
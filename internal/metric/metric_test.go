@@ -0,0 +1,82 @@
+package metric_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"go-concurrency/internal/metric"
+)
+
+func TestHistogram(t *testing.T) {
+	var h metric.Histogram
+	for _, d := range []time.Duration{0, 5 * time.Microsecond, 2 * time.Millisecond, time.Minute} {
+		h.Observe(d, d > 0)
+	}
+	s := h.Summary()
+	if s.Count != 4 || s.Blocked != 3 || s.Max != time.Minute {
+		t.Errorf("Count, Blocked, Max = %d, %d, %v; want 4, 3, 1m0s", s.Count, s.Blocked, s.Max)
+	}
+	if want := time.Minute + 2005*time.Microsecond; s.Total != want || s.Mean() != want/4 {
+		t.Errorf("Total, Mean = %v, %v; want %v, %v", s.Total, s.Mean(), want, want/4)
+	}
+	var counts []int64
+	for _, b := range s.Buckets {
+		counts = append(counts, b.Count)
+	}
+	// The minute is only in the implicit +Inf bucket
+	if want := []int64{1, 2, 2, 2, 3, 3, 3, 3}; !slices.Equal(counts, want) {
+		t.Errorf("cumulative bucket counts = %v, want %v", counts, want)
+	}
+}
+
+func TestRound(t *testing.T) {
+	for d, want := range map[time.Duration]time.Duration{
+		1234 * time.Nanosecond:       time.Microsecond,
+		1234567 * time.Nanosecond:    1230 * time.Microsecond,
+		1234567890 * time.Nanosecond: 1235 * time.Millisecond,
+	} {
+		if got := metric.Round(d); got != want {
+			t.Errorf("Round(%v) = %v, want %v", d, got, want)
+		}
+	}
+}
+
+type named string
+
+func (n named) Name() string { return string(n) }
+
+func TestRegistry(t *testing.T) {
+	var r metric.Registry[named]
+	for _, n := range []named{"queue", "cache", "jobs", "cache"} {
+		r.Register(n)
+	}
+	r.Unregister("jobs")
+	if got, want := r.Collectors(), []named{"cache", "queue"}; !slices.Equal(got, want) {
+		t.Errorf("Collectors() = %v, want %v", got, want)
+	}
+}
+
+// However Summary interleaves with concurrent Observe calls, no bucket
+// holds more than Count.
+func TestSummaryConsistentUnderObserve(t *testing.T) {
+	var h metric.Histogram
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 100000 {
+			h.Observe(time.Duration(i%7)*time.Millisecond, i%2 == 0)
+		}
+	}()
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		s := h.Summary()
+		if last := s.Buckets[len(s.Buckets)-1].Count; last > s.Count || s.Blocked > s.Count {
+			t.Fatalf("bucket count %d and blocked %d exceed Count %d", last, s.Blocked, s.Count)
+		}
+	}
+}
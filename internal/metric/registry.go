@@ -0,0 +1,45 @@
+package metric
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Registry is a set of collectors keyed by name. The zero value is empty
+// and ready to use.
+type Registry[C interface{ Name() string }] struct {
+	mu         sync.Mutex
+	collectors map[string]C
+}
+
+// Register adds c, replacing any collector with the same name.
+func (r *Registry[C]) Register(c C) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.collectors == nil {
+		r.collectors = make(map[string]C)
+	}
+	r.collectors[c.Name()] = c
+}
+
+// Unregister removes the collector with the given name.
+func (r *Registry[C]) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.collectors, name)
+}
+
+// Collectors returns the registered collectors, by name. The registry is
+// not locked while the caller collects from them.
+func (r *Registry[C]) Collectors() []C {
+	r.mu.Lock()
+	collectors := make([]C, 0, len(r.collectors))
+	for _, c := range r.collectors {
+		collectors = append(collectors, c)
+	}
+	r.mu.Unlock()
+
+	slices.SortFunc(collectors, func(a, b C) int { return strings.Compare(a.Name(), b.Name()) })
+	return collectors
+}
@@ -0,0 +1,70 @@
+// Package vettool runs an analyzer as a command that works both as a go vet
+// tool and on its own.
+//
+// A standalone analysis driver loads the packages it checks itself, reading
+// the export data the go command wrote for their dependencies. That data's
+// format follows the toolchain, so a driver built against an older
+// golang.org/x/tools fails on every package once the toolchain moves on.
+// Under go vet the same analyzer is handed type information the go command
+// produced, which always matches, so run directly the command re-runs
+// itself as go vet -vettool.
+package vettool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+// Main runs a on the packages named by the command line and exits. Flags
+// are passed on to go vet, which hands a's own flags back to it. Asked for
+// help, it describes a.
+func Main(a *analysis.Analyzer) {
+	args := os.Args[1:]
+	if fromVet(args) || help(args) {
+		unitchecker.Main(a)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", a.Name, err)
+		os.Exit(1)
+	}
+	cmd := exec.Command("go", append([]string{"vet", "-vettool=" + exe}, args...)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		if exit := (*exec.ExitError)(nil); errors.As(err, &exit) {
+			os.Exit(exit.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", a.Name, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// fromVet reports whether args are those go vet calls a tool with: -flags
+// or -V=full to describe it, or a .cfg file describing one package.
+func fromVet(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	last := args[len(args)-1]
+	return last == "-flags" || strings.HasPrefix(last, "-V=") || strings.HasSuffix(last, ".cfg")
+}
+
+// help reports whether args ask for a description of the tool.
+func help(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,28 @@
+package vettool
+
+import "testing"
+
+func TestArgs(t *testing.T) {
+	for _, tt := range []struct {
+		args          []string
+		fromVet, help bool
+	}{
+		{nil, false, false},
+		{[]string{"./..."}, false, false},
+		{[]string{"-json", "./elastic", "./clock"}, false, false},
+		{[]string{"-flags"}, true, false},
+		{[]string{"-V=full"}, true, false},
+		{[]string{"-json", "/tmp/go-build123/b001/vet.cfg"}, true, false},
+		{[]string{"help"}, false, true},
+		{[]string{"help", "chanowner"}, false, true},
+		{[]string{"-h"}, false, true},
+		{[]string{"--help"}, false, true},
+	} {
+		if got := fromVet(tt.args); got != tt.fromVet {
+			t.Errorf("fromVet(%q) = %v, want %v", tt.args, got, tt.fromVet)
+		}
+		if got := help(tt.args); got != tt.help {
+			t.Errorf("help(%q) = %v, want %v", tt.args, got, tt.help)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Package lazy provides initialization that may fail: a Value computed on
+// first use and a Once whose function returns an error.
+//
+// sync.Once and sync.OnceValue run their function once whatever happens,
+// so a failed initialization is remembered forever. Here a failure is
+// returned to the callers that waited for it and the next call tries
+// again, after a backoff if one is configured. At most one initializer
+// runs at a time; callers that arrive meanwhile wait for its result, and
+// can stop waiting when their context is done.
+package lazy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go-concurrency/clock"
+)
+
+// Option configures a Value or Once.
+type Option func(*options)
+
+type options struct {
+	clk        clock.Clock
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// WithBackoff delays retries after a failure: the first by initial, each
+// further consecutive one twice as long, up to limit. Calls made while a
+// retry is delayed return the last error without running the initializer.
+func WithBackoff(initial, limit time.Duration) Option {
+	return func(o *options) { o.backoff, o.maxBackoff = initial, max(limit, initial) }
+}
+
+// WithClock measures backoff on clk instead of the wall clock.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) { o.clk = clk }
+}
+
+// Value is a T computed by an initializer on first use and kept once it
+// succeeds. Create one with New.
+type Value[T any] struct {
+	fn func(context.Context) (T, error)
+	state[T]
+}
+
+// New returns a Value computed by fn. fn receives the context of the call
+// that started it, without its cancellation: a caller that stops waiting
+// does not abandon the initialization for everybody else.
+func New[T any](fn func(ctx context.Context) (T, error), opts ...Option) *Value[T] {
+	v := &Value[T]{fn: fn}
+	v.configure(opts)
+	return v
+}
+
+// Get returns the value, running the initializer if no call has succeeded
+// yet and none is running. It returns the initializer's error if the
+// attempt it waited for failed, the last error if a retry is being
+// delayed, or ctx.Err() if ctx is done first.
+func (v *Value[T]) Get(ctx context.Context) (T, error) {
+	return v.get(ctx, v.fn)
+}
+
+// Once runs a function that can fail until it succeeds once. The zero
+// Once is ready to use and retries without delay; use NewOnce for a
+// backoff.
+type Once struct {
+	state[struct{}]
+}
+
+// NewOnce returns a Once configured with opts.
+func NewOnce(opts ...Option) *Once {
+	o := &Once{}
+	o.configure(opts)
+	return o
+}
+
+// Do calls fn unless a call to Do has already succeeded, and returns nil
+// once one has. Like Value.Get, it waits for a call already running, and
+// returns its error, the last error while a retry is being delayed, or
+// ctx.Err(). fn receives ctx without its cancellation.
+func (o *Once) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := o.get(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// state is the initialization state machine shared by Value and Once.
+type state[T any] struct {
+	opts options
+
+	mu       sync.Mutex
+	done     bool
+	val      T
+	err      error     // of the last failed attempt
+	failures int       // consecutive
+	retryAt  time.Time // no attempt before this after a failure
+	running  *attempt[T]
+	gen      int // incremented by Reset; attempts from before are not stored
+}
+
+// attempt is one run of the initializer.
+type attempt[T any] struct {
+	gen  int
+	done chan struct{} // closed once val and err are set
+	val  T
+	err  error
+}
+
+func (s *state[T]) configure(opts []Option) {
+	for _, opt := range opts {
+		opt(&s.opts)
+	}
+}
+
+func (s *state[T]) clock() clock.Clock {
+	if s.opts.clk == nil {
+		return clock.Real()
+	}
+	return s.opts.clk
+}
+
+func (s *state[T]) get(ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	var zero T
+	for {
+		s.mu.Lock()
+		if s.done {
+			val := s.val
+			s.mu.Unlock()
+			return val, nil
+		}
+		a := s.running
+		stale := a != nil && a.gen != s.gen
+		if a == nil {
+			if s.err != nil && s.clock().Now().Before(s.retryAt) {
+				err := s.err
+				s.mu.Unlock()
+				return zero, err
+			}
+			a = &attempt[T]{gen: s.gen, done: make(chan struct{})}
+			s.running = a
+			go s.run(context.WithoutCancel(ctx), a, fn)
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-a.done:
+		case <-ctx.Done():
+			return zero, ctx.Err()
+		}
+		if stale {
+			continue // started before a Reset; try again
+		}
+		return a.val, a.err
+	}
+}
+
+// run runs fn for attempt a and records its outcome.
+func (s *state[T]) run(ctx context.Context, a *attempt[T], fn func(context.Context) (T, error)) {
+	defer close(a.done)
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			s.finish(a, zero, fmt.Errorf("lazy: initializer panicked: %v", r))
+		}
+	}()
+	val, err := fn(ctx)
+	s.finish(a, val, err)
+}
+
+func (s *state[T]) finish(a *attempt[T], val T, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a.val, a.err = val, err
+	s.running = nil
+	if a.gen != s.gen {
+		return
+	}
+	if err == nil {
+		s.done, s.val, s.err, s.failures = true, val, nil, 0
+		return
+	}
+	s.err = err
+	s.failures++
+	if d := s.opts.backoff; d > 0 {
+		for i := 1; i < s.failures && d < s.opts.maxBackoff; i++ {
+			d *= 2
+		}
+		s.retryAt = s.clock().Now().Add(min(d, s.opts.maxBackoff))
+	}
+}
+
+// Reset forgets the value, or the success of Once, and any failure and
+// backoff, so the next call initializes again; it is meant for tests. An
+// initializer already running is not interrupted, but its result is not
+// kept, and calls made after Reset wait for it to end before starting a
+// new one.
+func (s *state[T]) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var zero T
+	s.done, s.val, s.err, s.failures, s.retryAt = false, zero, nil, 0, time.Time{}
+	s.gen++
+}
@@ -0,0 +1,197 @@
+package lazy_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/lazy"
+)
+
+var errRefused = errors.New("connection refused")
+
+func TestValueRetriesAfterFailure(t *testing.T) {
+	var attempts int
+	v := lazy.New(func(context.Context) (int, error) {
+		attempts++
+		if attempts == 1 {
+			return 0, errRefused
+		}
+		return 42, nil
+	})
+	ctx := context.Background()
+
+	if _, err := v.Get(ctx); !errors.Is(err, errRefused) {
+		t.Fatalf("first Get() error = %v, want %v", err, errRefused)
+	}
+	for range 2 {
+		if got, err := v.Get(ctx); got != 42 || err != nil {
+			t.Fatalf("Get() = %d, %v; want 42, nil", got, err)
+		}
+	}
+	if attempts != 2 {
+		t.Errorf("initializer ran %d times, want 2", attempts)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var attempts int
+	o := lazy.NewOnce(lazy.WithBackoff(100*time.Millisecond, 300*time.Millisecond), lazy.WithClock(fake))
+	fail := func(context.Context) error {
+		attempts++
+		return errRefused
+	}
+	ctx := context.Background()
+
+	// Each delay doubles up to the limit: 100ms, 200ms, 300ms, 300ms
+	for i, delay := range []time.Duration{100, 200, 300, 300} {
+		delay *= time.Millisecond
+		if err := o.Do(ctx, fail); !errors.Is(err, errRefused) {
+			t.Fatalf("attempt %d: Do() = %v, want %v", i+1, err, errRefused)
+		}
+		fake.Advance(delay - time.Millisecond)
+		if err := o.Do(ctx, fail); !errors.Is(err, errRefused) || attempts != i+1 {
+			t.Fatalf("attempt %d: retried %v after the failure, want a wait of %v", i+1, delay-time.Millisecond, delay)
+		}
+		fake.Advance(time.Millisecond)
+	}
+	if err := o.Do(ctx, func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("Do() = %v once the backoff passed, want nil", err)
+	}
+}
+
+// Callers that arrive while an initializer runs wait for its result
+// instead of starting their own.
+func TestConcurrentCallersShareAttempt(t *testing.T) {
+	release := make(chan struct{})
+	var attempts atomic.Int32
+	v := lazy.New(func(context.Context) (string, error) {
+		attempts.Add(1)
+		<-release
+		return "", errRefused
+	})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = v.Get(context.Background())
+		}()
+	}
+	for attempts.Load() == 0 {
+		runtime.Gosched()
+	}
+	time.Sleep(10 * time.Millisecond) // let the other callers join
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, errRefused) {
+			t.Errorf("caller %d: Get() error = %v, want %v", i, err, errRefused)
+		}
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("initializer ran %d times for callers arriving together, want 1", n)
+	}
+}
+
+func TestCallerGivesUpWithoutCancellingInitializer(t *testing.T) {
+	release := make(chan struct{})
+	initCtx := make(chan context.Context, 1)
+	v := lazy.New(func(ctx context.Context) (string, error) {
+		initCtx <- ctx
+		<-release
+		return "loaded", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := v.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Get() = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := (<-initCtx).Err(); err != nil {
+		t.Errorf("initializer's context done with %v after the caller gave up", err)
+	}
+	close(release)
+	if got, err := v.Get(context.Background()); got != "loaded" || err != nil {
+		t.Errorf("Get() = %q, %v; want loaded, nil", got, err)
+	}
+}
+
+func TestPanicIsReturnedAsError(t *testing.T) {
+	var o lazy.Once
+	err := o.Do(context.Background(), func(context.Context) error { panic("boom") })
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("Do() = %v, want the panic as an error", err)
+	}
+	if err := o.Do(context.Background(), func(context.Context) error { return nil }); err != nil {
+		t.Errorf("Do() after the panic = %v, want a retry to succeed", err)
+	}
+}
+
+func TestReset(t *testing.T) {
+	var n int
+	v := lazy.New(func(context.Context) (int, error) {
+		n++
+		return n, nil
+	})
+	ctx := context.Background()
+	if got, _ := v.Get(ctx); got != 1 {
+		t.Fatalf("Get() = %d, want 1", got)
+	}
+	v.Reset()
+	if got, _ := v.Get(ctx); got != 2 {
+		t.Errorf("Get() after Reset = %d, want 2", got)
+	}
+}
+
+// However calls and Resets interleave, initializers never overlap.
+func TestInitializersNeverOverlap(t *testing.T) {
+	var (
+		once          lazy.Once
+		running, most atomic.Int32
+		inits         atomic.Int32
+		wg            sync.WaitGroup
+	)
+	ctx := context.Background()
+	for g := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				once.Do(ctx, func(context.Context) error {
+					n := running.Add(1)
+					defer running.Add(-1)
+					for cur := most.Load(); n > cur && !most.CompareAndSwap(cur, n); cur = most.Load() {
+					}
+					inits.Add(1)
+					runtime.Gosched()
+					if (g+i)%3 == 0 {
+						return errors.New("transient")
+					}
+					return nil
+				})
+				if (g+i)%7 == 0 {
+					once.Reset()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := most.Load(); n != 1 {
+		t.Errorf("%d initializers ran at once, want 1", n)
+	}
+	if inits.Load() == 0 {
+		t.Error("no initializer ran")
+	}
+}
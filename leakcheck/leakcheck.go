@@ -0,0 +1,254 @@
+// Package leakcheck detects goroutines that are still running after a
+// function has returned.
+//
+// It snapshots the stacks of all goroutines before and after the function
+// runs, ignores goroutines that already existed and well-known runtime
+// helpers, and retries for a short grace period so goroutines that are just
+// exiting are not reported. Leaks are returned as a Report that lists each
+// goroutine with its current top frame and the site that created it.
+//
+// The package does not depend on testing, so programs can use Check at run
+// time; tests use the helpers in leakcheck/leaktest.
+package leakcheck
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-concurrency/internal/goid"
+)
+
+// Goroutine is a parsed entry from a runtime stack dump.
+type Goroutine struct {
+	// ID is the runtime goroutine ID.
+	ID uint64
+	// State is the scheduler state, e.g. "running" or "chan receive".
+	State string
+	// Top is the function the goroutine is currently executing.
+	Top string
+	// CreatedBy is the "created by" frame and its file:line, if known.
+	CreatedBy string
+	// Stack is the full trace of the goroutine.
+	Stack string
+}
+
+func (g Goroutine) String() string {
+	created := g.CreatedBy
+	if created == "" {
+		created = "unknown"
+	}
+	return fmt.Sprintf("goroutine %d [%s] in %s, created by %s", g.ID, g.State, g.Top, created)
+}
+
+// Report lists the goroutines found leaking by Check.
+type Report struct {
+	Leaked []Goroutine
+}
+
+// OK reports whether no goroutine leaked.
+func (r *Report) OK() bool {
+	return len(r.Leaked) == 0
+}
+
+// Err returns the report as an error, or nil if nothing leaked.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return r
+}
+
+func (r *Report) Error() string {
+	return r.String()
+}
+
+func (r *Report) String() string {
+	if r.OK() {
+		return "no leaked goroutines"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d leaked goroutine(s):", len(r.Leaked))
+	for _, g := range r.Leaked {
+		b.WriteString("\n  ")
+		b.WriteString(g.String())
+	}
+	return b.String()
+}
+
+// defaultIgnored are top functions of goroutines the runtime and standard
+// library start lazily and never stop.
+var defaultIgnored = []string{
+	"os/signal.signal_recv",
+	"os/signal.loop",
+	"runtime.ensureSigM",
+	"runtime.ReadTrace",
+	"testing.(*T).Parallel",
+	"testing.runFuzzing",
+}
+
+type config struct {
+	timeout time.Duration
+	ignored []string
+}
+
+// Option configures Check.
+type Option func(*config)
+
+// WithTimeout sets how long goroutines are given to exit after the function
+// returns before they are reported. The default is one second.
+func WithTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = d
+	}
+}
+
+// IgnoreTopFunction ignores goroutines whose current function is fn, e.g.
+// "net/http.(*persistConn).readLoop".
+func IgnoreTopFunction(fn string) Option {
+	return func(c *config) {
+		c.ignored = append(c.ignored, fn)
+	}
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		timeout: time.Second,
+		ignored: append([]string(nil), defaultIgnored...),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check runs fn and reports goroutines started while it ran that are still
+// alive once it has returned and the grace period has expired.
+func Check(fn func(), opts ...Option) *Report {
+	c := newConfig(opts)
+
+	before := make(map[uint64]bool)
+	for _, g := range Snapshot() {
+		before[g.ID] = true
+	}
+
+	fn()
+
+	deadline := time.Now().Add(c.timeout)
+	delay := time.Millisecond
+	for {
+		leaked := c.leaked(before)
+		if len(leaked) == 0 || time.Now().After(deadline) {
+			return &Report{Leaked: leaked}
+		}
+
+		time.Sleep(delay)
+		delay = min(2*delay, 100*time.Millisecond)
+	}
+}
+
+func (c *config) leaked(before map[uint64]bool) []Goroutine {
+	self := uint64(goid.Current())
+
+	var leaked []Goroutine
+	for _, g := range Snapshot() {
+		if before[g.ID] || g.ID == self || c.isIgnored(g) {
+			continue
+		}
+		leaked = append(leaked, g)
+	}
+	return leaked
+}
+
+func (c *config) isIgnored(g Goroutine) bool {
+	for _, fn := range c.ignored {
+		if g.Top == fn {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot returns every goroutine currently alive.
+func Snapshot() []Goroutine {
+	return parse(stacks(true))
+}
+
+// stacks returns runtime.Stack output, growing the buffer until it fits.
+func stacks(all bool) []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parse splits a runtime.Stack dump into goroutines. Each block looks like:
+//
+//	goroutine 7 [chan receive]:
+//	main.worker(...)
+//		/src/main.go:42 +0x2c
+//	created by main.main in goroutine 1
+//		/src/main.go:17 +0x8d
+func parse(dump []byte) []Goroutine {
+	var gs []Goroutine
+	for _, block := range bytes.Split(dump, []byte("\n\n")) {
+		lines := strings.Split(strings.TrimSpace(string(block)), "\n")
+		if len(lines) < 2 || !strings.HasPrefix(lines[0], "goroutine ") {
+			continue
+		}
+
+		header := strings.TrimPrefix(lines[0], "goroutine ")
+		idText, rest, _ := strings.Cut(header, " ")
+		id, err := strconv.ParseUint(idText, 10, 64)
+		if err != nil {
+			continue
+		}
+		_, state, _ := strings.Cut(rest, "[")
+		state, _, _ = strings.Cut(state, "]")
+		// Drop the wait duration, e.g. "chan receive, 2 minutes".
+		state, _, _ = strings.Cut(state, ",")
+
+		g := Goroutine{
+			ID:    id,
+			State: state,
+			Top:   funcName(lines[1]),
+			Stack: strings.Join(lines, "\n"),
+		}
+		for i, line := range lines {
+			if !strings.HasPrefix(line, "created by ") {
+				continue
+			}
+			g.CreatedBy = strings.TrimPrefix(line, "created by ")
+			if i+1 < len(lines) {
+				g.CreatedBy += " at " + fileLine(lines[i+1])
+			}
+		}
+		gs = append(gs, g)
+	}
+	return gs
+}
+
+// funcName strips the argument list from a stack frame line.
+func funcName(frame string) string {
+	if i := strings.LastIndex(frame, "("); i > 0 {
+		return frame[:i]
+	}
+	return frame
+}
+
+// fileLine strips the leading tab and PC offset from a file:line line.
+func fileLine(line string) string {
+	line = strings.TrimSpace(line)
+	if i := strings.LastIndex(line, " +0x"); i > 0 {
+		line = line[:i]
+	}
+	return line
+}
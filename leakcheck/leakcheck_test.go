@@ -0,0 +1,143 @@
+package leakcheck
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dump is runtime.Stack output for a main goroutine, a worker blocked for
+// a while, a method running in a generic type, a goroutine in a stdlib
+// signal loop, and a goroutine without any frames left to show.
+const dump = `goroutine 1 [running, locked to thread]:
+main.main()
+	/src/main.go:12 +0x1d
+
+goroutine 7 [chan receive, 2 minutes]:
+main.worker(...)
+	/src/main.go:42
+main.startWorkers.func1()
+	/src/main.go:30 +0x2c
+created by main.startWorkers in goroutine 1
+	/src/main.go:28 +0x8d
+
+goroutine 18 [select]:
+example.com/pool.(*Pool[...]).run(0xc000010000, {0x1, 0x2})
+	/src/pool/pool.go:77 +0x105
+created by example.com/pool.New[...] in goroutine 7
+	/src/pool/pool.go:40 +0xfe
+
+goroutine 5 [syscall]:
+os/signal.signal_recv()
+	/usr/local/go/src/runtime/sigqueue.go:152 +0x29
+os/signal.loop()
+	/usr/local/go/src/os/signal/signal_unix.go:23 +0x13
+created by os/signal.Notify.func1.1 in goroutine 1
+	/usr/local/go/src/os/signal/signal.go:151 +0x1f
+
+goroutine 9 [running]:
+
+goroutine x [running]:
+main.broken()
+
+not a goroutine
+`
+
+func TestParse(t *testing.T) {
+	want := []Goroutine{
+		{ID: 1, State: "running", Top: "main.main"},
+		{ID: 7, State: "chan receive", Top: "main.worker", CreatedBy: "main.startWorkers in goroutine 1 at /src/main.go:28"},
+		{ID: 18, State: "select", Top: "example.com/pool.(*Pool[...]).run", CreatedBy: "example.com/pool.New[...] in goroutine 7 at /src/pool/pool.go:40"},
+		{ID: 5, State: "syscall", Top: "os/signal.signal_recv", CreatedBy: "os/signal.Notify.func1.1 in goroutine 1 at /usr/local/go/src/os/signal/signal.go:151"},
+	}
+	got := parse([]byte(dump))
+	if len(got) != len(want) {
+		t.Fatalf("parse found %d goroutines, want %d: %v", len(got), len(want), got)
+	}
+	for i, g := range got {
+		w := want[i]
+		if g.ID != w.ID || g.State != w.State || g.Top != w.Top || g.CreatedBy != w.CreatedBy {
+			t.Errorf("goroutine %d = %v, want %v", i, g, w)
+		}
+		if !strings.HasPrefix(g.Stack, "goroutine ") || strings.HasSuffix(g.Stack, "\n") {
+			t.Errorf("goroutine %d: Stack = %q, want the trimmed block", i, g.Stack)
+		}
+	}
+	if got[1].Stack != strings.Join(strings.Split(dump, "\n")[4:11], "\n") {
+		t.Errorf("Stack of goroutine 7 = %q", got[1].Stack)
+	}
+}
+
+func TestFuncName(t *testing.T) {
+	for _, tt := range []struct{ frame, want string }{
+		{"main.worker(...)", "main.worker"},
+		{"main.main()", "main.main"},
+		{"main.startWorkers.func1()", "main.startWorkers.func1"},
+		{"net/http.(*persistConn).readLoop(0xc0001b2000)", "net/http.(*persistConn).readLoop"},
+		{"example.com/pool.(*Pool[...]).run(0xc000010000, {0x1, 0x2})", "example.com/pool.(*Pool[...]).run"},
+		{"example.com/pool.New[...]({0x0?})", "example.com/pool.New[...]"},
+		{"runtime.goexit", "runtime.goexit"},
+	} {
+		if got := funcName(tt.frame); got != tt.want {
+			t.Errorf("funcName(%q) = %q, want %q", tt.frame, got, tt.want)
+		}
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	gs := parse([]byte(dump))
+	for _, tt := range []struct {
+		name string
+		opts []Option
+		want []uint64 // IDs not ignored
+	}{
+		{"defaults", nil, []uint64{1, 7, 18}},
+		{"top function", []Option{IgnoreTopFunction("main.worker")}, []uint64{1, 18}},
+		{"generic method", []Option{IgnoreTopFunction("example.com/pool.(*Pool[...]).run")}, []uint64{1, 7}},
+		// Only the top function counts, not the ones below it
+		{"lower frame", []Option{IgnoreTopFunction("main.startWorkers.func1")}, []uint64{1, 7, 18}},
+	} {
+		c := newConfig(tt.opts)
+		var got []uint64
+		for _, g := range gs {
+			if !c.isIgnored(g) {
+				got = append(got, g.ID)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: kept goroutines %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func blockedForever(ch chan struct{}) { <-ch }
+
+func TestCheck(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	r := Check(func() { go blockedForever(stop) }, WithTimeout(50*time.Millisecond))
+	if len(r.Leaked) != 1 || r.Leaked[0].Top != "go-concurrency/leakcheck.blockedForever" || r.Leaked[0].State != "chan receive" {
+		t.Fatalf("Check = %v, want blockedForever leaked", r)
+	}
+	if !strings.Contains(r.Leaked[0].CreatedBy, "leakcheck.TestCheck.func1") {
+		t.Errorf("CreatedBy = %q, want the function that started it", r.Leaked[0].CreatedBy)
+	}
+
+	// Goroutines alive before fn ran are not its leaks
+	if r := Check(func() {}, WithTimeout(0)); !r.OK() {
+		t.Errorf("Check of a function starting nothing = %v", r)
+	}
+
+	r = Check(func() { go blockedForever(stop) }, WithTimeout(50*time.Millisecond), IgnoreTopFunction("go-concurrency/leakcheck.blockedForever"))
+	if !r.OK() {
+		t.Errorf("Check ignoring blockedForever = %v", r)
+	}
+
+	// A goroutine that exits within the grace period is not a leak
+	r = Check(func() { go time.Sleep(20 * time.Millisecond) }, WithTimeout(time.Second))
+	if !r.OK() {
+		t.Errorf("Check of a goroutine exiting after 20ms = %v", r)
+	}
+}
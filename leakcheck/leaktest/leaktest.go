@@ -0,0 +1,19 @@
+// Package leaktest adapts leakcheck to tests. It is kept apart from
+// leakcheck so that programs checking for leaks at run time do not link
+// the testing package.
+package leaktest
+
+import (
+	"testing"
+
+	"go-concurrency/leakcheck"
+)
+
+// VerifyNone runs fn and fails tb with the report if any goroutine leaked.
+func VerifyNone(tb testing.TB, fn func(), opts ...leakcheck.Option) {
+	tb.Helper()
+
+	if r := leakcheck.Check(fn, opts...); !r.OK() {
+		tb.Error(r)
+	}
+}
@@ -0,0 +1,253 @@
+// Package lifecycle records goroutine lifecycle events (spawned, started,
+// blocked, progress, finished, panicked) with a goroutine label and a
+// monotonic timestamp.
+//
+// Events are written through log/slog, as text or JSON, and can also be kept
+// in memory so tests can assert on their order instead of on interleaved
+// stdout:
+//
+//	rec := lifecycle.New(lifecycle.WithMemory())
+//	...
+//	if !rec.Before(lifecycle.Match(lifecycle.Progress, "producer"),
+//		lifecycle.Match(lifecycle.Finished, "consumer")) {
+//		t.Error("consumer finished before producer closed the channel")
+//	}
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kind is the type of a lifecycle event.
+type Kind int
+
+const (
+	// Spawned is recorded by the goroutine that starts another one.
+	Spawned Kind = iota
+	// Started is recorded when the goroutine begins running.
+	Started
+	// Blocked is recorded before the goroutine waits on something.
+	Blocked
+	// Progress is recorded for a notable step, e.g. closing a channel.
+	Progress
+	// Finished is recorded when the goroutine returns.
+	Finished
+	// Panicked is recorded when the goroutine panics.
+	Panicked
+)
+
+var kindNames = [...]string{"spawned", "started", "blocked", "progress", "finished", "panicked"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// Event is a single recorded lifecycle event.
+type Event struct {
+	// Seq orders events in the sequence they were recorded.
+	Seq int
+	// Kind is what happened.
+	Kind Kind
+	// Label names the goroutine the event is about.
+	Label string
+	// Detail is free-form context, e.g. what the goroutine is blocked on.
+	Detail string
+	// At is the monotonic time since the Recorder was created.
+	At time.Duration
+}
+
+func (e Event) String() string {
+	if e.Detail == "" {
+		return fmt.Sprintf("%s %s", e.Label, e.Kind)
+	}
+	return fmt.Sprintf("%s %s: %s", e.Label, e.Kind, e.Detail)
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithHandler logs every event through h.
+func WithHandler(h slog.Handler) Option {
+	return func(r *Recorder) {
+		r.logger = slog.New(h)
+	}
+}
+
+// WithText logs every event to w in slog's text format.
+func WithText(w io.Writer) Option {
+	return WithHandler(slog.NewTextHandler(w, handlerOptions))
+}
+
+// WithJSON logs every event to w as one JSON object per line.
+func WithJSON(w io.Writer) Option {
+	return WithHandler(slog.NewJSONHandler(w, handlerOptions))
+}
+
+// WithMemory keeps every event so it can be inspected with Events.
+func WithMemory() Option {
+	return func(r *Recorder) {
+		r.keep = true
+	}
+}
+
+// handlerOptions drop slog's wall-clock time and level; events carry their
+// own monotonic timestamp.
+var handlerOptions = &slog.HandlerOptions{
+	ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+			return slog.Attr{}
+		}
+		return a
+	},
+}
+
+// Recorder records lifecycle events. It is safe for concurrent use.
+// A nil *Recorder discards every event.
+type Recorder struct {
+	logger *slog.Logger
+	keep   bool
+	start  time.Time
+
+	mu     sync.Mutex
+	seq    int
+	events []Event
+}
+
+// New returns a Recorder configured with opts. Without options it records
+// nothing, which is useful to silence instrumented code.
+func New(opts ...Option) *Recorder {
+	r := &Recorder{start: time.Now()}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Spawned records that the current goroutine started goroutine label.
+func (r *Recorder) Spawned(label string) { r.record(Spawned, label, "") }
+
+// Started records that goroutine label began running.
+func (r *Recorder) Started(label string) { r.record(Started, label, "") }
+
+// Blocked records that goroutine label is about to wait on on.
+func (r *Recorder) Blocked(label, on string) { r.record(Blocked, label, on) }
+
+// Progressf records a notable step of goroutine label.
+func (r *Recorder) Progressf(label, format string, args ...any) {
+	r.record(Progress, label, fmt.Sprintf(format, args...))
+}
+
+// Finished records that goroutine label returned.
+func (r *Recorder) Finished(label string) { r.record(Finished, label, "") }
+
+// Panicked records that goroutine label panicked with value v.
+func (r *Recorder) Panicked(label string, v any) { r.record(Panicked, label, fmt.Sprint(v)) }
+
+// Go starts fn in a new goroutine and records its Spawned, Started and
+// Finished events. If fn panics, Panicked is recorded and the panic
+// continues unwinding.
+func (r *Recorder) Go(label string, fn func()) {
+	r.Spawned(label)
+	go r.Run(label, fn)
+}
+
+// Run calls fn on the current goroutine and records its Started and
+// Finished (or Panicked) events. It is meant for goroutines started by
+// other helpers, e.g. taskgroup.Group.Go.
+func (r *Recorder) Run(label string, fn func()) {
+	r.Started(label)
+	defer func() {
+		if v := recover(); v != nil {
+			r.Panicked(label, v)
+			panic(v)
+		}
+		r.Finished(label)
+	}()
+	fn()
+}
+
+func (r *Recorder) record(kind Kind, label, detail string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Logging under the lock keeps the output in Seq order.
+	e := Event{Seq: r.seq, Kind: kind, Label: label, Detail: detail, At: time.Since(r.start)}
+	r.seq++
+	if r.keep {
+		r.events = append(r.events, e)
+	}
+	if r.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("goroutine", label),
+		slog.Duration("at", e.At),
+	}
+	if detail != "" {
+		attrs = append(attrs, slog.String("detail", detail))
+	}
+	r.logger.LogAttrs(context.Background(), slog.LevelInfo, kind.String(), attrs...)
+}
+
+// Events returns a copy of the events kept so far, in recording order.
+// It is empty unless the Recorder was created WithMemory.
+func (r *Recorder) Events() []Event {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Matcher selects events.
+type Matcher func(Event) bool
+
+// Match selects events of the given kind for goroutine label. If detail is
+// given, the event's Detail must contain it.
+func Match(kind Kind, label string, detail ...string) Matcher {
+	return func(e Event) bool {
+		if e.Kind != kind || e.Label != label {
+			return false
+		}
+		for _, d := range detail {
+			if !strings.Contains(e.Detail, d) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Find returns the first kept event matching m.
+func (r *Recorder) Find(m Matcher) (Event, bool) {
+	for _, e := range r.Events() {
+		if m(e) {
+			return e, true
+		}
+	}
+	return Event{}, false
+}
+
+// Before reports whether the first event matching a was recorded before the
+// first event matching b. It is false if either event is missing.
+func (r *Recorder) Before(a, b Matcher) bool {
+	ea, okA := r.Find(a)
+	eb, okB := r.Find(b)
+	return okA && okB && ea.Seq < eb.Seq
+}
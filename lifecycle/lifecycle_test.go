@@ -0,0 +1,183 @@
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// kinds returns the kinds of the events for label, in order.
+func kinds(events []Event, label string) []Kind {
+	var ks []Kind
+	for _, e := range events {
+		if e.Label == label {
+			ks = append(ks, e.Kind)
+		}
+	}
+	return ks
+}
+
+// waitFinished waits for the Finished event of label, which Go records
+// after fn has returned.
+func waitFinished(t *testing.T, rec *Recorder, label string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := rec.Find(Match(Finished, label)); ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s never finished:\n%v", label, rec.Events())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGoRecordsLifecycleInOrder(t *testing.T) {
+	rec := New(WithMemory())
+	done := make(chan struct{})
+	rec.Go("worker", func() {
+		defer close(done)
+		rec.Blocked("worker", "input")
+		rec.Progressf("worker", "handled %d items", 3)
+	})
+	<-done
+
+	waitFinished(t, rec, "worker")
+	want := []Kind{Spawned, Started, Blocked, Progress, Finished}
+	if got := kinds(rec.Events(), "worker"); !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if e, _ := rec.Find(Match(Progress, "worker", "handled")); e.Detail != "handled 3 items" {
+		t.Errorf("progress detail = %q, want %q", e.Detail, "handled 3 items")
+	}
+}
+
+func TestRunRecordsPanicAndRepanics(t *testing.T) {
+	rec := New(WithMemory())
+	func() {
+		defer func() {
+			if v := recover(); v != "boom" {
+				t.Errorf("recovered %v, want boom", v)
+			}
+		}()
+		rec.Run("worker", func() { panic("boom") })
+	}()
+
+	want := []Kind{Started, Panicked}
+	if got := kinds(rec.Events(), "worker"); !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if _, ok := rec.Find(Match(Finished, "worker")); ok {
+		t.Error("a goroutine that panicked was recorded as finished")
+	}
+}
+
+// The consumer of a channel stops only after the producer closed it, and
+// every send is recorded before the matching receive.
+func TestShutdownOrdering(t *testing.T) {
+	rec := New(WithMemory())
+	messages := make(chan string)
+	var wg sync.WaitGroup
+
+	wg.Add(2)
+	rec.Go("producer", func() {
+		defer wg.Done()
+		defer close(messages)
+		defer rec.Progressf("producer", "closing messages")
+		for _, m := range []string{"a", "b", "c"} {
+			rec.Progressf("producer", "sending %s", m)
+			messages <- m
+		}
+	})
+	rec.Go("consumer", func() {
+		defer wg.Done()
+		for m := range messages {
+			rec.Progressf("consumer", "received %s", m)
+		}
+	})
+	wg.Wait()
+	waitFinished(t, rec, "consumer")
+
+	closed := Match(Progress, "producer", "closing messages")
+	if !rec.Before(closed, Match(Finished, "consumer")) {
+		t.Errorf("consumer finished before producer closed messages:\n%v", rec.Events())
+	}
+	for _, m := range []string{"a", "b", "c"} {
+		if !rec.Before(Match(Progress, "producer", "sending "+m), Match(Progress, "consumer", "received "+m)) {
+			t.Errorf("%s received before it was sent:\n%v", m, rec.Events())
+		}
+	}
+	if rec.Before(Match(Finished, "consumer"), closed) {
+		t.Error("Before is true in both directions")
+	}
+}
+
+func TestBeforeMissingEvent(t *testing.T) {
+	rec := New(WithMemory())
+	rec.Spawned("a")
+	if rec.Before(Match(Spawned, "a"), Match(Finished, "a")) {
+		t.Error("Before is true although the second event is missing")
+	}
+}
+
+func TestSeqIsMonotonic(t *testing.T) {
+	rec := New(WithMemory())
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				rec.Progressf("worker", "step")
+			}
+		}()
+	}
+	wg.Wait()
+
+	events := rec.Events()
+	for i, e := range events {
+		if e.Seq != i {
+			t.Fatalf("event %d has Seq %d", i, e.Seq)
+		}
+		if i > 0 && e.At < events[i-1].At {
+			t.Fatalf("event %d at %v is earlier than event %d at %v", i, e.At, i-1, events[i-1].At)
+		}
+	}
+}
+
+func TestJSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	rec := New(WithJSON(&buf))
+	rec.Blocked("consumer", "messages")
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if line["msg"] != "blocked" || line["goroutine"] != "consumer" || line["detail"] != "messages" {
+		t.Errorf("unexpected fields: %v", line)
+	}
+	if _, ok := line["time"]; ok {
+		t.Error("wall-clock time is logged")
+	}
+	if rec.Events() != nil {
+		t.Error("events kept without WithMemory")
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var rec *Recorder
+	rec.Go("worker", func() {})
+	rec.Progressf("worker", "ignored")
+	if rec.Events() != nil {
+		t.Error("nil Recorder kept events")
+	}
+	if !strings.Contains(Kind(42).String(), "42") {
+		t.Errorf("Kind(42).String() = %q", Kind(42).String())
+	}
+}
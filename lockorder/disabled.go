@@ -0,0 +1,74 @@
+//go:build !lockorder
+
+package lockorder
+
+import (
+	"sync"
+	"time"
+
+	"go-concurrency/clock"
+)
+
+// Enabled reports whether lock order tracking is compiled in.
+const Enabled = false
+
+// Mutex is a sync.Mutex; build with the lockorder tag to track it.
+type Mutex struct {
+	mu sync.Mutex
+}
+
+// Lock locks m.
+func (m *Mutex) Lock() { m.mu.Lock() }
+
+// TryLock tries to lock m and reports whether it succeeded.
+func (m *Mutex) TryLock() bool { return m.mu.TryLock() }
+
+// Unlock unlocks m.
+func (m *Mutex) Unlock() { m.mu.Unlock() }
+
+// SetName names m in reports.
+func (m *Mutex) SetName(name string) {}
+
+// RWMutex is a sync.RWMutex; build with the lockorder tag to track it.
+type RWMutex struct {
+	mu sync.RWMutex
+}
+
+// Lock locks rw for writing.
+func (rw *RWMutex) Lock() { rw.mu.Lock() }
+
+// TryLock tries to lock rw for writing and reports whether it succeeded.
+func (rw *RWMutex) TryLock() bool { return rw.mu.TryLock() }
+
+// Unlock unlocks rw for writing.
+func (rw *RWMutex) Unlock() { rw.mu.Unlock() }
+
+// RLock locks rw for reading.
+func (rw *RWMutex) RLock() { rw.mu.RLock() }
+
+// TryRLock tries to lock rw for reading and reports whether it succeeded.
+func (rw *RWMutex) TryRLock() bool { return rw.mu.TryRLock() }
+
+// RUnlock undoes a single RLock call.
+func (rw *RWMutex) RUnlock() { rw.mu.RUnlock() }
+
+// RLocker returns a sync.Locker that calls RLock and RUnlock.
+func (rw *RWMutex) RLocker() sync.Locker { return rw.mu.RLocker() }
+
+// SetName names rw in reports.
+func (rw *RWMutex) SetName(name string) {}
+
+// SetReporter sets the function that receives reports and returns the
+// previous one.
+func SetReporter(fn func(Report)) func(Report) { return nil }
+
+// SetClock sets the clock hold times are measured on and returns the
+// previous one.
+func SetClock(clk clock.Clock) clock.Clock { return nil }
+
+// SetHoldThreshold sets how long a lock may be held before it is
+// reported; zero turns the check off. It returns the previous threshold.
+func SetHoldThreshold(d time.Duration) time.Duration { return 0 }
+
+// Reset forgets the lock graph and the problems already reported.
+func Reset() {}
@@ -0,0 +1,451 @@
+//go:build lockorder
+
+package lockorder
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/internal/goid"
+)
+
+// Enabled reports whether lock order tracking is compiled in.
+const Enabled = true
+
+// Mutex is a sync.Mutex whose acquisitions are checked against the lock
+// graph.
+type Mutex struct {
+	mu sync.Mutex
+}
+
+// Lock locks m, first reporting an inversion if taking m while holding
+// the calling goroutine's other locks closes a cycle.
+func (m *Mutex) Lock() {
+	pcs := acquiring(m)
+	m.mu.Lock()
+	acquired(m, pcs)
+}
+
+// TryLock tries to lock m and reports whether it succeeded. A TryLock
+// cannot deadlock, so it adds no edge to the lock graph, but locks taken
+// while holding m do.
+func (m *Mutex) TryLock() bool {
+	if !m.mu.TryLock() {
+		return false
+	}
+	acquired(m, callers())
+	return true
+}
+
+// Unlock unlocks m, reporting it if it was held too long.
+func (m *Mutex) Unlock() {
+	released(m)
+	m.mu.Unlock()
+}
+
+// SetName names m in reports; unnamed locks are shown by address.
+func (m *Mutex) SetName(name string) { setName(m, name) }
+
+// RWMutex is a sync.RWMutex whose acquisitions, for reading or writing,
+// are checked against the lock graph. Read locks take part because a
+// waiting writer blocks new readers, so readers can deadlock too.
+type RWMutex struct {
+	mu sync.RWMutex
+}
+
+// Lock locks rw for writing, first checking the lock order as Mutex.Lock
+// does.
+func (rw *RWMutex) Lock() {
+	pcs := acquiring(rw)
+	rw.mu.Lock()
+	acquired(rw, pcs)
+}
+
+// TryLock tries to lock rw for writing and reports whether it succeeded.
+func (rw *RWMutex) TryLock() bool {
+	if !rw.mu.TryLock() {
+		return false
+	}
+	acquired(rw, callers())
+	return true
+}
+
+// Unlock unlocks rw for writing, reporting it if it was held too long.
+func (rw *RWMutex) Unlock() {
+	released(rw)
+	rw.mu.Unlock()
+}
+
+// RLock locks rw for reading, first checking the lock order.
+func (rw *RWMutex) RLock() {
+	pcs := acquiring(rw)
+	rw.mu.RLock()
+	acquired(rw, pcs)
+}
+
+// TryRLock tries to lock rw for reading and reports whether it succeeded.
+func (rw *RWMutex) TryRLock() bool {
+	if !rw.mu.TryRLock() {
+		return false
+	}
+	acquired(rw, callers())
+	return true
+}
+
+// RUnlock undoes a single RLock call, reporting it if it was held too
+// long.
+func (rw *RWMutex) RUnlock() {
+	released(rw)
+	rw.mu.RUnlock()
+}
+
+// RLocker returns a sync.Locker that calls RLock and RUnlock.
+func (rw *RWMutex) RLocker() sync.Locker { return rlocker{rw} }
+
+type rlocker struct{ rw *RWMutex }
+
+func (r rlocker) Lock()   { r.rw.RLock() }
+func (r rlocker) Unlock() { r.rw.RUnlock() }
+
+// SetName names rw in reports; unnamed locks are shown by address.
+func (rw *RWMutex) SetName(name string) { setName(rw, name) }
+
+// A lock is a *Mutex or *RWMutex. The graph keeps every lock it has seen
+// reachable, which is fine for the debugging builds the tag is meant for.
+type lock any
+
+// hold is one acquisition of a lock still held.
+type hold struct {
+	l        lock
+	since    time.Time
+	pcs      []uintptr
+	reported bool // as a LongHold, by the watchdog
+}
+
+// edge records the first time to was acquired while from was held.
+type edge struct {
+	pcs []uintptr
+}
+
+var state = struct {
+	mu        sync.Mutex
+	held      map[int64][]hold // by goroutine
+	graph     map[lock]map[lock]edge
+	reported  map[[2]lock]bool
+	names     map[lock]string
+	threshold time.Duration
+	watchdog  *watchdog // running while threshold > 0
+	report    func(Report)
+	clock     clock.Clock
+}{
+	held:     make(map[int64][]hold),
+	graph:    make(map[lock]map[lock]edge),
+	reported: make(map[[2]lock]bool),
+	names:    make(map[lock]string),
+	report:   printReport,
+	clock:    clock.Real(),
+}
+
+func printReport(r Report) { fmt.Fprintf(os.Stderr, "lockorder: %s\n\n", r) }
+
+// SetReporter sets the function that receives reports, which by default
+// prints them to standard error, and returns the previous one. fn is
+// called without any lock held; nil restores the default.
+func SetReporter(fn func(Report)) func(Report) {
+	if fn == nil {
+		fn = printReport
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	prev := state.report
+	state.report = fn
+	return prev
+}
+
+// SetClock sets the clock hold times are measured on, which by default is
+// clock.Real(), and returns the previous one; nil restores the default. A
+// running watchdog is restarted on the new clock. Locks held at the time
+// keep the acquisition time read from the old one.
+func SetClock(clk clock.Clock) clock.Clock {
+	if clk == nil {
+		clk = clock.Real()
+	}
+	state.mu.Lock()
+	prev, old := state.clock, state.watchdog
+	state.clock = clk
+	if old != nil {
+		state.watchdog = startWatchdog(clk, max(state.threshold/2, time.Millisecond))
+	}
+	state.mu.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+	return prev
+}
+
+// SetHoldThreshold sets how long a lock may be held before it is
+// reported; zero, the default, turns the check off. While the check is on,
+// a watchdog goroutine looks for long holds every half threshold, so a
+// hold is reported at most about 1.5 thresholds after it began even if
+// the lock is never released. It returns the previous threshold.
+func SetHoldThreshold(d time.Duration) time.Duration {
+	state.mu.Lock()
+	prev, old := state.threshold, state.watchdog
+	state.threshold, state.watchdog = d, nil
+	if d > 0 {
+		state.watchdog = startWatchdog(state.clock, max(d/2, time.Millisecond))
+	}
+	state.mu.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+	return prev
+}
+
+// watchdog reports locks held past the threshold while they are held.
+type watchdog struct {
+	quit, done chan struct{}
+}
+
+func startWatchdog(clk clock.Clock, every time.Duration) *watchdog {
+	w := &watchdog{quit: make(chan struct{}), done: make(chan struct{})}
+	ticker := clk.NewTicker(every)
+	go func() {
+		defer close(w.done)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C():
+				reportLongHolds()
+			case <-w.quit:
+				return
+			}
+		}
+	}()
+	return w
+}
+
+// stop ends the watchdog and waits for it to exit.
+func (w *watchdog) stop() {
+	close(w.quit)
+	<-w.done
+}
+
+// reportLongHolds reports every hold past the threshold not reported yet.
+func reportLongHolds() {
+	state.mu.Lock()
+	var reports []Report
+	if threshold := state.threshold; threshold > 0 {
+		for _, holds := range state.held {
+			for i := range holds {
+				h := &holds[i]
+				if held := state.clock.Since(h.since); !h.reported && held > threshold {
+					h.reported = true
+					reports = append(reports, Report{
+						Kind:      LongHold,
+						Locks:     []string{name(h.l)},
+						Stacks:    [][]runtime.Frame{frames(h.pcs)},
+						Held:      held,
+						StillHeld: true,
+					})
+				}
+			}
+		}
+	}
+	report := state.report
+	state.mu.Unlock()
+
+	for _, r := range reports {
+		report(r)
+	}
+}
+
+// Reset forgets the lock graph and the problems already reported. Locks
+// held at the time stay held.
+func Reset() {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.graph = make(map[lock]map[lock]edge)
+	state.reported = make(map[[2]lock]bool)
+}
+
+func setName(l lock, name string) {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.names[l] = name
+}
+
+// acquiring adds the edges from the locks the calling goroutine holds to
+// l, reporting the first one that closes a cycle, and returns the stack
+// for acquired. It is called before blocking on l, so the report is made
+// even if this acquisition is the one that deadlocks.
+func acquiring(l lock) []uintptr {
+	pcs := callers()
+	g := goid.Current()
+
+	state.mu.Lock()
+	var reports []Report
+	for _, h := range state.held[g] {
+		if h.l == l || hasEdge(h.l, l) {
+			continue
+		}
+		if state.graph[h.l] == nil {
+			state.graph[h.l] = make(map[lock]edge)
+		}
+		state.graph[h.l][l] = edge{pcs: pcs}
+
+		// A path back from l to h.l means some goroutine took them the
+		// other way round
+		path := findPath(l, h.l)
+		if path == nil {
+			continue
+		}
+		key := [2]lock{h.l, l}
+		if state.reported[key] {
+			continue
+		}
+		state.reported[key] = true
+		conflict := state.graph[path[len(path)-2]][path[len(path)-1]]
+		names := make([]string, len(path))
+		for i, p := range path {
+			names[i] = name(p)
+		}
+		reports = append(reports, Report{
+			Kind:   Inversion,
+			Locks:  names,
+			Stacks: [][]runtime.Frame{frames(pcs), frames(conflict.pcs)},
+		})
+	}
+	report := state.report
+	state.mu.Unlock()
+
+	for _, r := range reports {
+		report(r)
+	}
+	return pcs
+}
+
+// acquired records that the calling goroutine holds l.
+func acquired(l lock, pcs []uintptr) {
+	g := goid.Current()
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	state.held[g] = append(state.held[g], hold{l: l, since: state.clock.Now(), pcs: pcs})
+}
+
+// released forgets the calling goroutine's most recent hold of l, or any
+// goroutine's if it has none, since a lock may be released by a goroutine
+// other than the one that acquired it. It reports l if it was held longer
+// than the threshold and the watchdog has not reported it yet.
+func released(l lock) {
+	g := goid.Current()
+	state.mu.Lock()
+	h, ok := removeHold(g, l)
+	if !ok {
+		for other := range state.held {
+			if h, ok = removeHold(other, l); ok {
+				break
+			}
+		}
+	}
+	threshold, report := state.threshold, state.report
+	var r *Report
+	if ok && threshold > 0 && !h.reported {
+		if held := state.clock.Since(h.since); held > threshold {
+			r = &Report{Kind: LongHold, Locks: []string{name(l)}, Stacks: [][]runtime.Frame{frames(h.pcs)}, Held: held}
+		}
+	}
+	state.mu.Unlock()
+
+	if r != nil {
+		report(*r)
+	}
+}
+
+// removeHold removes goroutine g's most recent hold of l; state.mu is
+// held.
+func removeHold(g int64, l lock) (hold, bool) {
+	holds := state.held[g]
+	for i := len(holds) - 1; i >= 0; i-- {
+		if holds[i].l == l {
+			h := holds[i]
+			holds = append(holds[:i], holds[i+1:]...)
+			if len(holds) == 0 {
+				delete(state.held, g)
+			} else {
+				state.held[g] = holds
+			}
+			return h, true
+		}
+	}
+	return hold{}, false
+}
+
+// hasEdge reports whether the graph has an edge from a to b; state.mu is
+// held.
+func hasEdge(a, b lock) bool {
+	_, ok := state.graph[a][b]
+	return ok
+}
+
+// findPath returns a path of edges from a to b, both included, or nil;
+// state.mu is held.
+func findPath(a, b lock) []lock {
+	prev := map[lock]lock{a: nil}
+	queue := []lock{a}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for next := range state.graph[n] {
+			if _, seen := prev[next]; seen {
+				continue
+			}
+			prev[next] = n
+			if next == b {
+				var path []lock
+				for p := b; p != nil; p = prev[p] {
+					path = append([]lock{p}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// name returns l's name in reports; state.mu is held.
+func name(l lock) string {
+	if n, ok := state.names[l]; ok {
+		return n
+	}
+	return fmt.Sprintf("%T(%p)", l, l)
+}
+
+// callers returns the stack of the goroutine acquiring a lock.
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(3, pcs)]
+}
+
+// frames formats pcs, leaving out this package's own frames.
+func frames(pcs []uintptr) []runtime.Frame {
+	var out []runtime.Frame
+	fs := runtime.CallersFrames(pcs)
+	for {
+		f, more := fs.Next()
+		if !strings.HasPrefix(f.Function, "go-concurrency/lockorder.") {
+			out = append(out, f)
+		}
+		if !more {
+			return out
+		}
+	}
+}
@@ -0,0 +1,141 @@
+//go:build lockorder
+
+package lockorder_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/lockorder"
+)
+
+// collect installs a reporter for the rest of the test and returns a
+// function returning the reports so far.
+func collect(t *testing.T) func() []lockorder.Report {
+	var (
+		mu      sync.Mutex
+		reports []lockorder.Report
+	)
+	lockorder.Reset()
+	prev := lockorder.SetReporter(func(r lockorder.Report) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, r)
+	})
+	t.Cleanup(func() {
+		lockorder.SetReporter(prev)
+		lockorder.Reset()
+	})
+	return func() []lockorder.Report {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]lockorder.Report(nil), reports...)
+	}
+}
+
+// waitFor returns the reports once there is one, giving a watchdog that
+// has found a long hold a second to deliver it.
+func waitFor(reports func() []lockorder.Report) []lockorder.Report {
+	deadline := time.Now().Add(time.Second)
+	for len(reports()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	return reports()
+}
+
+func TestInversion(t *testing.T) {
+	reports := collect(t)
+	var a, b lockorder.Mutex
+	a.SetName("a")
+	b.SetName("b")
+
+	a.Lock()
+	b.Lock()
+	b.Unlock()
+	a.Unlock()
+	if r := reports(); len(r) != 0 {
+		t.Fatalf("reports after a single order: %v", r)
+	}
+
+	b.Lock()
+	a.Lock()
+	a.Unlock()
+	b.Unlock()
+	r := reports()
+	if len(r) != 1 || r[0].Kind != lockorder.Inversion || len(r[0].Stacks) != 2 {
+		t.Fatalf("reports after the opposite order: %v, want one inversion with two stacks", r)
+	}
+	if got := r[0].Locks; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("inversion locks = %v, want [a b]", got)
+	}
+}
+
+// A lock held past the threshold is reported by the watchdog while it is
+// still held, and not again when it is released; one held for exactly the
+// threshold is not reported at all.
+func TestLongHoldReportedWhileHeld(t *testing.T) {
+	reports := collect(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer lockorder.SetClock(lockorder.SetClock(fake))
+	leaktest.VerifyNone(t, func() {
+		defer lockorder.SetHoldThreshold(lockorder.SetHoldThreshold(10 * time.Millisecond))
+		fake.BlockUntil(1) // the watchdog's ticker
+
+		var m lockorder.Mutex
+		m.Lock()
+		fake.Advance(10 * time.Millisecond)
+		m.Unlock()
+		if r := reports(); len(r) != 0 {
+			t.Fatalf("reports for a hold of exactly the threshold: %v", r)
+		}
+
+		var rw lockorder.RWMutex
+		rw.SetName("config")
+		rw.Lock()
+		fake.Advance(15 * time.Millisecond)
+		r := waitFor(reports)
+		if len(r) != 1 || r[0].Kind != lockorder.LongHold || !r[0].StillHeld || r[0].Locks[0] != "config" {
+			t.Fatalf("reports while held: %v, want one long hold of config still held", r)
+		}
+		if r[0].Held != 15*time.Millisecond {
+			t.Errorf("Held = %v, want 15ms", r[0].Held)
+		}
+		rw.Unlock()
+		if r := reports(); len(r) != 1 {
+			t.Errorf("release added reports: %v", r[1:])
+		}
+	})
+}
+
+// A hold that jumps past the threshold between two ticks is reported once,
+// by whichever of the watchdog and Unlock sees it first.
+func TestLongHoldReportedOnce(t *testing.T) {
+	reports := collect(t)
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer lockorder.SetClock(lockorder.SetClock(fake))
+	defer lockorder.SetHoldThreshold(lockorder.SetHoldThreshold(time.Second))
+
+	var m lockorder.Mutex
+	m.SetName("ledger")
+	m.Lock()
+	fake.Advance(2 * time.Second)
+	m.Unlock()
+	r := waitFor(reports)
+	if len(r) != 1 || r[0].Kind != lockorder.LongHold || r[0].Locks[0] != "ledger" || r[0].Held != 2*time.Second {
+		t.Fatalf("reports: %v, want one long hold of ledger for 2s", r)
+	}
+}
+
+func TestShortHoldNotReported(t *testing.T) {
+	reports := collect(t)
+	defer lockorder.SetHoldThreshold(lockorder.SetHoldThreshold(time.Second))
+	var m lockorder.Mutex
+	m.Lock()
+	m.Unlock()
+	if r := reports(); len(r) != 0 {
+		t.Errorf("reports for a short hold: %v", r)
+	}
+}
@@ -0,0 +1,99 @@
+// Package lockorder provides Mutex and RWMutex, drop-in replacements for
+// the sync types that, in builds with the lockorder tag, check the order
+// in which goroutines acquire them:
+//
+//	go run -tags lockorder ./cmd/3-sync
+//
+// Two goroutines that take the same two locks in opposite orders can
+// deadlock, but usually do not, so the bug hides until production. With
+// the tag, every acquisition made while holding other locks adds an edge
+// to a global lock graph, and the first acquisition that closes a cycle
+// is reported with its stack and the stack of the acquisition it
+// conflicts with, before the goroutine blocks. Locks held longer than a
+// threshold are reported too: by a watchdog while they are still held, so
+// a lock that is never released is reported as well, or on release if
+// the watchdog has not seen them yet.
+//
+// Without the tag the types are thin wrappers around sync.Mutex and
+// sync.RWMutex that the compiler inlines, and the configuration functions
+// do nothing, so production builds pay nothing.
+package lockorder
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Kind is the type of problem a Report describes.
+type Kind int
+
+const (
+	// Inversion is an acquisition that closes a cycle in the lock graph:
+	// the goroutines involved can deadlock.
+	Inversion Kind = iota
+	// LongHold is a lock held longer than the threshold set with
+	// SetHoldThreshold, reported once per acquisition.
+	LongHold
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Inversion:
+		return "lock order inversion"
+	case LongHold:
+		return "lock held too long"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Report describes a potential deadlock or a long-held lock.
+type Report struct {
+	Kind Kind
+	// Locks names the locks involved. For an Inversion it is the cycle,
+	// starting with the lock being acquired and ending with a lock the
+	// goroutine already holds; for a LongHold it is the lock.
+	Locks []string
+	// Stacks are the call stacks involved. For an Inversion the first is
+	// the acquisition that closed the cycle and the second the earlier one
+	// taking the locks in the opposite order; for a LongHold it is where
+	// the lock was acquired.
+	Stacks [][]runtime.Frame
+	// Held is how long the lock was held, for a LongHold.
+	Held time.Duration
+	// StillHeld is true for a LongHold reported before the lock was
+	// released; Held is then how long it had been held so far.
+	StillHeld bool
+}
+
+func (r Report) String() string {
+	var b strings.Builder
+	switch r.Kind {
+	case Inversion:
+		fmt.Fprintf(&b, "%s: %s", r.Kind, strings.Join(r.Locks, " -> "))
+		if len(r.Locks) > 0 {
+			fmt.Fprintf(&b, " -> %s", r.Locks[0])
+		}
+	case LongHold:
+		fmt.Fprintf(&b, "%s: %s held for %v", r.Kind, strings.Join(r.Locks, ", "), r.Held)
+		if r.StillHeld {
+			b.WriteString(" and not yet released")
+		}
+	default:
+		b.WriteString(r.Kind.String())
+	}
+	titles := []string{"acquired at", "conflicts with"}
+	if r.Kind == LongHold {
+		titles = []string{"acquired at"}
+	}
+	for i, stack := range r.Stacks {
+		if i < len(titles) {
+			fmt.Fprintf(&b, "\n%s:", titles[i])
+		}
+		for _, f := range stack {
+			fmt.Fprintf(&b, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,38 @@
+package lockorder_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/lockorder"
+)
+
+func TestReportString(t *testing.T) {
+	stack := []runtime.Frame{{Function: "main.transfer", File: "bank.go", Line: 12}}
+	for _, tt := range []struct {
+		r    lockorder.Report
+		want []string
+	}{
+		{
+			lockorder.Report{Kind: lockorder.Inversion, Locks: []string{"alice", "bob"}, Stacks: [][]runtime.Frame{stack, stack}},
+			[]string{"lock order inversion: alice -> bob -> alice", "acquired at:", "conflicts with:", "main.transfer", "bank.go:12"},
+		},
+		{
+			lockorder.Report{Kind: lockorder.LongHold, Locks: []string{"alice"}, Stacks: [][]runtime.Frame{stack}, Held: 30 * time.Millisecond},
+			[]string{"lock held too long: alice held for 30ms\nacquired at:"},
+		},
+		{
+			lockorder.Report{Kind: lockorder.LongHold, Locks: []string{"alice"}, Held: 30 * time.Millisecond, StillHeld: true},
+			[]string{"alice held for 30ms and not yet released"},
+		},
+	} {
+		got := tt.r.String()
+		for _, want := range tt.want {
+			if !strings.Contains(got, want) {
+				t.Errorf("String() = %q, want it to contain %q", got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Package lockstat provides named Mutex and RWMutex types that measure
+// their own contention: how often they are acquired, how often and how
+// long callers wait for them, how long they are held and who holds them
+// now.
+//
+// Locks registered with a Registry can be ranked into a "hottest locks"
+// report, which also lists the runtime's own mutex profile when it is
+// enabled with runtime.SetMutexProfileFraction:
+//
+//	reg := lockstat.NewRegistry()
+//	mu := lockstat.NewMutex("cache", lockstat.WithRegistry(reg))
+//	...
+//	reg.WriteReport(os.Stdout, 10)
+package lockstat
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/internal/metric"
+)
+
+// Option configures a lock.
+type Option func(*options)
+
+type options struct {
+	clk clock.Clock
+	reg *Registry
+}
+
+// WithClock measures waits and holds on clk instead of the wall clock.
+func WithClock(clk clock.Clock) Option {
+	return func(o *options) { o.clk = clk }
+}
+
+// WithRegistry registers the lock with reg under its name, replacing any
+// lock registered under the same name.
+func WithRegistry(reg *Registry) Option {
+	return func(o *options) { o.reg = reg }
+}
+
+// Mutex is a named sync.Mutex that records its contention. Create one with
+// NewMutex.
+type Mutex struct {
+	mu sync.Mutex
+	stats
+}
+
+// NewMutex returns an unlocked Mutex called name.
+func NewMutex(name string, opts ...Option) *Mutex {
+	m := &Mutex{}
+	m.init(name, "mutex", opts, m)
+	return m
+}
+
+// Lock locks m, recording how long it waited.
+func (m *Mutex) Lock() {
+	if m.mu.TryLock() {
+		m.waited(time.Time{})
+	} else {
+		start := m.clk.Now()
+		m.mu.Lock()
+		m.waited(start)
+	}
+	m.acquired(caller())
+}
+
+// TryLock tries to lock m and reports whether it succeeded. A failed
+// TryLock is not counted.
+func (m *Mutex) TryLock() bool {
+	if !m.mu.TryLock() {
+		return false
+	}
+	m.waited(time.Time{})
+	m.acquired(caller())
+	return true
+}
+
+// Unlock unlocks m, recording how long it was held.
+func (m *Mutex) Unlock() {
+	m.releasing()
+	m.mu.Unlock()
+}
+
+// RWMutex is a named sync.RWMutex that records its contention. Read
+// acquisitions are counted and their waits measured, but only exclusive
+// holds are timed. Create one with NewRWMutex.
+type RWMutex struct {
+	mu sync.RWMutex
+	stats
+}
+
+// NewRWMutex returns an unlocked RWMutex called name.
+func NewRWMutex(name string, opts ...Option) *RWMutex {
+	rw := &RWMutex{}
+	rw.init(name, "rwmutex", opts, rw)
+	return rw
+}
+
+// Lock locks rw for writing, recording how long it waited.
+func (rw *RWMutex) Lock() {
+	if rw.mu.TryLock() {
+		rw.waited(time.Time{})
+	} else {
+		start := rw.clk.Now()
+		rw.mu.Lock()
+		rw.waited(start)
+	}
+	rw.acquired(caller())
+}
+
+// TryLock tries to lock rw for writing and reports whether it succeeded.
+func (rw *RWMutex) TryLock() bool {
+	if !rw.mu.TryLock() {
+		return false
+	}
+	rw.waited(time.Time{})
+	rw.acquired(caller())
+	return true
+}
+
+// Unlock unlocks rw for writing, recording how long it was held.
+func (rw *RWMutex) Unlock() {
+	rw.releasing()
+	rw.mu.Unlock()
+}
+
+// RLock locks rw for reading, recording how long it waited.
+func (rw *RWMutex) RLock() {
+	if rw.mu.TryRLock() {
+		rw.waited(time.Time{})
+	} else {
+		start := rw.clk.Now()
+		rw.mu.RLock()
+		rw.waited(start)
+	}
+	rw.rAcquires.Add(1)
+	rw.readers.Add(1)
+}
+
+// TryRLock tries to lock rw for reading and reports whether it succeeded.
+func (rw *RWMutex) TryRLock() bool {
+	if !rw.mu.TryRLock() {
+		return false
+	}
+	rw.waited(time.Time{})
+	rw.rAcquires.Add(1)
+	rw.readers.Add(1)
+	return true
+}
+
+// RUnlock undoes a single RLock call.
+func (rw *RWMutex) RUnlock() {
+	rw.readers.Add(-1)
+	rw.mu.RUnlock()
+}
+
+// stats holds the measurements shared by Mutex and RWMutex.
+type stats struct {
+	name string
+	kind string
+	clk  clock.Clock
+
+	acquires  atomic.Int64
+	rAcquires atomic.Int64
+	readers   atomic.Int64
+	wait      metric.Histogram
+	hold      metric.Histogram
+	holder    atomic.Uintptr // pc of the exclusive holder's Lock call, or 0
+	heldSince atomic.Int64   // unix nanoseconds, while held exclusively
+}
+
+func (s *stats) init(name, kind string, opts []Option, c Collector) {
+	o := options{clk: clock.Real()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	s.name, s.kind, s.clk = name, kind, o.clk
+	if o.reg != nil {
+		o.reg.Register(c)
+	}
+}
+
+// Name returns the lock's name.
+func (s *stats) Name() string { return s.name }
+
+// waited records an acquisition that waited since start, or did not have
+// to wait if start is zero.
+func (s *stats) waited(start time.Time) {
+	if start.IsZero() {
+		s.wait.Observe(0, false)
+		return
+	}
+	s.wait.Observe(s.clk.Since(start), true)
+}
+
+// acquired records the exclusive holder, called from pc.
+func (s *stats) acquired(pc uintptr) {
+	s.acquires.Add(1)
+	s.heldSince.Store(s.clk.Now().UnixNano())
+	s.holder.Store(pc)
+}
+
+// releasing records the end of an exclusive hold.
+func (s *stats) releasing() {
+	since := s.heldSince.Swap(0)
+	s.holder.Store(0)
+	if since != 0 {
+		s.hold.Observe(s.clk.Since(time.Unix(0, since)), false)
+	}
+}
+
+// caller returns the pc of the call to Lock or TryLock.
+func caller() uintptr {
+	var pc [1]uintptr
+	if runtime.Callers(3, pc[:]) == 0 {
+		return 0
+	}
+	return pc[0]
+}
+
+// Stats is a point-in-time view of a lock's measurements.
+type Stats struct {
+	Name      string
+	Kind      string    // "mutex" or "rwmutex"
+	Acquires  int64     // exclusive acquisitions
+	RAcquires int64     // read acquisitions, for an RWMutex
+	Wait      Histogram // time spent waiting to acquire, in either mode
+	Hold      Histogram // time held exclusively
+	Holder    string    // where the exclusive holder locked it, e.g. "main.work:42"; empty if not held
+	HeldSince time.Time // zero unless held exclusively
+	Readers   int64     // read locks held now
+}
+
+// Contended returns the number of acquisitions that had to wait.
+func (s Stats) Contended() int64 { return s.Wait.Blocked }
+
+// Stats returns the lock's measurements so far.
+func (s *stats) Stats() Stats {
+	st := Stats{
+		Name:      s.name,
+		Kind:      s.kind,
+		Acquires:  s.acquires.Load(),
+		RAcquires: s.rAcquires.Load(),
+		Wait:      s.wait.Summary(),
+		Hold:      s.hold.Summary(),
+		Readers:   s.readers.Load(),
+	}
+	if since := s.heldSince.Load(); since != 0 {
+		st.HeldSince = time.Unix(0, since)
+		if pc := s.holder.Load(); pc != 0 {
+			st.Holder = location(pc)
+		}
+	}
+	return st
+}
+
+// location formats the call at return address pc as "function:line".
+func location(pc uintptr) string {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+}
+
+// Histogram summarizes a set of durations. For waits, Blocked counts the
+// acquisitions that did not succeed at once.
+type Histogram = metric.Summary
+
+// Bucket counts the durations no longer than UpperBound.
+type Bucket = metric.Bucket
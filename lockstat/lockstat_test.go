@@ -0,0 +1,111 @@
+package lockstat_test
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/lockstat"
+)
+
+var start = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// locker is what Mutex and RWMutex have in common.
+type locker interface {
+	sync.Locker
+	Stats() lockstat.Stats
+}
+
+// contend holds l for d of fake time while another goroutine waits for
+// it, then lets that goroutine hold it for hold more before locking it
+// once more without contention. l is unlocked when contend returns.
+func contend(fake *clock.Fake, l locker, d, hold time.Duration) {
+	l.Lock()
+	acquired := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		l.Lock()
+		close(acquired)
+		<-release
+		fake.Advance(hold)
+		l.Unlock()
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond) // let the goroutine start waiting
+	fake.Advance(d)
+	l.Unlock()
+	<-acquired
+	close(release)
+	<-done
+	l.Lock()
+	l.Unlock()
+}
+
+func TestCountsAndHistograms(t *testing.T) {
+	fake := clock.NewFake(start)
+	m := lockstat.NewMutex("counter", lockstat.WithClock(fake))
+	contend(fake, m, 30*time.Millisecond, 5*time.Millisecond)
+
+	s := m.Stats()
+	if s.Name != "counter" || s.Kind != "mutex" || s.Acquires != 3 {
+		t.Errorf("Name %q, Kind %q, Acquires %d; want counter, mutex, 3", s.Name, s.Kind, s.Acquires)
+	}
+	if c := s.Contended(); c != 1 || s.Wait.Count != 3 || s.Wait.Max != 30*time.Millisecond {
+		t.Errorf("Contended %d, waits %d, longest %v; want 1, 3, 30ms", c, s.Wait.Count, s.Wait.Max)
+	}
+	if s.Hold.Count != 3 || s.Hold.Max != 30*time.Millisecond || s.Hold.Total != 35*time.Millisecond {
+		t.Errorf("holds: %d, max %v, total %v; want 3, 30ms, 35ms", s.Hold.Count, s.Hold.Max, s.Hold.Total)
+	}
+	if b := s.Hold.Buckets; b[3].Count != 1 || b[4].Count != 2 || b[5].Count != 3 {
+		t.Errorf("hold buckets up to 1ms, 10ms, 100ms = %d, %d, %d; want 1, 2, 3", b[3].Count, b[4].Count, b[5].Count)
+	}
+	if m.TryLock() {
+		m.Unlock()
+	}
+	if n := m.Stats().Acquires; n != 4 {
+		t.Errorf("Acquires = %d after a TryLock, want 4", n)
+	}
+}
+
+func TestRWMutexReaders(t *testing.T) {
+	fake := clock.NewFake(start)
+	rw := lockstat.NewRWMutex("cache", lockstat.WithClock(fake))
+	rw.RLock()
+	if !rw.TryRLock() {
+		t.Fatal("TryRLock() failed with only readers holding the lock")
+	}
+	if rw.TryLock() {
+		t.Fatal("TryLock() succeeded while read locked")
+	}
+	s := rw.Stats()
+	if s.Kind != "rwmutex" || s.RAcquires != 2 || s.Readers != 2 || s.Acquires != 0 || s.Holder != "" {
+		t.Errorf("read locked: %+v; want 2 read acquires, 2 readers, no exclusive holder", s)
+	}
+	rw.RUnlock()
+	rw.RUnlock()
+	if n := rw.Stats().Readers; n != 0 {
+		t.Errorf("Readers = %d after both RUnlocks", n)
+	}
+	if s := rw.Stats(); s.Hold.Count != 0 || s.Wait.Count != 2 {
+		t.Errorf("read holds timed (%d) or waits miscounted (%d); want 0 and 2", s.Hold.Count, s.Wait.Count)
+	}
+}
+
+func lockHere(m *lockstat.Mutex) { m.Lock() }
+
+func TestHolder(t *testing.T) {
+	fake := clock.NewFake(start)
+	m := lockstat.NewMutex("config", lockstat.WithClock(fake))
+	lockHere(m)
+	s := m.Stats()
+	if !strings.HasPrefix(s.Holder, "go-concurrency/lockstat_test.lockHere:") || !s.HeldSince.Equal(start) {
+		t.Errorf("Holder %q since %v, want lockHere since %v", s.Holder, s.HeldSince, start)
+	}
+	m.Unlock()
+	if s := m.Stats(); s.Holder != "" || !s.HeldSince.IsZero() {
+		t.Errorf("after Unlock: Holder %q since %v, want none", s.Holder, s.HeldSince)
+	}
+}
@@ -0,0 +1,203 @@
+package lockstat
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go-concurrency/internal/metric"
+)
+
+// Collector is anything that can report Stats; every Mutex and RWMutex is
+// one.
+type Collector interface {
+	Name() string
+	Stats() Stats
+}
+
+// Registry is a named set of locks reported on together. It is an
+// http.Handler serving the hottest locks report; the query parameter n
+// sets how many locks it lists.
+type Registry struct {
+	collectors metric.Registry[Collector]
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds c, replacing any collector with the same name.
+func (r *Registry) Register(c Collector) { r.collectors.Register(c) }
+
+// Unregister removes the collector with the given name.
+func (r *Registry) Unregister(name string) { r.collectors.Unregister(name) }
+
+// Stats returns the stats of every registered lock, by name.
+func (r *Registry) Stats() []Stats {
+	collectors := r.collectors.Collectors()
+	stats := make([]Stats, len(collectors))
+	for i, c := range collectors {
+		stats[i] = c.Stats()
+	}
+	return stats
+}
+
+// Hottest returns the stats of at most n registered locks, most contended
+// first: by total time spent waiting, then by the number of acquisitions
+// that had to wait. n <= 0 returns all of them.
+func (r *Registry) Hottest(n int) []Stats {
+	stats := r.Stats()
+	slices.SortStableFunc(stats, func(a, b Stats) int {
+		return cmp.Or(
+			cmp.Compare(b.Wait.Total, a.Wait.Total),
+			cmp.Compare(b.Contended(), a.Contended()),
+		)
+	})
+	if n > 0 && n < len(stats) {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// ServeHTTP writes the hottest locks report as plain text.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	n, err := strconv.Atoi(req.URL.Query().Get("n"))
+	if err != nil {
+		n = 10
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := r.WriteReport(w, n); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// WriteReport writes a table of the n hottest registered locks, as ranked
+// by Hottest. If the runtime mutex profile is enabled, with
+// runtime.SetMutexProfileFraction, it also lists the n call sites where
+// the runtime saw the most contention, on any sync.Mutex or RWMutex in the
+// program, including the ones inside this package's locks.
+func (r *Registry) WriteReport(w io.Writer, n int) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "Hottest locks, by time spent waiting:")
+	tw := tabwriter.NewWriter(bw, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LOCK\tKIND\tACQUIRES\tCONTENDED\tWAIT TOTAL\tWAIT MAX\tHOLD MEAN\tHOLD MAX\tHOLDER")
+	for _, s := range r.Hottest(n) {
+		acquires := strconv.FormatInt(s.Acquires, 10)
+		if s.Kind == "rwmutex" {
+			acquires += fmt.Sprintf(" (+%d read)", s.RAcquires)
+		}
+		contended := "0"
+		if total := s.Acquires + s.RAcquires; total > 0 {
+			contended = fmt.Sprintf("%d (%.0f%%)", s.Contended(), 100*float64(s.Contended())/float64(total))
+		}
+		holder := s.Holder
+		switch {
+		case holder == "" && s.Readers > 0:
+			holder = fmt.Sprintf("%d reader(s)", s.Readers)
+		case holder == "":
+			holder = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%v\t%v\t%v\t%v\t%s\n", s.Name, s.Kind, acquires, contended,
+			metric.Round(s.Wait.Total), metric.Round(s.Wait.Max), metric.Round(s.Hold.Mean()), metric.Round(s.Hold.Max), holder)
+	}
+	tw.Flush()
+
+	if rate := runtime.SetMutexProfileFraction(-1); rate > 0 {
+		fmt.Fprintf(bw, "\nRuntime mutex profile (sampling 1 in %d), by delay:\n", rate)
+		tw := tabwriter.NewWriter(bw, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "DELAY\tCONTENTIONS\tUNLOCKED AT")
+		for _, site := range mutexProfile(n) {
+			fmt.Fprintf(tw, "%v\t%d\t%s\n", metric.Round(site.delay), site.count, site.at)
+		}
+		tw.Flush()
+	}
+	return bw.Flush()
+}
+
+// site is the runtime mutex profile's contention at one call site.
+type site struct {
+	at    string
+	count int64
+	delay time.Duration
+}
+
+// mutexProfile returns the n call sites with the longest delays in the
+// runtime mutex profile. The runtime records where a contended lock was
+// unlocked; each record is attributed to the first caller outside the
+// runtime, the sync package and this one, and scaled by the sampling rate
+// as pprof does.
+func mutexProfile(n int) []site {
+	var records []runtime.BlockProfileRecord
+	size, _ := runtime.MutexProfile(nil)
+	for {
+		records = make([]runtime.BlockProfileRecord, size+10)
+		var ok bool
+		if size, ok = runtime.MutexProfile(records); ok {
+			records = records[:size]
+			break
+		}
+	}
+
+	rate := int64(max(runtime.SetMutexProfileFraction(-1), 1))
+	perSecond := cyclesPerSecond()
+	bySite := make(map[string]*site)
+	for _, rec := range records {
+		at := "?"
+		frames := runtime.CallersFrames(rec.Stack())
+		for {
+			f, more := frames.Next()
+			if !strings.HasPrefix(f.Function, "runtime.") && !strings.HasPrefix(f.Function, "sync.") &&
+				!strings.HasPrefix(f.Function, "go-concurrency/lockstat.") {
+				at = fmt.Sprintf("%s:%d", f.Function, f.Line)
+				break
+			}
+			if !more {
+				break
+			}
+		}
+		s := bySite[at]
+		if s == nil {
+			s = &site{at: at}
+			bySite[at] = s
+		}
+		s.count += rec.Count * rate
+		s.delay += time.Duration(float64(rec.Cycles*rate) / perSecond * float64(time.Second))
+	}
+
+	sites := make([]site, 0, len(bySite))
+	for _, s := range bySite {
+		sites = append(sites, *s)
+	}
+	slices.SortFunc(sites, func(a, b site) int { return cmp.Compare(b.delay, a.delay) })
+	if n > 0 && n < len(sites) {
+		sites = sites[:n]
+	}
+	return sites
+}
+
+// cyclesPerSecond returns the rate of the clock the runtime measures
+// contention in. It is not exported by the runtime, but the text form of
+// the mutex profile starts with it.
+func cyclesPerSecond() float64 {
+	var buf bytes.Buffer
+	pprof.Lookup("mutex").WriteTo(&buf, 1)
+	for line := range strings.Lines(buf.String()) {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "cycles/second="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 {
+				return f
+			}
+		}
+	}
+	return 1e9 // nanoseconds, a reasonable guess
+}
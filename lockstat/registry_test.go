@@ -0,0 +1,94 @@
+package lockstat_test
+
+import (
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go-concurrency/clock"
+	"go-concurrency/lockstat"
+)
+
+// registry returns locks that waited 50ms, 10ms and not at all, in a
+// Registry that also held a lock since unregistered.
+func registry(fake *clock.Fake) *lockstat.Registry {
+	reg := lockstat.NewRegistry()
+	opts := []lockstat.Option{lockstat.WithClock(fake), lockstat.WithRegistry(reg)}
+	cold := lockstat.NewMutex("cold", opts...)
+	warm := lockstat.NewMutex("warm", opts...)
+	hot := lockstat.NewRWMutex("hot", opts...)
+	lockstat.NewMutex("gone", opts...)
+	reg.Unregister("gone")
+
+	contend(fake, hot, 50*time.Millisecond, 0)
+	contend(fake, warm, 10*time.Millisecond, 0)
+	cold.Lock()
+	cold.Unlock()
+	hot.RLock()
+	return reg
+}
+
+func names(stats []lockstat.Stats) string {
+	var s []string
+	for _, st := range stats {
+		s = append(s, st.Name)
+	}
+	return strings.Join(s, " ")
+}
+
+func TestHottest(t *testing.T) {
+	reg := registry(clock.NewFake(start))
+	if got := names(reg.Stats()); got != "cold hot warm" {
+		t.Errorf("Stats() = %s, want cold hot warm by name", got)
+	}
+	if got := names(reg.Hottest(0)); got != "hot warm cold" {
+		t.Errorf("Hottest(0) = %s, want hot warm cold", got)
+	}
+	if got := names(reg.Hottest(2)); got != "hot warm" {
+		t.Errorf("Hottest(2) = %s, want hot warm", got)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	defer runtime.SetMutexProfileFraction(runtime.SetMutexProfileFraction(0))
+	reg := registry(clock.NewFake(start))
+
+	var b strings.Builder
+	if err := reg.WriteReport(&b, 2); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	if len(lines) != 4 || lines[0] != "Hottest locks, by time spent waiting:" {
+		t.Fatalf("report is not a title, a header and two locks:\n%s", b.String())
+	}
+	for i, want := range [][]string{
+		{"LOCK", "KIND", "ACQUIRES", "CONTENDED", "WAIT", "TOTAL", "WAIT", "MAX", "HOLD", "MEAN", "HOLD", "MAX", "HOLDER"},
+		{"hot", "rwmutex", "3", "(+1", "read)", "1", "(25%)", "50ms", "50ms", "16.67ms", "50ms", "1", "reader(s)"},
+		{"warm", "mutex", "3", "1", "(33%)", "10ms", "10ms", "3.33ms", "10ms", "-"},
+	} {
+		if got := strings.Fields(lines[i+1]); strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("line %d = %q, want the fields %q", i+2, lines[i+1], want)
+		}
+	}
+	// Columns are aligned
+	if col := strings.Index(lines[1], "KIND"); strings.Index(lines[2], "rwmutex") != col || strings.Index(lines[3], "mutex") != col {
+		t.Errorf("KIND column not aligned:\n%s", b.String())
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	defer runtime.SetMutexProfileFraction(runtime.SetMutexProfileFraction(0))
+	reg := registry(clock.NewFake(start))
+	rec := httptest.NewRecorder()
+	reg.ServeHTTP(rec, httptest.NewRequest("GET", "/locks?n=1", nil))
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type = %q, want text/plain", ct)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, "\nhot ") || strings.Contains(body, "warm") {
+		t.Errorf("report for n=1 does not list only the hottest lock:\n%s", body)
+	}
+}
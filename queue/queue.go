@@ -0,0 +1,227 @@
+// Package queue provides a typed, bounded producer/consumer queue that
+// settles the "who closes the channel" question for multiple producers.
+//
+// Producers register with AddProducer and call Done when they have nothing
+// more to send; the queue closes itself exactly once, after the last
+// registered producer is done. A queue that never gets a producer, or whose
+// producers may never finish, is closed with Close instead. Consumers see
+// ErrClosed from Get (or the end of a range over Chan) once every queued
+// value has been received.
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go-concurrency/internal/closeguard"
+)
+
+// ErrClosed is returned by Get once the queue is closed and drained, by Put
+// after the producer is done or the queue is closed, and by AddProducer
+// once the queue is closed.
+var ErrClosed = errors.New("queue: closed")
+
+// ErrEmpty is returned by TryGet when no value is available right now but
+// the queue is still open.
+var ErrEmpty = errors.New("queue: empty")
+
+// Queue is a bounded FIFO queue of T values.
+type Queue[T any] struct {
+	ch    chan T
+	guard *closeguard.Guard // closes ch once no Put is sending on it
+
+	mu        sync.Mutex
+	producers int
+	closed    bool
+
+	puts atomic.Int64
+	gets atomic.Int64
+}
+
+// Stats is a point-in-time view of a Queue.
+type Stats struct {
+	Len       int   // values currently buffered
+	Cap       int   // maximum values buffered
+	Producers int   // registered producers not yet done
+	Puts      int64 // values accepted so far
+	Gets      int64 // values received through Get and TryGet so far
+	Closed    bool  // whether the last producer is done or Close was called
+}
+
+// New returns an open queue that buffers up to capacity values. A capacity
+// of zero makes every Put wait for a matching Get.
+func New[T any](capacity int) *Queue[T] {
+	return &Queue[T]{ch: make(chan T, capacity), guard: closeguard.New()}
+}
+
+// Producer is a handle for one producer of a Queue.
+type Producer[T any] struct {
+	q    *Queue[T]
+	quit chan struct{} // closed by Done, so Puts in flight give up
+	once sync.Once
+}
+
+// AddProducer registers a new producer. The queue stays open until every
+// registered producer has called Done.
+func (q *Queue[T]) AddProducer() (*Producer[T], error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return nil, ErrClosed
+	}
+	q.producers++
+	return &Producer[T]{q: q, quit: make(chan struct{})}, nil
+}
+
+// Put adds v to the queue, waiting for space until ctx is done. It returns
+// ErrClosed if the producer is done or the queue closed before v went in.
+func (p *Producer[T]) Put(ctx context.Context, v T) error {
+	if p.isDone() || !p.q.guard.Enter() {
+		return ErrClosed
+	}
+	defer p.q.guard.Exit()
+
+	select {
+	case p.q.ch <- v:
+		p.q.puts.Add(1)
+		return nil
+	case <-p.quit:
+		return ErrClosed
+	case <-p.q.guard.Done():
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryPut adds v to the queue only if there is space right now.
+func (p *Producer[T]) TryPut(v T) bool {
+	if p.isDone() || !p.q.guard.Enter() {
+		return false
+	}
+	defer p.q.guard.Exit()
+
+	select {
+	case p.q.ch <- v:
+		p.q.puts.Add(1)
+		return true
+	default:
+		return false
+	}
+}
+
+// Done marks the producer as finished. The last producer to call Done
+// closes the queue. Done does not wait for the producer's Puts still in
+// flight in other goroutines: they give up and return ErrClosed. Calling
+// Done more than once has no further effect.
+func (p *Producer[T]) Done() {
+	p.once.Do(func() {
+		close(p.quit)
+		p.q.producerDone()
+	})
+}
+
+func (p *Producer[T]) isDone() bool {
+	select {
+	case <-p.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *Queue[T]) producerDone() {
+	q.mu.Lock()
+	q.producers--
+	last := q.producers == 0
+	if last {
+		q.closed = true
+	}
+	q.mu.Unlock()
+
+	if last {
+		q.guard.Close(func() { close(q.ch) })
+	}
+}
+
+// Close closes the queue whether or not producers remain registered, for
+// a queue that may never get a producer or whose producers may never
+// finish. Puts in flight give up with ErrClosed, later Puts and AddProducer
+// fail, and consumers still receive the values already queued. Calling
+// Close more than once, or after the last producer is done, has no further
+// effect.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.guard.Close(func() { close(q.ch) })
+}
+
+// Get removes and returns the oldest value, waiting until one is available
+// or ctx is done. It returns ErrClosed once the queue is closed and empty.
+func (q *Queue[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case v, ok := <-q.ch:
+		if !ok {
+			return v, ErrClosed
+		}
+		q.gets.Add(1)
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// TryGet removes and returns the oldest value only if one is available
+// right now. Otherwise it returns ErrEmpty while the queue is open, and
+// ErrClosed once it is closed and drained, so a poller knows when to stop.
+func (q *Queue[T]) TryGet() (T, error) {
+	select {
+	case v, ok := <-q.ch:
+		if !ok {
+			return v, ErrClosed
+		}
+		q.gets.Add(1)
+		return v, nil
+	default:
+		var zero T
+		return zero, ErrEmpty
+	}
+}
+
+// Chan returns the underlying channel for use with range and select.
+// Values received from it directly are not counted in Stats.Gets.
+func (q *Queue[T]) Chan() <-chan T {
+	return q.ch
+}
+
+// Len returns the number of values currently buffered.
+func (q *Queue[T]) Len() int {
+	return len(q.ch)
+}
+
+// Cap returns the maximum number of values the queue buffers.
+func (q *Queue[T]) Cap() int {
+	return cap(q.ch)
+}
+
+// Stats returns a snapshot of the queue's counters.
+func (q *Queue[T]) Stats() Stats {
+	q.mu.Lock()
+	producers, closed := q.producers, q.closed
+	q.mu.Unlock()
+
+	return Stats{
+		Len:       q.Len(),
+		Cap:       q.Cap(),
+		Producers: producers,
+		Puts:      q.puts.Load(),
+		Gets:      q.gets.Load(),
+		Closed:    closed,
+	}
+}
@@ -0,0 +1,247 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-concurrency/leakcheck"
+	"go-concurrency/leakcheck/leaktest"
+)
+
+func TestTryGetTellsEmptyFromClosed(t *testing.T) {
+	q := New[int](2)
+	p, err := q.AddProducer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := q.TryGet(); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("TryGet on an open, empty queue: %v, want ErrEmpty", err)
+	}
+	if err := p.Put(context.Background(), 7); err != nil {
+		t.Fatal(err)
+	}
+	p.Done()
+
+	// Values queued before the close are still delivered
+	if v, err := q.TryGet(); v != 7 || err != nil {
+		t.Fatalf("TryGet = %d, %v; want 7, nil", v, err)
+	}
+	if _, err := q.TryGet(); !errors.Is(err, ErrClosed) {
+		t.Fatalf("TryGet on a closed, drained queue: %v, want ErrClosed", err)
+	}
+	if got := q.Stats().Gets; got != 1 {
+		t.Errorf("Stats().Gets = %d, want 1", got)
+	}
+}
+
+// A poller stops once the last producer is done instead of spinning.
+func TestPollUntilClosed(t *testing.T) {
+	q := New[int](1)
+	p, _ := q.AddProducer()
+	go func() {
+		defer p.Done()
+		for i := range 100 {
+			p.Put(context.Background(), i)
+		}
+	}()
+
+	sum := 0
+	for {
+		v, err := q.TryGet()
+		if errors.Is(err, ErrClosed) {
+			break
+		}
+		if err == nil {
+			sum += v
+		} else {
+			runtime.Gosched() // let the producer run
+		}
+	}
+	if sum != 4950 {
+		t.Errorf("sum = %d, want 4950", sum)
+	}
+}
+
+// Several producers finishing at once close the queue exactly once, after
+// the last of them, and nothing registers afterwards.
+func TestLastProducerClosesOnce(t *testing.T) {
+	q := New[int](4)
+	var wg sync.WaitGroup
+	for i := range 5 {
+		p, err := q.AddProducer()
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer p.Done()
+			defer p.Done() // a second Done changes nothing
+			for j := range 10 {
+				if err := p.Put(context.Background(), i*10+j); err != nil {
+					t.Errorf("Put: %v", err)
+				}
+			}
+		}()
+	}
+
+	seen := make(map[int]bool)
+	for v := range q.Chan() {
+		seen[v] = true
+	}
+	wg.Wait()
+	if len(seen) != 50 {
+		t.Errorf("received %d distinct values, want 50", len(seen))
+	}
+	if s := q.Stats(); !s.Closed || s.Producers != 0 || s.Puts != 50 {
+		t.Errorf("Stats() = %+v, want closed with 0 producers and 50 puts", s)
+	}
+	if _, err := q.AddProducer(); !errors.Is(err, ErrClosed) {
+		t.Errorf("AddProducer after close: %v, want ErrClosed", err)
+	}
+}
+
+func TestDoneTwiceCountsOnce(t *testing.T) {
+	q := New[int](1)
+	first, _ := q.AddProducer()
+	second, _ := q.AddProducer()
+	first.Done()
+	first.Done()
+	if s := q.Stats(); s.Closed || s.Producers != 1 {
+		t.Fatalf("Stats() = %+v after one producer's Done twice, want open with 1 producer", s)
+	}
+	if err := first.Put(context.Background(), 1); !errors.Is(err, ErrClosed) {
+		t.Errorf("Put after Done: %v, want ErrClosed", err)
+	}
+	second.Done()
+	if !q.Stats().Closed {
+		t.Error("queue still open after every producer is done")
+	}
+}
+
+func TestContextCancelsPutAndGet(t *testing.T) {
+	q := New[int](0)
+	p, _ := q.AddProducer()
+	defer p.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := p.Put(ctx, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Put with nobody receiving: %v, want DeadlineExceeded", err)
+	}
+	if _, err := q.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Get with nothing queued: %v, want DeadlineExceeded", err)
+	}
+	if s := q.Stats(); s.Puts != 0 || s.Gets != 0 {
+		t.Errorf("Stats() = %+v, want nothing counted", s)
+	}
+}
+
+func TestTryPut(t *testing.T) {
+	q := New[int](1)
+	p, _ := q.AddProducer()
+	if !p.TryPut(1) {
+		t.Fatal("TryPut into an empty queue failed")
+	}
+	if p.TryPut(2) {
+		t.Fatal("TryPut into a full queue succeeded")
+	}
+	if v, err := q.TryGet(); v != 1 || err != nil {
+		t.Fatalf("TryGet = %d, %v; want 1, nil", v, err)
+	}
+	p.Done()
+	if p.TryPut(3) {
+		t.Error("TryPut after Done succeeded")
+	}
+	if got := q.Stats().Puts; got != 1 {
+		t.Errorf("Stats().Puts = %d, want 1", got)
+	}
+}
+
+// Done returns without waiting for the producer's blocked Put, which gives
+// up, whether or not Done closed the queue.
+func TestDoneDoesNotWaitForPut(t *testing.T) {
+	for _, others := range []int{0, 1} {
+		leaktest.VerifyNone(t, func() {
+			q := New[int](0)
+			for range others {
+				if _, err := q.AddProducer(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			p, _ := q.AddProducer()
+			put := make(chan error)
+			go func() { put <- p.Put(context.Background(), 1) }()
+			for !blockedPut() {
+				runtime.Gosched()
+			}
+
+			p.Done()
+			if err := <-put; !errors.Is(err, ErrClosed) {
+				t.Errorf("blocked Put after Done with %d other producers: %v, want ErrClosed", others, err)
+			}
+			if closed := q.Stats().Closed; closed != (others == 0) {
+				t.Errorf("Closed = %v with %d other producers", closed, others)
+			}
+		})
+	}
+}
+
+// blockedPut reports whether some goroutine is blocked in Put.
+func blockedPut() bool {
+	for _, g := range leakcheck.Snapshot() {
+		if g.State == "select" && strings.Contains(g.Stack, "queue.(*Producer[...]).Put(") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestClose(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		// Without producers the queue would otherwise never close
+		empty := New[int](1)
+		empty.Close()
+		if _, err := empty.Get(context.Background()); !errors.Is(err, ErrClosed) {
+			t.Errorf("Get after Close: %v, want ErrClosed", err)
+		}
+		if _, err := empty.AddProducer(); !errors.Is(err, ErrClosed) {
+			t.Errorf("AddProducer after Close: %v, want ErrClosed", err)
+		}
+
+		q := New[int](1)
+		p, _ := q.AddProducer()
+		if err := p.Put(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+		put := make(chan error)
+		go func() { put <- p.Put(context.Background(), 2) }()
+		for !blockedPut() {
+			runtime.Gosched()
+		}
+		q.Close()
+		q.Close()
+		if err := <-put; !errors.Is(err, ErrClosed) {
+			t.Errorf("Put blocked on a full queue when it closed: %v, want ErrClosed", err)
+		}
+		if v, err := q.Get(context.Background()); v != 1 || err != nil {
+			t.Errorf("Get after Close = %d, %v; want the queued 1, nil", v, err)
+		}
+		if _, err := q.Get(context.Background()); !errors.Is(err, ErrClosed) {
+			t.Errorf("Get once drained: %v, want ErrClosed", err)
+		}
+		if p.TryPut(3) {
+			t.Error("TryPut after Close succeeded")
+		}
+		p.Done() // still allowed
+		if s := q.Stats(); !s.Closed || s.Producers != 0 {
+			t.Errorf("Stats() = %+v, want closed with 0 producers", s)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Package reqrep adds replies to channels: a client sends a request on a
+// shared channel and waits for the answer to its own request, and nobody
+// else's.
+//
+// Each request carries a correlation ID and a private, buffered reply
+// channel, so a server never blocks answering a client that gave up. The
+// client's context travels with the request: a client whose deadline
+// passes stops waiting at once, and the handler working on its request
+// sees its context canceled. Serve runs the server side with a fixed
+// number of concurrent handlers.
+package reqrep
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go-concurrency/internal/closeguard"
+)
+
+// ErrClosed is returned by Call once the Channel is closed.
+var ErrClosed = errors.New("reqrep: channel closed")
+
+// Channel carries requests of type Req answered with Resp. Create one with
+// New.
+type Channel[Req, Resp any] struct {
+	ch     chan *Request[Req, Resp]
+	guard  *closeguard.Guard
+	nextID atomic.Uint64
+}
+
+// New returns an open Channel that queues up to buffer requests no server
+// has picked up yet.
+func New[Req, Resp any](buffer int) *Channel[Req, Resp] {
+	return &Channel[Req, Resp]{
+		ch:    make(chan *Request[Req, Resp], max(buffer, 0)),
+		guard: closeguard.New(),
+	}
+}
+
+// Call sends req and waits for its reply until ctx is done. It returns the
+// handler's response and error, ctx.Err() if ctx is done first, or
+// ErrClosed if the Channel is closed before the request is queued.
+func (c *Channel[Req, Resp]) Call(ctx context.Context, req Req) (Resp, error) {
+	var zero Resp
+	r := &Request[Req, Resp]{
+		ID:    c.nextID.Add(1),
+		Value: req,
+		ctx:   ctx,
+		reply: make(chan result[Resp], 1),
+	}
+	if err := c.send(ctx, r); err != nil {
+		return zero, err
+	}
+	select {
+	case res := <-r.reply:
+		return res.value, res.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+func (c *Channel[Req, Resp]) send(ctx context.Context, r *Request[Req, Resp]) error {
+	if !c.guard.Enter() {
+		return ErrClosed
+	}
+	defer c.guard.Exit()
+	select {
+	case c.ch <- r:
+		return nil
+	case <-c.guard.Done():
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Requests returns the channel requests arrive on, for servers that need
+// their own loop; most should use Serve. It is closed by Close once every
+// queued request has been received.
+func (c *Channel[Req, Resp]) Requests() <-chan *Request[Req, Resp] { return c.ch }
+
+// Close stops new calls: later and blocked Calls return ErrClosed, while
+// requests already queued are still delivered to the server. Closing more
+// than once has no further effect.
+func (c *Channel[Req, Resp]) Close() {
+	c.guard.Close(func() { close(c.ch) })
+}
+
+// Request is one call waiting for a reply.
+type Request[Req, Resp any] struct {
+	ID    uint64 // correlation ID, unique per Channel
+	Value Req
+
+	ctx   context.Context
+	reply chan result[Resp]
+}
+
+type result[Resp any] struct {
+	value Resp
+	err   error
+}
+
+// Context returns the caller's context; it is done once the caller has
+// stopped waiting.
+func (r *Request[Req, Resp]) Context() context.Context { return r.ctx }
+
+// Reply answers the request. Only the first reply counts; Reply never
+// blocks and reports whether the caller was still waiting.
+func (r *Request[Req, Resp]) Reply(resp Resp, err error) bool {
+	select {
+	case r.reply <- result[Resp]{resp, err}:
+		return r.ctx.Err() == nil
+	default:
+		return false
+	}
+}
+
+// Handler answers one request. ctx is done when the caller stops waiting or
+// the server is stopped; id is the request's correlation ID.
+type Handler[Req, Resp any] func(ctx context.Context, id uint64, req Req) (Resp, error)
+
+// Serve answers requests on c with n concurrent calls to h until c is
+// closed and drained, in which case it returns nil, or ctx is done, in
+// which case it cancels the handlers still running, waits for them and
+// returns ctx.Err(). Requests whose caller has already given up are
+// skipped.
+func (c *Channel[Req, Resp]) Serve(ctx context.Context, n int, h Handler[Req, Resp]) error {
+	var wg sync.WaitGroup
+	for range max(n, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case r, ok := <-c.ch:
+					if !ok {
+						return
+					}
+					if r.ctx.Err() != nil {
+						continue
+					}
+					serve(ctx, r, h)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// serve runs h for r with a context done when either the caller or the
+// server is.
+func serve[Req, Resp any](server context.Context, r *Request[Req, Resp], h Handler[Req, Resp]) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	stop := context.AfterFunc(server, cancel)
+	defer stop()
+	r.Reply(h(ctx, r.ID, r.Value))
+}
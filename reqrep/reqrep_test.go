@@ -0,0 +1,179 @@
+package reqrep_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/reqrep"
+)
+
+// serve runs c.Serve in the background until the returned stop is called,
+// which returns Serve's error.
+func serve[Req, Resp any](c *reqrep.Channel[Req, Resp], n int, h reqrep.Handler[Req, Resp]) (stop func() error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- c.Serve(ctx, n, h) }()
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// Concurrent callers each get the reply to their own request, under an ID
+// no other request has.
+func TestConcurrentCalls(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		type reply struct {
+			id     uint64
+			double int
+		}
+		c := reqrep.New[int, reply](0)
+		stop := serve(c, 4, func(_ context.Context, id uint64, n int) (reply, error) {
+			time.Sleep(time.Millisecond) // let replies finish out of order
+			return reply{id, 2 * n}, nil
+		})
+		defer stop()
+
+		var (
+			wg  sync.WaitGroup
+			mu  sync.Mutex
+			ids = make(map[uint64]bool)
+		)
+		for n := range 50 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r, err := c.Call(context.Background(), n)
+				if err != nil || r.double != 2*n {
+					t.Errorf("Call(%d) = %+v, %v; want %d", n, r, err, 2*n)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if ids[r.id] {
+					t.Errorf("ID %d used twice", r.id)
+				}
+				ids[r.id] = true
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestCallDeadlineCancelsHandler(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		c := reqrep.New[string, string](0)
+		handlerErr := make(chan error, 1)
+		stop := serve(c, 1, func(ctx context.Context, _ uint64, _ string) (string, error) {
+			<-ctx.Done()
+			handlerErr <- ctx.Err()
+			return "", ctx.Err()
+		})
+		defer stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := c.Call(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Call() = %v, want %v", err, context.DeadlineExceeded)
+		}
+		select {
+		case err := <-handlerErr:
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("handler's context ended with %v, want %v", err, context.DeadlineExceeded)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("handler's context was not canceled when the caller's deadline passed")
+		}
+	})
+}
+
+func TestServeRunsAtMostNHandlers(t *testing.T) {
+	const n = 3
+	var running, most atomic.Int32
+	c := reqrep.New[int, int](0)
+	stop := serve(c, n, func(_ context.Context, _ uint64, v int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for m := most.Load(); cur > m && !most.CompareAndSwap(m, cur); m = most.Load() {
+		}
+		time.Sleep(2 * time.Millisecond)
+		return v, nil
+	})
+	defer stop()
+
+	var wg sync.WaitGroup
+	for v := range 30 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Call(context.Background(), v)
+		}()
+	}
+	wg.Wait()
+	if m := most.Load(); m > n || m == 0 {
+		t.Errorf("%d handlers ran at once, want between 1 and %d", m, n)
+	}
+}
+
+// Close turns away new calls but still delivers the requests queued before
+// it, after which Serve returns nil.
+func TestCloseDeliversQueuedRequests(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		c := reqrep.New[int, int](3)
+		replies := make(chan int, 3)
+		for v := range 3 {
+			go func() {
+				r, err := c.Call(context.Background(), v)
+				if err != nil {
+					t.Errorf("queued Call(%d) = %v, want a reply", v, err)
+				}
+				replies <- r
+			}()
+		}
+		for len(c.Requests()) < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		c.Close()
+		c.Close() // no effect
+		if _, err := c.Call(context.Background(), 99); !errors.Is(err, reqrep.ErrClosed) {
+			t.Errorf("Call() after Close = %v, want %v", err, reqrep.ErrClosed)
+		}
+
+		err := c.Serve(context.Background(), 2, func(_ context.Context, _ uint64, v int) (int, error) {
+			return v + 100, nil
+		})
+		if err != nil {
+			t.Errorf("Serve() on a closed Channel = %v, want nil once drained", err)
+		}
+		sum := 0
+		for range 3 {
+			sum += <-replies
+		}
+		if sum != 303 {
+			t.Errorf("replies summed to %d, want 303", sum)
+		}
+	})
+}
+
+func TestServeReturnsContextError(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		c := reqrep.New[int, int](0)
+		started := make(chan struct{})
+		stop := serve(c, 2, func(ctx context.Context, _ uint64, _ int) (int, error) {
+			close(started)
+			<-ctx.Done() // released by the server stopping
+			return 0, ctx.Err()
+		})
+		go c.Call(context.Background(), 1)
+		<-started
+
+		if err := stop(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Serve() = %v, want %v", err, context.Canceled)
+		}
+		c.Close()
+	})
+}
@@ -0,0 +1,294 @@
+// Package rwlock provides reader/writer locks with a choice of policy for
+// who goes first when readers and writers compete.
+//
+// sync.RWMutex blocks new readers once a writer is waiting. Other choices
+// trade throughput for fairness differently:
+//
+//   - ReaderPreferring lets readers in whenever no writer holds the lock,
+//     which maximizes read throughput but can starve writers for as long
+//     as readers keep overlapping;
+//   - WriterPreferring makes new readers wait while any writer waits,
+//     which can starve readers under a steady stream of writers;
+//   - PhaseFair alternates: readers that arrive while a writer holds or
+//     waits for the lock wait, and when the writer is done they all go in
+//     before the next writer, so neither side waits for more than one
+//     phase of the other.
+//
+// Every lock also supports TryLock and locking with a context, and keeps
+// Stats on how long readers and writers have waited.
+package rwlock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RWLocker is a reader/writer lock that can also be tried and waited for
+// with a context. *RWMutex implements it for every Policy.
+type RWLocker interface {
+	Lock()
+	Unlock()
+	RLock()
+	RUnlock()
+	TryLock() bool
+	TryRLock() bool
+	LockContext(ctx context.Context) error
+	RLockContext(ctx context.Context) error
+}
+
+// Policy decides whether a reader or a writer gets the lock when both are
+// waiting for it.
+type Policy int
+
+const (
+	// ReaderPreferring admits readers whenever no writer holds the lock.
+	ReaderPreferring Policy = iota
+	// WriterPreferring holds new readers back while a writer waits.
+	WriterPreferring
+	// PhaseFair alternates between a writer and the readers that arrived
+	// while it held or waited for the lock.
+	PhaseFair
+)
+
+func (p Policy) String() string {
+	switch p {
+	case ReaderPreferring:
+		return "reader-preferring"
+	case WriterPreferring:
+		return "writer-preferring"
+	case PhaseFair:
+		return "phase-fair"
+	}
+	return "unknown"
+}
+
+// RWMutex is a reader/writer lock with a Policy. Create one with New.
+type RWMutex struct {
+	policy Policy
+
+	mu sync.Mutex
+	// changed is closed and replaced whenever the state changes, waking
+	// every waiter to check whether it may go in.
+	changed        chan struct{}
+	readers        int  // holding the lock
+	writer         bool // holding the lock
+	waitingWriters int
+	waitingReaders int
+	// For PhaseFair: phase counts writer releases, and admitted is the
+	// number of waiting readers that arrived in an earlier phase and may
+	// go in ahead of waiting writers.
+	phase    uint64
+	admitted int
+
+	stats Stats
+}
+
+// New returns an unlocked RWMutex with the given policy.
+func New(policy Policy) *RWMutex {
+	return &RWMutex{policy: policy, changed: make(chan struct{})}
+}
+
+// Policy returns the lock's policy.
+func (rw *RWMutex) Policy() Policy { return rw.policy }
+
+// Lock locks rw for writing, waiting as long as it takes.
+func (rw *RWMutex) Lock() { rw.LockContext(context.Background()) }
+
+// RLock locks rw for reading, waiting as long as it takes.
+func (rw *RWMutex) RLock() { rw.RLockContext(context.Background()) }
+
+// TryLock locks rw for writing if it can without waiting, and reports
+// whether it did.
+func (rw *RWMutex) TryLock() bool {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if !rw.canWrite() {
+		return false
+	}
+	rw.writer = true
+	rw.stats.Writes++
+	return true
+}
+
+// TryRLock locks rw for reading if it can without waiting, and reports
+// whether it did.
+func (rw *RWMutex) TryRLock() bool {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if !rw.canRead(false) {
+		return false
+	}
+	rw.readers++
+	rw.stats.Reads++
+	return true
+}
+
+// LockContext locks rw for writing, or returns ctx.Err() if ctx is done
+// first, leaving rw as it was.
+func (rw *RWMutex) LockContext(ctx context.Context) error {
+	rw.mu.Lock()
+	if rw.canWrite() {
+		rw.writer = true
+		rw.stats.Writes++
+		rw.mu.Unlock()
+		return nil
+	}
+
+	start := time.Now()
+	rw.waitingWriters++
+	for {
+		changed := rw.changed
+		rw.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			rw.mu.Lock()
+			rw.waitingWriters--
+			rw.stats.AbandonedWrites++
+			rw.stats.waitedWrite(time.Since(start))
+			rw.broadcast() // readers held back by this writer may go in
+			rw.mu.Unlock()
+			return ctx.Err()
+		}
+		rw.mu.Lock()
+		if rw.canWrite() {
+			break
+		}
+	}
+	rw.waitingWriters--
+	rw.writer = true
+	rw.stats.Writes++
+	rw.stats.waitedWrite(time.Since(start))
+	rw.mu.Unlock()
+	return nil
+}
+
+// RLockContext locks rw for reading, or returns ctx.Err() if ctx is done
+// first, leaving rw as it was.
+func (rw *RWMutex) RLockContext(ctx context.Context) error {
+	rw.mu.Lock()
+	if rw.canRead(false) {
+		rw.readers++
+		rw.stats.Reads++
+		rw.mu.Unlock()
+		return nil
+	}
+
+	start := time.Now()
+	phase := rw.phase
+	rw.waitingReaders++
+	for {
+		changed := rw.changed
+		rw.mu.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			rw.mu.Lock()
+			rw.waitingReaders--
+			rw.stats.AbandonedReads++
+			rw.stats.waitedRead(time.Since(start))
+			if rw.phase != phase {
+				rw.admitted--
+				rw.broadcast() // a writer may have been waiting for this reader
+			}
+			rw.mu.Unlock()
+			return ctx.Err()
+		}
+		rw.mu.Lock()
+		if rw.canRead(rw.phase != phase) {
+			break
+		}
+	}
+	rw.waitingReaders--
+	if rw.phase != phase {
+		rw.admitted--
+	}
+	rw.readers++
+	rw.stats.Reads++
+	rw.stats.waitedRead(time.Since(start))
+	rw.mu.Unlock()
+	return nil
+}
+
+// Unlock unlocks rw for writing. It panics if rw is not locked for
+// writing.
+func (rw *RWMutex) Unlock() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if !rw.writer {
+		panic("rwlock: Unlock of unlocked RWMutex")
+	}
+	rw.writer = false
+	if rw.policy == PhaseFair {
+		// Every reader waiting now goes before the next writer
+		rw.phase++
+		rw.admitted = rw.waitingReaders
+	}
+	rw.broadcast()
+}
+
+// RUnlock undoes a single RLock call. It panics if rw is not locked for
+// reading.
+func (rw *RWMutex) RUnlock() {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	if rw.readers == 0 {
+		panic("rwlock: RUnlock of unlocked RWMutex")
+	}
+	rw.readers--
+	if rw.readers == 0 {
+		rw.broadcast()
+	}
+}
+
+// canRead reports whether a reader may go in now; early is true for a
+// reader that has waited since an earlier phase. rw.mu is held.
+func (rw *RWMutex) canRead(early bool) bool {
+	if rw.writer {
+		return false
+	}
+	switch rw.policy {
+	case WriterPreferring:
+		return rw.waitingWriters == 0
+	case PhaseFair:
+		return early || rw.waitingWriters == 0
+	}
+	return true
+}
+
+// canWrite reports whether a writer may go in now. rw.mu is held.
+func (rw *RWMutex) canWrite() bool {
+	if rw.writer || rw.readers > 0 {
+		return false
+	}
+	return rw.policy != PhaseFair || rw.admitted == 0
+}
+
+// broadcast wakes every waiter. rw.mu is held.
+func (rw *RWMutex) broadcast() {
+	close(rw.changed)
+	rw.changed = make(chan struct{})
+}
+
+// Stats counts a lock's acquisitions and the longest waits for it, which
+// is how starvation shows. A wait abandoned through a context counts
+// towards the longest wait too.
+type Stats struct {
+	Reads           int64         // read acquisitions
+	Writes          int64         // write acquisitions
+	AbandonedReads  int64         // RLockContext calls that gave up
+	AbandonedWrites int64         // LockContext calls that gave up
+	MaxReadWait     time.Duration // longest a reader waited
+	MaxWriteWait    time.Duration // longest a writer waited
+}
+
+func (s *Stats) waitedRead(d time.Duration)  { s.MaxReadWait = max(s.MaxReadWait, d) }
+func (s *Stats) waitedWrite(d time.Duration) { s.MaxWriteWait = max(s.MaxWriteWait, d) }
+
+// Stats returns the lock's counts so far.
+func (rw *RWMutex) Stats() Stats {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	return rw.stats
+}
@@ -0,0 +1,353 @@
+package rwlock
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var policies = []Policy{ReaderPreferring, WriterPreferring, PhaseFair}
+
+// waitUntil polls cond, called with rw.mu held, until it holds, failing t
+// after a second.
+func waitUntil(t *testing.T, rw *RWMutex, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		rw.mu.Lock()
+		ok := cond()
+		rw.mu.Unlock()
+		if ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("condition not reached within a second")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// acquired reports whether ch is closed within a second.
+func acquired(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	case <-time.After(time.Second):
+		return false
+	}
+}
+
+// goLock locks rw for writing in a new goroutine and returns a channel
+// closed once it holds the lock.
+func goLock(rw *RWMutex) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		rw.Lock()
+		close(done)
+	}()
+	return done
+}
+
+// goRLock locks rw for reading in a new goroutine and returns a channel
+// closed once it holds the lock.
+func goRLock(rw *RWMutex) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		rw.RLock()
+		close(done)
+	}()
+	return done
+}
+
+func TestTryLock(t *testing.T) {
+	for _, p := range policies {
+		t.Run(p.String(), func(t *testing.T) {
+			rw := New(p)
+			if !rw.TryRLock() || !rw.TryRLock() {
+				t.Fatal("TryRLock() = false on a lock held only by readers")
+			}
+			if rw.TryLock() {
+				t.Fatal("TryLock() = true while readers hold the lock")
+			}
+			rw.RUnlock()
+			rw.RUnlock()
+			if !rw.TryLock() {
+				t.Fatal("TryLock() = false on an unlocked lock")
+			}
+			if rw.TryLock() || rw.TryRLock() {
+				t.Fatal("lock tried successfully while a writer holds it")
+			}
+			rw.Unlock()
+		})
+	}
+}
+
+func TestMutualExclusion(t *testing.T) {
+	for _, p := range policies {
+		t.Run(p.String(), func(t *testing.T) {
+			rw := New(p)
+			var (
+				wg               sync.WaitGroup
+				readers, writers atomic.Int32
+				violations       atomic.Int32
+			)
+			for i := range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 200 {
+						if i%4 == 0 {
+							rw.Lock()
+							if writers.Add(1) != 1 || readers.Load() != 0 {
+								violations.Add(1)
+							}
+							runtime.Gosched()
+							writers.Add(-1)
+							rw.Unlock()
+						} else {
+							rw.RLock()
+							readers.Add(1)
+							if writers.Load() != 0 {
+								violations.Add(1)
+							}
+							runtime.Gosched()
+							readers.Add(-1)
+							rw.RUnlock()
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			if n := violations.Load(); n > 0 {
+				t.Errorf("%d times a writer shared the lock", n)
+			}
+			if s := rw.Stats(); s.Reads != 6*200 || s.Writes != 2*200 {
+				t.Errorf("Stats() counted %d reads and %d writes, want %d and %d", s.Reads, s.Writes, 6*200, 2*200)
+			}
+		})
+	}
+}
+
+func TestReaderPreferringAdmitsReadersPastWaitingWriter(t *testing.T) {
+	rw := New(ReaderPreferring)
+	rw.RLock()
+	wrote := goLock(rw)
+	waitUntil(t, rw, func() bool { return rw.waitingWriters == 1 })
+
+	if !rw.TryRLock() {
+		t.Fatal("TryRLock() = false while a writer waits, want readers to go first")
+	}
+	rw.RUnlock()
+	rw.RUnlock()
+	if !acquired(wrote) {
+		t.Fatal("writer did not get the lock once the readers left")
+	}
+	rw.Unlock()
+}
+
+func TestWriterPreferringHoldsReadersBackForWaitingWriter(t *testing.T) {
+	rw := New(WriterPreferring)
+	rw.RLock()
+	wrote := goLock(rw)
+	waitUntil(t, rw, func() bool { return rw.waitingWriters == 1 })
+
+	if rw.TryRLock() {
+		t.Fatal("TryRLock() = true while a writer waits")
+	}
+	read := goRLock(rw)
+	waitUntil(t, rw, func() bool { return rw.waitingReaders == 1 })
+	rw.RUnlock()
+	if !acquired(wrote) {
+		t.Fatal("writer did not get the lock once the reader left")
+	}
+	rw.Unlock()
+	if !acquired(read) {
+		t.Fatal("held back reader did not get the lock after the writer")
+	}
+	rw.RUnlock()
+}
+
+func TestPhaseFairAlternates(t *testing.T) {
+	rw := New(PhaseFair)
+	rw.Lock()
+	read := goRLock(rw)
+	waitUntil(t, rw, func() bool { return rw.waitingReaders == 1 })
+	wrote := goLock(rw)
+	waitUntil(t, rw, func() bool { return rw.waitingWriters == 1 })
+
+	// The reader that waited through the first writer goes before the next
+	rw.Unlock()
+	if !acquired(read) {
+		t.Fatal("reader waiting since the last write phase did not go in before the next writer")
+	}
+	// A reader arriving now waits for the writer's phase
+	if rw.TryRLock() {
+		t.Fatal("TryRLock() = true for a new reader while a writer waits")
+	}
+	rw.RUnlock()
+	if !acquired(wrote) {
+		t.Fatal("writer did not get the lock after the reader phase")
+	}
+	rw.Unlock()
+}
+
+func TestLockContextGivesUp(t *testing.T) {
+	for _, p := range policies {
+		t.Run(p.String(), func(t *testing.T) {
+			rw := New(p)
+			rw.RLock()
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := rw.LockContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("LockContext() = %v, want %v", err, context.DeadlineExceeded)
+			}
+			// The abandoned writer no longer holds readers back
+			if !rw.TryRLock() {
+				t.Fatal("TryRLock() = false after the waiting writer gave up")
+			}
+			rw.RUnlock()
+			rw.RUnlock()
+			if !rw.TryLock() {
+				t.Fatal("TryLock() = false on an unlocked lock")
+			}
+
+			ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			if err := rw.RLockContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("RLockContext() = %v, want %v", err, context.DeadlineExceeded)
+			}
+			rw.Unlock()
+
+			s := rw.Stats()
+			if s.AbandonedWrites != 1 || s.AbandonedReads != 1 {
+				t.Errorf("Stats() abandoned %d writes and %d reads, want 1 and 1", s.AbandonedWrites, s.AbandonedReads)
+			}
+			if s.MaxWriteWait < 10*time.Millisecond || s.MaxReadWait < 10*time.Millisecond {
+				t.Errorf("Stats() longest waits %v and %v, want at least the 10ms timeout", s.MaxWriteWait, s.MaxReadWait)
+			}
+		})
+	}
+}
+
+func TestUnlockOfUnlockedPanics(t *testing.T) {
+	for name, unlock := range map[string]func(*RWMutex){
+		"Unlock":  (*RWMutex).Unlock,
+		"RUnlock": (*RWMutex).RUnlock,
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s of an unlocked RWMutex did not panic", name)
+				}
+			}()
+			unlock(New(ReaderPreferring))
+		})
+	}
+}
+
+const (
+	// readHeavyReaders keep the lock read-locked most of the time, one of
+	// them being the benchmark loop itself
+	readHeavyReaders = 4
+	// writeTimeout is how long a write waits before it counts as starved
+	writeTimeout = 10 * time.Millisecond
+)
+
+// BenchmarkReadHeavy times a read while other readers hold the lock most
+// of the time and a writer keeps trying to take it, giving up after
+// writeTimeout. The writer runs on its own, so a starved write costs the
+// benchmark no more than the reads it lets through. Besides the time per
+// read it reports the longest wait of a write and of a read, the share of
+// writes that gave up and the writes done per read.
+func BenchmarkReadHeavy(b *testing.B) {
+	b.Run("sync", func(b *testing.B) { readHeavyBench(b, &syncRWMutex{}) })
+	for _, p := range policies {
+		b.Run(p.String(), func(b *testing.B) { readHeavyBench(b, New(p)) })
+	}
+}
+
+// readHeavyBench locks rw for reading b.N times, yielding while it holds
+// it so that readers overlap even on one CPU.
+func readHeavyBench(b *testing.B, rw RWLocker) {
+	var (
+		wg          sync.WaitGroup
+		stop        atomic.Bool
+		maxReadWait atomic.Int64
+	)
+	// read takes rw for reading once and records the wait.
+	read := func() {
+		start := time.Now()
+		rw.RLock()
+		wait := int64(time.Since(start))
+		for cur := maxReadWait.Load(); wait > cur && !maxReadWait.CompareAndSwap(cur, wait); cur = maxReadWait.Load() {
+		}
+		runtime.Gosched()
+		rw.RUnlock()
+	}
+	for range readHeavyReaders - 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !stop.Load() {
+				read()
+			}
+		}()
+	}
+
+	var (
+		maxWriteWait            time.Duration
+		writes, starved, trials int
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for !stop.Load() {
+			ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+			start := time.Now()
+			err := rw.LockContext(ctx)
+			maxWriteWait = max(maxWriteWait, time.Since(start))
+			cancel()
+			trials++
+			if err != nil {
+				starved++
+				continue
+			}
+			writes++
+			rw.Unlock()
+			runtime.Gosched()
+		}
+	}()
+
+	b.ResetTimer()
+	for range b.N {
+		read()
+	}
+	b.StopTimer()
+	stop.Store(true)
+	wg.Wait()
+
+	b.ReportMetric(float64(maxWriteWait.Microseconds()), "max-write-wait-µs")
+	b.ReportMetric(float64(time.Duration(maxReadWait.Load()).Microseconds()), "max-read-wait-µs")
+	b.ReportMetric(100*float64(starved)/float64(max(trials, 1)), "starved-writes-%")
+	b.ReportMetric(float64(writes)/float64(b.N), "writes/op")
+}
+
+// syncRWMutex is the standard library's lock as an RWLocker for comparison.
+// Its context methods cannot give up, so they wait for the lock.
+type syncRWMutex struct {
+	sync.RWMutex
+}
+
+func (rw *syncRWMutex) LockContext(context.Context) error {
+	rw.Lock()
+	return nil
+}
+
+func (rw *syncRWMutex) RLockContext(context.Context) error {
+	rw.RLock()
+	return nil
+}
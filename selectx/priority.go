@@ -0,0 +1,147 @@
+package selectx
+
+import (
+	"context"
+	"reflect"
+)
+
+// Priority receives from a fixed list of channels, preferring earlier ones:
+// a select statement picks at random among ready cases, while Priority
+// always takes the value from the first ready channel in the list, so a
+// control channel placed first is always drained before data.
+//
+// With WithWeights, strict priority gives way to weighted fairness so that
+// a busy high-priority channel cannot starve the others.
+//
+// A Priority must be used from one goroutine at a time.
+type Priority[T any] struct {
+	chans   []<-chan T
+	weights []int // nil for strict priority
+	credits []int
+	open    int
+
+	// cases is reused by every blocking wait; the last case is ctx.Done.
+	cases []reflect.SelectCase
+}
+
+// PriorityOption configures a Priority.
+type PriorityOption func(*priorityOptions)
+
+type priorityOptions struct {
+	weights []int
+}
+
+// WithWeights shares receives between channels that are all busy in
+// proportion to weights, one per channel in priority order: with weights
+// 3 and 1, the second channel gets one value in four while both have
+// values ready. Among channels that still have credit in the current
+// round, earlier ones are preferred. Weights below one count as one.
+func WithWeights(weights ...int) PriorityOption {
+	return func(o *priorityOptions) { o.weights = weights }
+}
+
+// NewPriority returns a Priority over chans, highest priority first.
+// NewPriority panics if WithWeights does not give one weight per channel.
+func NewPriority[T any](chans []<-chan T, opts ...PriorityOption) *Priority[T] {
+	var o priorityOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	p := &Priority[T]{
+		chans: append([]<-chan T(nil), chans...),
+		open:  len(chans),
+		cases: make([]reflect.SelectCase, len(chans)+1),
+	}
+	if o.weights != nil {
+		if len(o.weights) != len(chans) {
+			panic("selectx: WithWeights needs one weight per channel")
+		}
+		p.weights = make([]int, len(chans))
+		for i, w := range o.weights {
+			p.weights[i] = max(w, 1)
+		}
+		p.credits = append([]int(nil), p.weights...)
+	}
+	for i, ch := range p.chans {
+		p.cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+	return p
+}
+
+// Recv returns a value from the highest-priority channel that has one
+// ready, waiting until one does or ctx is done. Index is the channel's
+// position in the list. A closed channel is reported once, with OK false,
+// and then ignored; once every channel is closed Recv returns ErrClosed.
+//
+// Under strict priority, Recv never returns a value from a channel while a
+// value was already waiting in an earlier one when Recv was called.
+func (p *Priority[T]) Recv(ctx context.Context) (Received[T], error) {
+	if p.open == 0 {
+		return Received[T]{}, ErrClosed
+	}
+
+	// Channels with credit left in this round come first; if none of
+	// them is ready, any ready channel starts a new round
+	if r, ok := p.poll(true); ok {
+		return r, nil
+	}
+	if p.weights != nil {
+		if r, ok := p.poll(false); ok {
+			return r, nil
+		}
+	}
+
+	// Nothing is ready: wait for whichever channel is first
+	p.cases[len(p.chans)] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())}
+	i, v, ok := reflect.Select(p.cases)
+	if i == len(p.chans) {
+		return Received[T]{}, ctx.Err()
+	}
+	if p.weights != nil && p.credits[i] == 0 {
+		p.refill()
+	}
+	var value T
+	if ok {
+		value, _ = v.Interface().(T) // a nil interface value stays zero
+	}
+	return p.received(i, value, ok), nil
+}
+
+// poll tries each channel in priority order without blocking, skipping
+// those out of credit when credited is true.
+func (p *Priority[T]) poll(credited bool) (Received[T], bool) {
+	for i, ch := range p.chans {
+		if ch == nil || (p.weights != nil && (p.credits[i] > 0) != credited) {
+			continue
+		}
+		select {
+		case v, ok := <-ch:
+			if p.weights != nil && !credited {
+				p.refill()
+			}
+			return p.received(i, v, ok), true
+		default:
+		}
+	}
+	return Received[T]{}, false
+}
+
+// received records a receive from channel i.
+func (p *Priority[T]) received(i int, v T, ok bool) Received[T] {
+	if !ok {
+		// A closed channel is always ready; stop polling it
+		p.chans[i] = nil
+		p.cases[i].Chan = reflect.ValueOf((<-chan T)(nil))
+		p.open--
+		return Received[T]{Index: i}
+	}
+	if p.weights != nil {
+		p.credits[i]--
+	}
+	return Received[T]{Index: i, Value: v, OK: true}
+}
+
+// refill starts a new round of credits.
+func (p *Priority[T]) refill() {
+	copy(p.credits, p.weights)
+}
@@ -0,0 +1,251 @@
+// Package selectx waits on a set of channels chosen at run time, the way a
+// select statement waits on a fixed set.
+//
+// A Selector serves its channels in groups of eight. Each group is one
+// goroutine running an ordinary select statement over its slots, so
+// receiving costs no reflection and no allocation however many channels
+// are registered, and hundreds of channels need only a few dozen
+// goroutines. Channels can be added and removed while Recv is running; a
+// group left without channels stops its goroutine.
+//
+// A Priority receives from a fixed list of channels in order of preference
+// instead of at random.
+package selectx
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrClosed is returned by Add and Recv once the Selector is closed, and
+// by Priority.Recv once every channel is closed.
+var ErrClosed = errors.New("selectx: selector closed")
+
+// groupSize is the number of slots served by one goroutine. run has one
+// select case per slot.
+const groupSize = 8
+
+// Received is one value received by a Selector.
+type Received[T any] struct {
+	Index int  // the id Add returned for the channel
+	Value T    // the value received, or the zero value if OK is false
+	OK    bool // false if the channel was closed
+}
+
+// Selector receives from a dynamic set of channels of T. The zero value is
+// not usable; create one with New and release it with Close.
+type Selector[T any] struct {
+	out  chan Received[T]
+	done chan struct{}
+
+	mu     sync.Mutex
+	closed bool
+	nextID int
+	cases  map[int]slot[T]
+	groups []*group[T]
+}
+
+// slot locates a registered channel.
+type slot[T any] struct {
+	g *group[T]
+	i int
+}
+
+// New returns an empty Selector.
+func New[T any]() *Selector[T] {
+	return &Selector[T]{
+		out:   make(chan Received[T]),
+		done:  make(chan struct{}),
+		cases: make(map[int]slot[T]),
+	}
+}
+
+// Add registers ch and returns the id Recv reports for values from it.
+// Ids are never reused.
+func (s *Selector[T]) Add(ch <-chan T) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, ErrClosed
+	}
+
+	var g *group[T]
+	for _, candidate := range s.groups {
+		if candidate.n < groupSize {
+			g = candidate
+			break
+		}
+	}
+	if g == nil {
+		g = &group[T]{ctl: make(chan control[T])}
+		s.groups = append(s.groups, g)
+		go g.run(s.out, s.done)
+	}
+
+	i := 0
+	for g.used[i] {
+		i++
+	}
+	id := s.nextID
+	s.nextID++
+	g.used[i] = true
+	g.n++
+	s.cases[id] = slot[T]{g, i}
+
+	// The group goroutine never takes s.mu, so it is always free to accept
+	g.ctl <- control[T]{slot: i, ch: ch, id: id}
+	return id, nil
+}
+
+// Remove unregisters the channel with the given id and reports whether it
+// was registered. Once Remove returns nothing more is received from the
+// channel, but a value received from it just before may still be
+// reported by Recv.
+func (s *Selector[T]) Remove(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sl, ok := s.cases[id]
+	if !ok || s.closed {
+		return false
+	}
+	s.release(id, sl)
+	return true
+}
+
+// Len returns the number of registered channels.
+func (s *Selector[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.cases)
+}
+
+// Recv waits until one of the registered channels delivers a value or is
+// closed. A closed channel is reported once, with OK false, and is then
+// removed. Recv returns ctx.Err() if ctx is done first and ErrClosed once
+// the Selector is closed.
+func (s *Selector[T]) Recv(ctx context.Context) (Received[T], error) {
+	select {
+	case r := <-s.out:
+		if !r.OK {
+			s.mu.Lock()
+			if sl, ok := s.cases[r.Index]; ok {
+				s.release(r.Index, sl)
+			}
+			s.mu.Unlock()
+		}
+		return r, nil
+	case <-ctx.Done():
+		return Received[T]{}, ctx.Err()
+	case <-s.done:
+		return Received[T]{}, ErrClosed
+	}
+}
+
+// Close stops every group goroutine. Values they had received but not yet
+// reported are discarded.
+func (s *Selector[T]) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+// release frees the slot of id and empties it in its group, stopping the
+// group's goroutine if no channel is left in it. Callers hold s.mu.
+func (s *Selector[T]) release(id int, sl slot[T]) {
+	delete(s.cases, id)
+	sl.g.used[sl.i] = false
+	sl.g.n--
+	if sl.g.n > 0 {
+		sl.g.ctl <- control[T]{slot: sl.i}
+		return
+	}
+	s.groups = slices.DeleteFunc(s.groups, func(g *group[T]) bool { return g == sl.g })
+	sl.g.ctl <- control[T]{stop: true}
+}
+
+// control sets one slot of a group; a nil ch empties it. A stop control
+// ends the group's goroutine, discarding any value it has not delivered.
+type control[T any] struct {
+	slot int
+	ch   <-chan T
+	id   int
+	stop bool
+}
+
+// group is up to groupSize channels served by one goroutine. used and n
+// are guarded by the Selector's mutex; the channels themselves belong to
+// the goroutine and change only through ctl.
+type group[T any] struct {
+	ctl  chan control[T]
+	used [groupSize]bool
+	n    int
+}
+
+func (g *group[T]) run(out chan<- Received[T], done <-chan struct{}) {
+	var (
+		cs  [groupSize]<-chan T
+		ids [groupSize]int
+	)
+	apply := func(c control[T]) bool {
+		if c.stop {
+			return false
+		}
+		cs[c.slot], ids[c.slot] = c.ch, c.id
+		return true
+	}
+	recv := func(i int, v T, ok bool) Received[T] {
+		if !ok {
+			cs[i] = nil // a closed channel is always ready; stop selecting on it
+		}
+		return Received[T]{Index: ids[i], Value: v, OK: ok}
+	}
+
+	for {
+		var r Received[T]
+		select {
+		case v, ok := <-cs[0]:
+			r = recv(0, v, ok)
+		case v, ok := <-cs[1]:
+			r = recv(1, v, ok)
+		case v, ok := <-cs[2]:
+			r = recv(2, v, ok)
+		case v, ok := <-cs[3]:
+			r = recv(3, v, ok)
+		case v, ok := <-cs[4]:
+			r = recv(4, v, ok)
+		case v, ok := <-cs[5]:
+			r = recv(5, v, ok)
+		case v, ok := <-cs[6]:
+			r = recv(6, v, ok)
+		case v, ok := <-cs[7]:
+			r = recv(7, v, ok)
+		case c := <-g.ctl:
+			if !apply(c) {
+				return
+			}
+			continue
+		case <-done:
+			return
+		}
+
+		// Keep accepting control messages while the value waits for Recv,
+		// so Add and Remove never wait on a slow consumer
+		for delivered := false; !delivered; {
+			select {
+			case out <- r:
+				delivered = true
+			case c := <-g.ctl:
+				if !apply(c) {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}
+}
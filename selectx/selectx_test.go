@@ -0,0 +1,392 @@
+package selectx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/selectx"
+)
+
+// recvAsync calls Recv in a goroutine and returns where its result goes.
+func recvAsync[T any](s *selectx.Selector[T]) <-chan selectx.Received[T] {
+	got := make(chan selectx.Received[T], 1)
+	go func() {
+		r, _ := s.Recv(context.Background())
+		got <- r
+	}()
+	return got
+}
+
+// Every value comes with the id Add returned for its channel, across
+// several groups.
+func TestSelectorReportsIDs(t *testing.T) {
+	s := selectx.New[int]()
+	defer s.Close()
+	sent := make(map[int]int) // value sent, by id
+	for i := range 20 {
+		ch := make(chan int, 1)
+		id, err := s.Add(ch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, dup := sent[id]; dup {
+			t.Fatalf("Add returned id %d twice", id)
+		}
+		sent[id] = i
+		ch <- i
+	}
+	if n := s.Len(); n != 20 {
+		t.Errorf("Len() = %d, want 20", n)
+	}
+	for range 20 {
+		r, err := s.Recv(context.Background())
+		if err != nil || !r.OK {
+			t.Fatalf("Recv() = %+v, %v", r, err)
+		}
+		if want, ok := sent[r.Index]; !ok || r.Value != want {
+			t.Fatalf("Recv() = %d from id %d, which was sent %d", r.Value, r.Index, want)
+		}
+		delete(sent, r.Index)
+	}
+	if len(sent) != 0 {
+		t.Errorf("no value reported for ids %v", sent)
+	}
+}
+
+func TestSelectorAddRemoveWhileRecvBlocked(t *testing.T) {
+	s := selectx.New[string]()
+	defer s.Close()
+	got := recvAsync(s)
+
+	time.Sleep(10 * time.Millisecond) // Recv is waiting on no channels
+	ch := make(chan string)
+	id, err := s.Add(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch <- "hello"
+	if r := <-got; r != (selectx.Received[string]{Index: id, Value: "hello", OK: true}) {
+		t.Fatalf("Recv() = %+v, want hello from %d", r, id)
+	}
+
+	got = recvAsync(s)
+	if !s.Remove(id) {
+		t.Fatalf("Remove(%d) = false for a registered channel", id)
+	}
+	if s.Remove(id) {
+		t.Errorf("second Remove(%d) = true", id)
+	}
+	select {
+	case ch <- "ignored":
+		t.Fatal("a removed channel was still received from")
+	case r := <-got:
+		t.Fatalf("Recv() = %+v with no channels", r)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	other := make(chan string, 1)
+	otherID, _ := s.Add(other)
+	other <- "again"
+	if r := <-got; r.Index != otherID || r.Value != "again" {
+		t.Errorf("Recv() = %+v, want again from %d", r, otherID)
+	}
+}
+
+func TestSelectorReportsClosedChannelOnce(t *testing.T) {
+	s := selectx.New[int]()
+	defer s.Close()
+	ch := make(chan int)
+	id, _ := s.Add(ch)
+	close(ch)
+
+	if r, err := s.Recv(context.Background()); r != (selectx.Received[int]{Index: id}) || err != nil {
+		t.Fatalf("Recv() = %+v, %v; want %d closed", r, err, id)
+	}
+	if n := s.Len(); n != 0 {
+		t.Errorf("Len() = %d after the close was reported, want 0", n)
+	}
+	if s.Remove(id) {
+		t.Errorf("Remove(%d) = true for a channel already freed", id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if r, err := s.Recv(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Recv() = %+v, %v; want the close reported only once", r, err)
+	}
+}
+
+func TestSelectorClose(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		s := selectx.New[int]()
+		for range 10 {
+			s.Add(make(chan int))
+		}
+		blocked := make(chan error)
+		go func() {
+			_, err := s.Recv(context.Background())
+			blocked <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		s.Close()
+		if err := <-blocked; !errors.Is(err, selectx.ErrClosed) {
+			t.Errorf("blocked Recv() = %v, want %v", err, selectx.ErrClosed)
+		}
+		if _, err := s.Recv(context.Background()); !errors.Is(err, selectx.ErrClosed) {
+			t.Errorf("Recv() after Close = %v, want %v", err, selectx.ErrClosed)
+		}
+		if _, err := s.Add(make(chan int)); !errors.Is(err, selectx.ErrClosed) {
+			t.Errorf("Add() after Close = %v, want %v", err, selectx.ErrClosed)
+		}
+		s.Close() // no effect
+	})
+}
+
+// Groups whose channels are all gone, removed or reported closed, stop
+// their goroutines without waiting for Close.
+func TestSelectorStopsEmptyGroups(t *testing.T) {
+	s := selectx.New[int]()
+	defer s.Close()
+	leaktest.VerifyNone(t, func() {
+		var ids []int
+		for range 20 {
+			id, _ := s.Add(make(chan int))
+			ids = append(ids, id)
+		}
+		closed := make(chan int)
+		closedID, _ := s.Add(closed)
+		close(closed)
+		if r, _ := s.Recv(context.Background()); r.Index != closedID || r.OK {
+			t.Fatalf("Recv() = %+v, want %d closed", r, closedID)
+		}
+		for _, id := range ids {
+			s.Remove(id)
+		}
+	})
+
+	// The Selector still works once its groups are gone
+	ch := make(chan int, 1)
+	id, _ := s.Add(ch)
+	ch <- 1
+	if r, err := s.Recv(context.Background()); r.Index != id || err != nil {
+		t.Errorf("Recv() = %+v, %v; want 1 from %d", r, err, id)
+	}
+}
+
+func TestPriorityPrefersEarlierChannels(t *testing.T) {
+	control, data := make(chan string, 1), make(chan string, 2)
+	data <- "row 1"
+	data <- "row 2"
+	control <- "stop"
+	p := selectx.NewPriority([]<-chan string{control, data})
+
+	for _, want := range []selectx.Received[string]{{0, "stop", true}, {1, "row 1", true}, {1, "row 2", true}} {
+		if got, err := p.Recv(context.Background()); got != want || err != nil {
+			t.Fatalf("Recv() = %+v, %v; want %+v, nil", got, err, want)
+		}
+	}
+}
+
+func TestPriorityWeights(t *testing.T) {
+	high, low := make(chan string, 8), make(chan string, 8)
+	for range 8 {
+		high <- "H"
+		low <- "L"
+	}
+	p := selectx.NewPriority([]<-chan string{high, low}, selectx.WithWeights(3, 1))
+	var order strings.Builder
+	for range 8 {
+		r, _ := p.Recv(context.Background())
+		order.WriteString(r.Value)
+	}
+	if got, want := order.String(), "HHHLHHHL"; got != want {
+		t.Errorf("received %s with weights 3:1, want %s", got, want)
+	}
+}
+
+func TestPriorityClosedChannels(t *testing.T) {
+	a, b := make(chan int), make(chan int, 1)
+	close(a)
+	b <- 1
+	close(b)
+	p := selectx.NewPriority([]<-chan int{a, b})
+
+	for _, want := range []selectx.Received[int]{{Index: 0}, {1, 1, true}, {Index: 1}} {
+		if got, err := p.Recv(context.Background()); got != want || err != nil {
+			t.Fatalf("Recv() = %+v, %v; want %+v, nil", got, err, want)
+		}
+	}
+	if _, err := p.Recv(context.Background()); !errors.Is(err, selectx.ErrClosed) {
+		t.Errorf("Recv() with every channel closed = %v, want %v", err, selectx.ErrClosed)
+	}
+}
+
+func TestPriorityRecvGivesUp(t *testing.T) {
+	p := selectx.NewPriority([]<-chan int{make(chan int)})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := p.Recv(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Recv() = %v, want %v", err, context.Canceled)
+	}
+}
+
+// Under strict priority with concurrent senders, whatever an earlier
+// channel held before Recv was called is taken first.
+func TestPriorityOrderingUnderConcurrentSenders(t *testing.T) {
+	const senders, perSender = 3, 2000
+	chans := make([]<-chan int, senders)
+	var sent [senders]atomic.Int64
+	var wg sync.WaitGroup
+	for i := range senders {
+		ch := make(chan int, 16)
+		chans[i] = ch
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(ch)
+			for v := range perSender {
+				ch <- v
+				sent[i].Add(1) // counted only once the value is in ch
+			}
+		}()
+	}
+	p := selectx.NewPriority(chans)
+	var received [senders]int64
+	total := 0
+	for {
+		var before [senders]int64
+		for i := range before {
+			before[i] = sent[i].Load()
+		}
+		r, err := p.Recv(context.Background())
+		if err != nil {
+			break // ErrClosed: every sender is done
+		}
+		if !r.OK {
+			continue
+		}
+		for higher := range r.Index {
+			if received[higher] < before[higher] {
+				t.Fatalf("receive %d came from channel %d while %d value(s) waited in channel %d",
+					total, r.Index, before[higher]-received[higher], higher)
+			}
+		}
+		received[r.Index]++
+		total++
+		runtime.Gosched() // let the senders refill so channels compete
+	}
+	wg.Wait()
+	if total != senders*perSender {
+		t.Errorf("received %d values, want %d", total, senders*perSender)
+	}
+}
+
+// BenchmarkRecv receives from 8 and 256 channels through a Selector,
+// through reflect.Select, and through a goroutine per channel merging into
+// one.
+func BenchmarkRecv(b *testing.B) {
+	for _, n := range []int{8, 256} {
+		b.Run(fmt.Sprintf("%d/selector", n), func(b *testing.B) {
+			chans, stop := feeds(b.N, n)
+			defer stop()
+			s := selectx.New[int]()
+			defer s.Close()
+			for _, ch := range chans {
+				if _, err := s.Add(ch); err != nil {
+					b.Fatal(err)
+				}
+			}
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				if _, err := s.Recv(ctx); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("%d/reflect", n), func(b *testing.B) {
+			chans, stop := feeds(b.N, n)
+			defer stop()
+			cases := make([]reflect.SelectCase, len(chans))
+			for i, ch := range chans {
+				cases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				reflect.Select(cases)
+			}
+		})
+		b.Run(fmt.Sprintf("%d/merge", n), func(b *testing.B) {
+			chans, stop := feeds(b.N, n)
+			defer stop()
+			type tagged struct{ index, value int }
+			out := make(chan tagged)
+			done := make(chan struct{})
+			var wg sync.WaitGroup
+			for i, ch := range chans {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for v := range ch {
+						select {
+						case out <- tagged{i, v}:
+						case <-done:
+							return
+						}
+					}
+				}()
+			}
+			defer wg.Wait()
+			defer close(done)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for range b.N {
+				<-out
+			}
+		})
+	}
+}
+
+// feeds returns n channels that together carry total values, sent round
+// robin by one producer. stop ends the producer and closes the channels.
+func feeds(total, n int) ([]<-chan int, func()) {
+	chans := make([]chan int, n)
+	recv := make([]<-chan int, n)
+	for i := range chans {
+		chans[i] = make(chan int, 1)
+		recv[i] = chans[i]
+	}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		defer func() {
+			for _, ch := range chans {
+				close(ch)
+			}
+		}()
+		for i := range total {
+			select {
+			case chans[i%n] <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return recv, func() {
+		close(done)
+		<-finished
+	}
+}
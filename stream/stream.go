@@ -0,0 +1,96 @@
+// Package stream provides a channel that carries the reason it was closed.
+//
+// Receiving from a closed Go channel only reports ok == false, so a
+// consumer cannot tell a producer that finished from one that failed, and
+// pipelines end up pairing every data channel with an error channel. A
+// Stream folds the two together: Recv returns io.EOF after a normal Close
+// and the producer's error after CloseWithError, once every value sent
+// before the close has been received.
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"go-concurrency/internal/closeguard"
+)
+
+// ErrClosed is returned by Send once the Stream is closed.
+var ErrClosed = errors.New("stream: send on closed stream")
+
+// Stream is a channel of T values closed with an error. Create one with New.
+type Stream[T any] struct {
+	ch    chan T
+	guard *closeguard.Guard
+	err   error // set by the close
+}
+
+// New returns an open Stream that buffers up to buffer values.
+func New[T any](buffer int) *Stream[T] {
+	return &Stream[T]{ch: make(chan T, max(buffer, 0)), guard: closeguard.New()}
+}
+
+// Send sends v, waiting for room until ctx is done. It returns ErrClosed
+// if the Stream is or becomes closed first.
+func (s *Stream[T]) Send(ctx context.Context, v T) error {
+	if !s.guard.Enter() {
+		return ErrClosed
+	}
+	defer s.guard.Exit()
+	select {
+	case s.ch <- v:
+		return nil
+	case <-s.guard.Done():
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Recv receives the next value, waiting until ctx is done. Once the Stream
+// is closed and drained it returns io.EOF after a normal close, or the
+// error passed to CloseWithError.
+func (s *Stream[T]) Recv(ctx context.Context) (T, error) {
+	select {
+	case v, ok := <-s.ch:
+		if !ok {
+			return v, s.Err()
+		}
+		return v, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Close closes the Stream normally; receivers get io.EOF after the last
+// value. It is CloseWithError(nil).
+func (s *Stream[T]) Close() {
+	s.CloseWithError(nil)
+}
+
+// CloseWithError closes the Stream so that receivers get err after the
+// last value, or io.EOF if err is nil. Only the first close takes effect.
+// Blocked senders return ErrClosed.
+func (s *Stream[T]) CloseWithError(err error) {
+	if err == nil {
+		err = io.EOF
+	}
+	s.guard.Close(func() {
+		s.err = err
+		close(s.ch)
+	})
+}
+
+// Err returns the reason the Stream was closed: nil while it is open,
+// io.EOF after a normal close, or the error passed to CloseWithError.
+func (s *Stream[T]) Err() error {
+	if !s.guard.Closed() {
+		return nil
+	}
+	return s.err
+}
+
+// Len returns the number of values buffered.
+func (s *Stream[T]) Len() int { return len(s.ch) }
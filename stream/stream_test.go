@@ -0,0 +1,166 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/stream"
+)
+
+var errParse = errors.New("bad line")
+
+// parse is a first pipeline stage: it sends the numbers in lines and
+// closes its Stream with the first parse error.
+func parse(ctx context.Context, lines ...string) *stream.Stream[int] {
+	out := stream.New[int](0)
+	go func() {
+		for _, line := range lines {
+			n, err := strconv.Atoi(line)
+			if err != nil {
+				out.CloseWithError(errParse)
+				return
+			}
+			if err := out.Send(ctx, n); err != nil {
+				out.CloseWithError(err)
+				return
+			}
+		}
+		out.Close()
+	}()
+	return out
+}
+
+// square is a middle stage: it passes on the squares of its input and
+// closes with the reason its input was closed.
+func square(ctx context.Context, in *stream.Stream[int]) *stream.Stream[int] {
+	out := stream.New[int](1)
+	go func() {
+		for {
+			n, err := in.Recv(ctx)
+			if err == io.EOF {
+				out.Close()
+				return
+			}
+			if err != nil {
+				out.CloseWithError(err)
+				return
+			}
+			if err := out.Send(ctx, n*n); err != nil {
+				out.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// drain receives until the Stream ends and returns what it got and why it
+// ended.
+func drain(ctx context.Context, s *stream.Stream[int]) ([]int, error) {
+	var got []int
+	for {
+		v, err := s.Recv(ctx)
+		if err != nil {
+			return got, err
+		}
+		got = append(got, v)
+	}
+}
+
+// The reason the first stage closed reaches the end of the pipeline after
+// every value sent before it.
+func TestPipelineCarriesCloseReason(t *testing.T) {
+	for _, tt := range []struct {
+		lines   []string
+		want    []int
+		wantErr error
+	}{
+		{[]string{"1", "2", "3"}, []int{1, 4, 9}, io.EOF},
+		{[]string{"1", "2", "x", "4"}, []int{1, 4}, errParse},
+		{nil, nil, io.EOF},
+	} {
+		leaktest.VerifyNone(t, func() {
+			ctx := context.Background()
+			got, err := drain(ctx, square(ctx, parse(ctx, tt.lines...)))
+			if !slices.Equal(got, tt.want) || !errors.Is(err, tt.wantErr) {
+				t.Errorf("pipeline over %q = %v, %v; want %v, %v", tt.lines, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Cancelling the pipeline's context ends every stage, each closing its
+// Stream with the context's error.
+func TestPipelineCancellation(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		lines := make([]string, 100)
+		for i := range lines {
+			lines[i] = strconv.Itoa(i)
+		}
+		out := square(ctx, parse(ctx, lines...))
+		if v, err := out.Recv(ctx); v != 0 || err != nil {
+			t.Fatalf("Recv() = %d, %v; want 0, nil", v, err)
+		}
+		cancel()
+
+		_, err := drain(context.Background(), out)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("cancelled pipeline ended with %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestSendAndRecvGiveUp(t *testing.T) {
+	s := stream.New[int](1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := s.Recv(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Recv() on an empty Stream = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if err := s.Send(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send() on a full Stream = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestClose(t *testing.T) {
+	s := stream.New[int](1)
+	if err := s.Err(); err != nil {
+		t.Errorf("Err() while open = %v, want nil", err)
+	}
+	s.Send(context.Background(), 1)
+	blocked := make(chan error)
+	go func() { blocked <- s.Send(context.Background(), 2) }()
+
+	time.Sleep(10 * time.Millisecond)
+	s.CloseWithError(errParse)
+	s.Close() // only the first close counts
+	if err := <-blocked; !errors.Is(err, stream.ErrClosed) {
+		t.Errorf("blocked Send() = %v, want %v", err, stream.ErrClosed)
+	}
+	if err := s.Send(context.Background(), 3); !errors.Is(err, stream.ErrClosed) {
+		t.Errorf("Send() after close = %v, want %v", err, stream.ErrClosed)
+	}
+	if !errors.Is(s.Err(), errParse) {
+		t.Errorf("Err() = %v, want %v", s.Err(), errParse)
+	}
+
+	// What was buffered before the close is still received
+	if v, err := s.Recv(context.Background()); v != 1 || err != nil {
+		t.Errorf("Recv() = %d, %v; want 1, nil", v, err)
+	}
+	for range 2 {
+		if _, err := s.Recv(context.Background()); !errors.Is(err, errParse) {
+			t.Errorf("Recv() once drained = %v, want %v", err, errParse)
+		}
+	}
+}
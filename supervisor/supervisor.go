@@ -0,0 +1,131 @@
+// Package supervisor launches goroutines that never crash the process.
+//
+// A Supervisor recovers panics raised by the functions it starts, converts
+// them into *PanicError values carrying the recovered value and the stack of
+// the panicking goroutine, reports every failure to a pluggable Sink and lets
+// the caller Wait for all launched goroutines to collect a joined error.
+//
+// It replaces the hand-rolled recover/errChan/time.After block from the
+// "Goroutine Error Handling" demo in 1-basic-goroutine: there is no channel
+// capacity to get right and no collector loop that can hang or time out.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError is the error produced when a supervised function panics.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic recovered: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, so errors.Is and
+// errors.As see through a recovered panic(err).
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Sink receives every error produced by a supervised goroutine as soon as
+// it happens. Implementations must be safe for concurrent use.
+type Sink interface {
+	Report(err error)
+}
+
+// SinkFunc adapts an ordinary function to the Sink interface.
+type SinkFunc func(err error)
+
+// Report calls f(err).
+func (f SinkFunc) Report(err error) { f(err) }
+
+// Option configures a Supervisor.
+type Option func(*Supervisor)
+
+// WithSink sets the Sink that failures are reported to.
+func WithSink(sink Sink) Option {
+	return func(s *Supervisor) {
+		s.sink = sink
+	}
+}
+
+// Supervisor starts goroutines, recovers their panics and collects their
+// errors. The zero value is ready to use and reports to no sink.
+type Supervisor struct {
+	sink Sink
+
+	wg   sync.WaitGroup
+	mu   sync.Mutex
+	errs []error
+}
+
+// New returns a Supervisor configured with opts.
+func New(opts ...Option) *Supervisor {
+	s := &Supervisor{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Go runs fn in a new goroutine. A non-nil return value or a recovered panic
+// is reported to the sink and remembered for Wait. If ctx is already done,
+// fn is not started and ctx.Err() is recorded instead.
+func (s *Supervisor) Go(ctx context.Context, fn func(ctx context.Context) error) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.record(run(ctx, fn))
+	}()
+}
+
+// Wait blocks until every goroutine started with Go has returned and
+// reports their failures joined with errors.Join, in completion order.
+// It returns nil if all of them succeeded.
+func (s *Supervisor) Wait() error {
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errors.Join(s.errs...)
+}
+
+func (s *Supervisor) record(err error) {
+	if err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.errs = append(s.errs, err)
+	s.mu.Unlock()
+
+	if s.sink != nil {
+		s.sink.Report(err)
+	}
+}
+
+// run calls fn and converts a panic into a *PanicError.
+func run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,117 @@
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"go-concurrency/supervisor"
+)
+
+// crash panics with v from a named function, so its stack can be found.
+func crash(v any) { panic(v) }
+
+func TestPanicBecomesPanicError(t *testing.T) {
+	var s supervisor.Supervisor
+	s.Go(context.Background(), func(context.Context) error {
+		crash("boom")
+		return nil
+	})
+
+	var pe *supervisor.PanicError
+	if err := s.Wait(); !errors.As(err, &pe) {
+		t.Fatalf("Wait() = %v, want a *PanicError", err)
+	}
+	if pe.Value != "boom" || pe.Error() != "panic recovered: boom" {
+		t.Errorf("PanicError = %v with value %v, want boom", pe, pe.Value)
+	}
+	if !strings.Contains(string(pe.Stack), "supervisor_test.crash") {
+		t.Errorf("Stack does not show the panicking function:\n%s", pe.Stack)
+	}
+	if pe.Unwrap() != nil {
+		t.Errorf("Unwrap() = %v for a non-error value, want nil", pe.Unwrap())
+	}
+}
+
+func TestPanicWithErrorUnwraps(t *testing.T) {
+	var s supervisor.Supervisor
+	s.Go(context.Background(), func(context.Context) error {
+		crash(fs.ErrPermission)
+		return nil
+	})
+
+	err := s.Wait()
+	if !errors.Is(err, fs.ErrPermission) {
+		t.Errorf("Wait() = %v, want it to wrap %v", err, fs.ErrPermission)
+	}
+	var pe *supervisor.PanicError
+	if !errors.As(err, &pe) || pe.Unwrap() != fs.ErrPermission {
+		t.Errorf("Wait() = %v, want a *PanicError unwrapping to %v", err, fs.ErrPermission)
+	}
+}
+
+func TestSinkSeesEveryFailure(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		reported []error
+	)
+	s := supervisor.New(supervisor.WithSink(supervisor.SinkFunc(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, err)
+	})))
+	errA := errors.New("a")
+	s.Go(context.Background(), func(context.Context) error { return errA })
+	s.Go(context.Background(), func(context.Context) error { return nil })
+	s.Go(context.Background(), func(context.Context) error { crash("b"); return nil })
+	s.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	var pe *supervisor.PanicError
+	if len(reported) != 2 || !slices.Contains(reported, errA) ||
+		!slices.ContainsFunc(reported, func(err error) bool { return errors.As(err, &pe) }) {
+		t.Errorf("sink got %v, want a and the panic", reported)
+	}
+}
+
+func TestWaitJoinsErrors(t *testing.T) {
+	var s supervisor.Supervisor
+	if err := s.Wait(); err != nil {
+		t.Fatalf("Wait() with nothing started = %v, want nil", err)
+	}
+
+	errA, errB := errors.New("a"), errors.New("b")
+	s.Go(context.Background(), func(context.Context) error { return errA })
+	s.Go(context.Background(), func(context.Context) error { return nil })
+	s.Go(context.Background(), func(context.Context) error { return errB })
+	err := s.Wait()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Wait() = %v, want both errors", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 2 {
+		t.Errorf("Wait() joined %d errors, want 2", n)
+	}
+}
+
+func TestDoneContextSkipsFunction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var s supervisor.Supervisor
+	ran := false
+	s.Go(ctx, func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	if err := s.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() = %v, want %v", err, context.Canceled)
+	}
+	if ran {
+		t.Error("function ran with a context that was already done")
+	}
+}
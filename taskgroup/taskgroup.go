@@ -0,0 +1,70 @@
+// Package taskgroup runs goroutines and hands back a Handle for each one, so
+// callers can wait on completion deterministically instead of sleeping and
+// hoping the goroutines have finished.
+package taskgroup
+
+import (
+	"sync"
+	"time"
+)
+
+// Group tracks a set of goroutines started with Go or Run.
+// The zero value is ready to use.
+type Group struct {
+	wg sync.WaitGroup
+}
+
+// Handle refers to a single goroutine started by a Group.
+type Handle[T any] struct {
+	done    chan struct{}
+	value   T
+	err     error
+	elapsed time.Duration
+}
+
+// Go runs fn in a new goroutine tracked by g.
+func (g *Group) Go(fn func()) *Handle[struct{}] {
+	return Run(g, func() (struct{}, error) {
+		fn()
+		return struct{}{}, nil
+	})
+}
+
+// Run runs fn in a new goroutine tracked by g and returns a Handle that
+// carries its result once it has finished.
+func Run[T any](g *Group, fn func() (T, error)) *Handle[T] {
+	h := &Handle[T]{done: make(chan struct{})}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer close(h.done)
+
+		start := time.Now()
+		defer func() { h.elapsed = time.Since(start) }()
+		h.value, h.err = fn()
+	}()
+	return h
+}
+
+// Wait blocks until every goroutine started on g has finished.
+func (g *Group) Wait() {
+	g.wg.Wait()
+}
+
+// Done returns a channel that is closed when the goroutine has finished.
+func (h *Handle[T]) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result blocks until the goroutine has finished and returns what it returned.
+func (h *Handle[T]) Result() (T, error) {
+	<-h.done
+	return h.value, h.err
+}
+
+// Elapsed blocks until the goroutine has finished and reports how long it ran.
+func (h *Handle[T]) Elapsed() time.Duration {
+	<-h.done
+	return h.elapsed
+}
@@ -0,0 +1,83 @@
+package taskgroup
+
+import (
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// settle waits for the goroutine count to drop back to baseline, failing t
+// if it does not within a second.
+func settle(t *testing.T, baseline int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d goroutines still running, want %d", runtime.NumGoroutine(), baseline)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWaitWaitsForEveryGoroutine(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var g Group
+	var ran atomic.Int32
+	for range 50 {
+		g.Go(func() { ran.Add(1) })
+	}
+	g.Wait()
+
+	if got := ran.Load(); got != 50 {
+		t.Errorf("ran %d goroutines before Wait returned, want 50", got)
+	}
+	settle(t, baseline)
+}
+
+func TestRunReturnsResult(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var g Group
+	errBoom := errors.New("boom")
+	ok := Run(&g, func() (int, error) { return 42, nil })
+	failed := Run(&g, func() (string, error) { return "", errBoom })
+
+	if v, err := ok.Result(); v != 42 || err != nil {
+		t.Errorf("Result() = %d, %v; want 42, nil", v, err)
+	}
+	if _, err := failed.Result(); !errors.Is(err, errBoom) {
+		t.Errorf("Result() error = %v, want %v", err, errBoom)
+	}
+	g.Wait()
+	settle(t, baseline)
+}
+
+func TestDoneClosesOnlyAfterFinish(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+
+	var g Group
+	release := make(chan struct{})
+	h := g.Go(func() { <-release })
+
+	select {
+	case <-h.Done():
+		t.Fatal("Done closed before the goroutine finished")
+	default:
+	}
+	close(release)
+	<-h.Done()
+	g.Wait()
+	settle(t, baseline)
+}
+
+func TestElapsed(t *testing.T) {
+	var g Group
+	h := g.Go(func() { time.Sleep(10 * time.Millisecond) })
+	if d := h.Elapsed(); d < 10*time.Millisecond {
+		t.Errorf("Elapsed() = %v, want at least 10ms", d)
+	}
+	g.Wait()
+}
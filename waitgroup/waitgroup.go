@@ -0,0 +1,135 @@
+// Package waitgroup provides a Group that starts its goroutines itself, so
+// the count can never go negative or be incremented too late.
+//
+// With sync.WaitGroup the caller pairs Add with Done, and two mistakes are
+// easy: calling Done once too often, which panics far from the cause, and
+// calling Add after Wait has seen the counter at zero, which lets Wait
+// return while the new goroutine runs. A Group counts a goroutine in Go,
+// before it starts, and out when its function returns, so the first cannot
+// happen, and Go panics with an explanation when it is called too late.
+//
+// Goroutines may start more goroutines of their own Group while it is
+// waited for: the count has not reached zero while they run. A Group can
+// limit how many functions run at once and keeps every error returned.
+package waitgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Option configures a Group.
+type Option func(*Group)
+
+// WithLimit lets at most n functions run at once; Go blocks until one of
+// them returns. n <= 0 means no limit.
+func WithLimit(n int) Option {
+	return func(g *Group) {
+		if n > 0 {
+			g.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// Group runs goroutines and waits for them. The zero value is ready to use
+// and has no limit. A Group is finished once Wait has seen it with no
+// goroutine left, and must not be reused.
+type Group struct {
+	sem chan struct{} // holds a token per running function, if limited
+
+	mu       sync.Mutex
+	n        int  // goroutines started and not yet returned
+	waited   bool // Wait has been called
+	finished bool // the count reached zero after Wait was called
+	idle     chan struct{}
+	errs     []error
+}
+
+// New returns a Group configured with opts.
+func New(opts ...Option) *Group {
+	g := &Group{}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Go runs fn in a new goroutine, once the limit allows. Its error, if any,
+// is kept for Wait.
+//
+// Go panics if the Group is finished: a goroutine started then would not
+// be waited for. Call Go before Wait, or from a goroutine of the Group.
+// With a limit, calling Go from a goroutine of the Group can deadlock if
+// every running function does so.
+func (g *Group) Go(fn func() error) {
+	g.mu.Lock()
+	if g.finished {
+		g.mu.Unlock()
+		panic("waitgroup: Go called after Wait returned; start goroutines before Wait " +
+			"or from the Group's own goroutines, and use a new Group to start over")
+	}
+	g.n++
+	g.mu.Unlock()
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	go func() {
+		err := fn()
+		if g.sem != nil {
+			<-g.sem
+		}
+		g.done(err)
+	}()
+}
+
+// done counts out a goroutine that returned err.
+func (g *Group) done(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if err != nil {
+		g.errs = append(g.errs, err)
+	}
+	g.n--
+	if g.n == 0 && g.waited {
+		g.finish()
+	}
+}
+
+// finish marks g finished and wakes Wait; g.mu is held.
+func (g *Group) finish() {
+	g.finished = true
+	if g.idle != nil {
+		close(g.idle)
+	}
+}
+
+// Wait blocks until every goroutine started with Go has returned,
+// including those started meanwhile, and returns their errors joined with
+// errors.Join in the order they returned, or nil. If ctx is done first it
+// returns ctx.Err() and the goroutines keep running; Wait may then be
+// called again.
+func (g *Group) Wait(ctx context.Context) error {
+	g.mu.Lock()
+	g.waited = true
+	if g.n == 0 && !g.finished {
+		g.finish()
+	}
+	if g.idle == nil && !g.finished {
+		g.idle = make(chan struct{})
+	}
+	idle, finished := g.idle, g.finished
+	g.mu.Unlock()
+
+	if !finished {
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
@@ -0,0 +1,138 @@
+package waitgroup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-concurrency/leakcheck/leaktest"
+	"go-concurrency/waitgroup"
+)
+
+// The five goroutines of the sync module's counter example, limited to
+// two at a time: every increment is counted and the limit holds.
+func TestCounter(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		var (
+			mu            sync.Mutex
+			counter       int
+			running, most atomic.Int32
+		)
+		g := waitgroup.New(waitgroup.WithLimit(2))
+		for range 5 {
+			g.Go(func() error {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for cur := most.Load(); n > cur && !most.CompareAndSwap(cur, n); cur = most.Load() {
+				}
+				for range 1000 {
+					mu.Lock()
+					counter++
+					mu.Unlock()
+				}
+				return nil
+			})
+		}
+		if err := g.Wait(context.Background()); err != nil {
+			t.Fatalf("Wait() = %v, want nil", err)
+		}
+		if counter != 5000 {
+			t.Errorf("counter = %d, want 5000", counter)
+		}
+		if n := most.Load(); n > 2 {
+			t.Errorf("%d functions ran at once, want at most 2", n)
+		}
+	})
+}
+
+func TestZeroGroup(t *testing.T) {
+	var g waitgroup.Group
+	if err := g.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() on an empty Group = %v, want nil", err)
+	}
+}
+
+func TestWaitJoinsErrors(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	var g waitgroup.Group
+	g.Go(func() error { return errA })
+	g.Go(func() error { return nil })
+	g.Go(func() error { return errB })
+
+	err := g.Wait(context.Background())
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Wait() = %v, want both errors", err)
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 2 {
+		t.Errorf("Wait() joined %d errors, want 2", n)
+	}
+}
+
+// Goroutines started by goroutines of the Group while Wait waits are
+// waited for too.
+func TestGoFromGroupWhileWaiting(t *testing.T) {
+	var (
+		g       waitgroup.Group
+		visited atomic.Int32
+	)
+	var spawn func(depth int) error
+	spawn = func(depth int) error {
+		visited.Add(1)
+		if depth > 0 {
+			for range 2 {
+				g.Go(func() error { return spawn(depth - 1) })
+			}
+		}
+		return nil
+	}
+	g.Go(func() error { return spawn(4) })
+	if err := g.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+	if n := visited.Load(); n != 31 {
+		t.Errorf("visited %d nodes, want 31", n)
+	}
+}
+
+func TestWaitGivesUp(t *testing.T) {
+	leaktest.VerifyNone(t, func() {
+		release := make(chan struct{})
+		var g waitgroup.Group
+		g.Go(func() error {
+			<-release
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := g.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Wait() = %v, want %v", err, context.DeadlineExceeded)
+		}
+		// The Group is not finished, so it still takes goroutines
+		g.Go(func() error { return nil })
+		close(release)
+		if err := g.Wait(context.Background()); err != nil {
+			t.Errorf("second Wait() = %v, want nil", err)
+		}
+	})
+}
+
+func TestGoAfterWaitPanics(t *testing.T) {
+	var g waitgroup.Group
+	g.Go(func() error { return nil })
+	if err := g.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() = %v, want nil", err)
+	}
+
+	defer func() {
+		r, _ := recover().(string)
+		if !strings.Contains(r, "Go called after Wait returned") {
+			t.Errorf("recovered %q, want the Go after Wait panic", r)
+		}
+	}()
+	g.Go(func() error { return nil })
+}